@@ -57,6 +57,8 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Bastion.AMI = restored.Spec.Bastion.AMI
 	dst.Spec.Bastion.DisableIngressRules = restored.Spec.Bastion.DisableIngressRules
 	dst.Spec.Bastion.InstanceType = restored.Spec.Bastion.InstanceType
+	dst.Spec.Bastion.Mode = restored.Spec.Bastion.Mode
+	dst.Spec.Bastion.HighAvailability = restored.Spec.Bastion.HighAvailability
 	dst.Spec.ImageLookupFormat = restored.Spec.ImageLookupFormat
 	dst.Spec.ImageLookupOrg = restored.Spec.ImageLookupOrg
 	dst.Spec.ImageLookupBaseOS = restored.Spec.ImageLookupBaseOS
@@ -64,6 +66,12 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.ControlPlaneLoadBalancer = restored.Spec.ControlPlaneLoadBalancer
 	}
 	dst.Spec.NetworkSpec.CNI = restored.Spec.NetworkSpec.CNI
+	dst.Spec.InstanceEvents = restored.Spec.InstanceEvents
+	dst.Spec.S3Bucket = restored.Spec.S3Bucket
+	dst.Spec.Adoption = restored.Spec.Adoption
+	dst.Status.InstanceEventsQueueURL = restored.Status.InstanceEventsQueueURL
+	dst.Status.SessionManagerTargets = restored.Status.SessionManagerTargets
+	dst.Status.Adoption = restored.Status.Adoption
 	dst.Status.FailureDomains = restored.Status.FailureDomains
 	dst.Status.Network.APIServerELB.AvailabilityZones = restored.Status.Network.APIServerELB.AvailabilityZones
 	dst.Status.Network.APIServerELB.Attributes.CrossZoneLoadBalancing = restored.Status.Network.APIServerELB.Attributes.CrossZoneLoadBalancing
@@ -40,12 +40,29 @@ func (src *AWSMachine) ConvertTo(dstRaw conversion.Hub) error {
 	restoreAWSMachineSpec(&restored.Spec, &dst.Spec)
 	// Manual conversion for conditions
 	dst.SetConditions(restored.GetConditions())
+	dst.Status.NetworkInterfaces = restored.Status.NetworkInterfaces
+	dst.Status.ScheduledEvents = restored.Status.ScheduledEvents
+	dst.Status.LastHealthCheck = restored.Status.LastHealthCheck
+	dst.Status.Image = restored.Status.Image
+	dst.Status.BootstrapDataObject = restored.Status.BootstrapDataObject
+	dst.Status.BootDiagnostics = restored.Status.BootDiagnostics
 	return nil
 }
 
 func restoreAWSMachineSpec(restored, dst *infrav1alpha3.AWSMachineSpec) {
 	dst.ImageLookupFormat = restored.ImageLookupFormat
 	dst.ImageLookupBaseOS = restored.ImageLookupBaseOS
+	dst.ImageLookupArchitecture = restored.ImageLookupArchitecture
+	dst.Platform = restored.Platform
+	dst.AMI.SSMParameter = restored.AMI.SSMParameter
+	if dst.Subnet != nil && restored.Subnet != nil {
+		dst.Subnet.SSMParameter = restored.Subnet.SSMParameter
+	}
+	for i := range dst.AdditionalSecurityGroups {
+		if i < len(restored.AdditionalSecurityGroups) {
+			dst.AdditionalSecurityGroups[i].SSMParameter = restored.AdditionalSecurityGroups[i].SSMParameter
+		}
+	}
 
 	// Note this may override the manual conversion in Convert_v1alpha2_AWSMachineSpec_To_v1alpha3_AWSMachineSpec.
 	if restored.RootVolume != nil {
@@ -59,6 +76,8 @@ func restoreAWSMachineSpec(restored, dst *infrav1alpha3.AWSMachineSpec) {
 
 	// manual conversion for UncompressedUserData
 	dst.UncompressedUserData = restored.UncompressedUserData
+	dst.UserDataFormat = restored.UserDataFormat
+	dst.Ignition = restored.Ignition
 
 	if restored.SpotMarketOptions != nil {
 		dst.SpotMarketOptions = restored.SpotMarketOptions.DeepCopy()
@@ -72,7 +91,29 @@ func restoreAWSMachineSpec(restored, dst *infrav1alpha3.AWSMachineSpec) {
 	}
 
 	dst.Tenancy = restored.Tenancy
+
+	if restored.PlacementGroup != nil {
+		dst.PlacementGroup = restored.PlacementGroup.DeepCopy()
+	}
+
+	if restored.CapacityReservation != nil {
+		dst.CapacityReservation = restored.CapacityReservation.DeepCopy()
+	}
+
+	if restored.DedicatedHost != nil {
+		dst.DedicatedHost = restored.DedicatedHost.DeepCopy()
+	}
+
+	if restored.SecondaryNetworkInterfaces != nil {
+		dst.SecondaryNetworkInterfaces = restored.SecondaryNetworkInterfaces.DeepCopy()
+	}
+
+	if restored.BootDiagnostics != nil {
+		dst.BootDiagnostics = restored.BootDiagnostics.DeepCopy()
+	}
+
 	dst.CloudInit.SecureSecretsBackend = restored.CloudInit.SecureSecretsBackend
+	dst.CloudInit.KMSKeyID = restored.CloudInit.KMSKeyID
 }
 
 // ConvertFrom converts from the Hub version (v1alpha3) to this version.
@@ -185,6 +226,11 @@ func Convert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus(in *infrav1a
 	return nil
 }
 
+// Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference converts from the Hub version (v1alpha3) of the AWSResourceReference to this version.
+func Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(in *infrav1alpha3.AWSResourceReference, out *AWSResourceReference, s apiconversion.Scope) error {
+	return autoConvert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(in, out, s)
+}
+
 // Convert_v1alpha2_Instance_To_v1alpha3_Instance converts this Instance to the Hub version (v1alpha3).
 func Convert_v1alpha2_Instance_To_v1alpha3_Instance(in *Instance, out *infrav1alpha3.Instance, s apiconversion.Scope) error {
 	if err := autoConvert_v1alpha2_Instance_To_v1alpha3_Instance(in, out, s); err != nil {
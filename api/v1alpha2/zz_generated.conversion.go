@@ -129,11 +129,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.AWSResourceReference)(nil), (*AWSResourceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(a.(*v1alpha3.AWSResourceReference), b.(*AWSResourceReference), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*BuildParams)(nil), (*v1alpha3.BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_BuildParams_To_v1alpha3_BuildParams(a.(*BuildParams), b.(*v1alpha3.BuildParams), scope)
 	}); err != nil {
@@ -299,6 +294,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha3.AWSResourceReference)(nil), (*AWSResourceReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(a.(*v1alpha3.AWSResourceReference), b.(*AWSResourceReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.ClassicELBAttributes)(nil), (*ClassicELBAttributes)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ClassicELBAttributes_To_v1alpha2_ClassicELBAttributes(a.(*v1alpha3.ClassicELBAttributes), b.(*ClassicELBAttributes), scope)
 	}); err != nil {
@@ -451,6 +451,9 @@ func autoConvert_v1alpha3_AWSClusterSpec_To_v1alpha2_AWSClusterSpec(in *v1alpha3
 	// WARNING: in.ImageLookupOrg requires manual conversion: does not exist in peer-type
 	// WARNING: in.ImageLookupBaseOS requires manual conversion: does not exist in peer-type
 	// WARNING: in.Bastion requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceEvents requires manual conversion: does not exist in peer-type
+	// WARNING: in.S3Bucket requires manual conversion: does not exist in peer-type
+	// WARNING: in.Adoption requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -472,6 +475,9 @@ func autoConvert_v1alpha3_AWSClusterStatus_To_v1alpha2_AWSClusterStatus(in *v1al
 	// WARNING: in.FailureDomains requires manual conversion: does not exist in peer-type
 	// WARNING: in.Bastion requires manual conversion: inconvertible types (*sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3.Instance vs sigs.k8s.io/cluster-api-provider-aws/api/v1alpha2.Instance)
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceEventsQueueURL requires manual conversion: does not exist in peer-type
+	// WARNING: in.SessionManagerTargets requires manual conversion: does not exist in peer-type
+	// WARNING: in.Adoption requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -489,6 +495,7 @@ func autoConvert_v1alpha3_AWSLoadBalancerSpec_To_v1alpha2_AWSLoadBalancerSpec(in
 	out.Scheme = (*ClassicELBScheme)(unsafe.Pointer(in.Scheme))
 	// WARNING: in.CrossZoneLoadBalancing requires manual conversion: does not exist in peer-type
 	// WARNING: in.Subnets requires manual conversion: does not exist in peer-type
+	// WARNING: in.Name requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -576,9 +583,27 @@ func autoConvert_v1alpha2_AWSMachineSpec_To_v1alpha3_AWSMachineSpec(in *AWSMachi
 	out.AdditionalTags = *(*v1alpha3.Tags)(unsafe.Pointer(&in.AdditionalTags))
 	out.IAMInstanceProfile = in.IAMInstanceProfile
 	out.PublicIP = (*bool)(unsafe.Pointer(in.PublicIP))
-	out.AdditionalSecurityGroups = *(*[]v1alpha3.AWSResourceReference)(unsafe.Pointer(&in.AdditionalSecurityGroups))
+	if in.AdditionalSecurityGroups != nil {
+		in, out := &in.AdditionalSecurityGroups, &out.AdditionalSecurityGroups
+		*out = make([]v1alpha3.AWSResourceReference, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalSecurityGroups = nil
+	}
 	// WARNING: in.AvailabilityZone requires manual conversion: does not exist in peer-type
-	out.Subnet = (*v1alpha3.AWSResourceReference)(unsafe.Pointer(in.Subnet))
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(v1alpha3.AWSResourceReference)
+		if err := Convert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Subnet = nil
+	}
 	if err := v1.Convert_string_To_Pointer_string(&in.SSHKeyName, &out.SSHKeyName, s); err != nil {
 		return err
 	}
@@ -596,23 +621,50 @@ func autoConvert_v1alpha3_AWSMachineSpec_To_v1alpha2_AWSMachineSpec(in *v1alpha3
 	// WARNING: in.ImageLookupFormat requires manual conversion: does not exist in peer-type
 	out.ImageLookupOrg = in.ImageLookupOrg
 	// WARNING: in.ImageLookupBaseOS requires manual conversion: does not exist in peer-type
+	// WARNING: in.ImageLookupArchitecture requires manual conversion: does not exist in peer-type
+	// WARNING: in.Platform requires manual conversion: does not exist in peer-type
 	out.InstanceType = in.InstanceType
 	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
 	out.IAMInstanceProfile = in.IAMInstanceProfile
 	out.PublicIP = (*bool)(unsafe.Pointer(in.PublicIP))
-	out.AdditionalSecurityGroups = *(*[]AWSResourceReference)(unsafe.Pointer(&in.AdditionalSecurityGroups))
+	if in.AdditionalSecurityGroups != nil {
+		in, out := &in.AdditionalSecurityGroups, &out.AdditionalSecurityGroups
+		*out = make([]AWSResourceReference, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalSecurityGroups = nil
+	}
 	// WARNING: in.FailureDomain requires manual conversion: does not exist in peer-type
-	out.Subnet = (*AWSResourceReference)(unsafe.Pointer(in.Subnet))
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(AWSResourceReference)
+		if err := Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Subnet = nil
+	}
 	if err := v1.Convert_Pointer_string_To_string(&in.SSHKeyName, &out.SSHKeyName, s); err != nil {
 		return err
 	}
 	// WARNING: in.RootVolume requires manual conversion: does not exist in peer-type
 	// WARNING: in.NonRootVolumes requires manual conversion: does not exist in peer-type
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
+	// WARNING: in.SecondaryNetworkInterfaces requires manual conversion: does not exist in peer-type
 	// WARNING: in.UncompressedUserData requires manual conversion: does not exist in peer-type
+	// WARNING: in.UserDataFormat requires manual conversion: does not exist in peer-type
+	// WARNING: in.Ignition requires manual conversion: does not exist in peer-type
 	// WARNING: in.CloudInit requires manual conversion: inconvertible types (sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3.CloudInit vs *sigs.k8s.io/cluster-api-provider-aws/api/v1alpha2.CloudInit)
 	// WARNING: in.SpotMarketOptions requires manual conversion: does not exist in peer-type
 	// WARNING: in.Tenancy requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlacementGroup requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityReservation requires manual conversion: does not exist in peer-type
+	// WARNING: in.DedicatedHost requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootDiagnostics requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -629,6 +681,12 @@ func autoConvert_v1alpha3_AWSMachineStatus_To_v1alpha2_AWSMachineStatus(in *v1al
 	out.Ready = in.Ready
 	out.Addresses = *(*[]apiv1alpha2.MachineAddress)(unsafe.Pointer(&in.Addresses))
 	out.InstanceState = (*InstanceState)(unsafe.Pointer(in.InstanceState))
+	// WARNING: in.NetworkInterfaces requires manual conversion: does not exist in peer-type
+	// WARNING: in.ScheduledEvents requires manual conversion: does not exist in peer-type
+	// WARNING: in.LastHealthCheck requires manual conversion: does not exist in peer-type
+	// WARNING: in.Image requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootDiagnostics requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapDataObject requires manual conversion: does not exist in peer-type
 	// WARNING: in.FailureReason requires manual conversion: does not exist in peer-type
 	// WARNING: in.FailureMessage requires manual conversion: does not exist in peer-type
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
@@ -765,16 +823,12 @@ func Convert_v1alpha2_AWSResourceReference_To_v1alpha3_AWSResourceReference(in *
 
 func autoConvert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(in *v1alpha3.AWSResourceReference, out *AWSResourceReference, s conversion.Scope) error {
 	out.ID = (*string)(unsafe.Pointer(in.ID))
+	// WARNING: in.SSMParameter requires manual conversion: does not exist in peer-type
 	out.ARN = (*string)(unsafe.Pointer(in.ARN))
 	out.Filters = *(*[]Filter)(unsafe.Pointer(&in.Filters))
 	return nil
 }
 
-// Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference is an autogenerated conversion function.
-func Convert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(in *v1alpha3.AWSResourceReference, out *AWSResourceReference, s conversion.Scope) error {
-	return autoConvert_v1alpha3_AWSResourceReference_To_v1alpha2_AWSResourceReference(in, out, s)
-}
-
 func autoConvert_v1alpha2_BuildParams_To_v1alpha3_BuildParams(in *BuildParams, out *v1alpha3.BuildParams, s conversion.Scope) error {
 	out.Lifecycle = v1alpha3.ResourceLifecycle(in.Lifecycle)
 	out.ClusterName = in.ClusterName
@@ -923,6 +977,7 @@ func autoConvert_v1alpha3_CloudInit_To_v1alpha2_CloudInit(in *v1alpha3.CloudInit
 	out.SecretCount = in.SecretCount
 	out.SecretPrefix = in.SecretPrefix
 	// WARNING: in.SecureSecretsBackend requires manual conversion: does not exist in peer-type
+	// WARNING: in.KMSKeyID requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1014,6 +1069,7 @@ func autoConvert_v1alpha3_Instance_To_v1alpha2_Instance(in *v1alpha3.Instance, o
 	out.PublicIP = (*string)(unsafe.Pointer(in.PublicIP))
 	out.ENASupport = (*bool)(unsafe.Pointer(in.ENASupport))
 	out.EBSOptimized = (*bool)(unsafe.Pointer(in.EBSOptimized))
+	// WARNING: in.RootDeviceName requires manual conversion: does not exist in peer-type
 	// WARNING: in.RootVolume requires manual conversion: does not exist in peer-type
 	// WARNING: in.NonRootVolumes requires manual conversion: does not exist in peer-type
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
@@ -1021,6 +1077,12 @@ func autoConvert_v1alpha3_Instance_To_v1alpha2_Instance(in *v1alpha3.Instance, o
 	// WARNING: in.AvailabilityZone requires manual conversion: does not exist in peer-type
 	// WARNING: in.SpotMarketOptions requires manual conversion: does not exist in peer-type
 	// WARNING: in.Tenancy requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlacementGroupName requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlacementGroupPartition requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityReservation requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostID requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostAffinity requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostResourceGroupARN requires manual conversion: does not exist in peer-type
 	return nil
 }
 
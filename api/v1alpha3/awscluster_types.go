@@ -25,6 +25,10 @@ const (
 	// ClusterFinalizer allows ReconcileAWSCluster to clean up AWS resources associated with AWSCluster before
 	// removing it from the apiserver.
 	ClusterFinalizer = "awscluster.infrastructure.cluster.x-k8s.io"
+
+	// InvalidateLookupCacheAnnotation, when set on an AWSCluster, clears the cached image and instance type
+	// lookups of the region of the cluster. The annotation is removed once the caches are cleared.
+	InvalidateLookupCacheAnnotation = "sigs.k8s.io/cluster-api-provider-aws-invalidate-lookup-cache"
 )
 
 // AWSClusterSpec defines the desired state of AWSCluster
@@ -55,8 +59,8 @@ type AWSClusterSpec struct {
 	// ImageLookupFormat is the AMI naming format to look up machine images when
 	// a machine does not specify an AMI. When set, this will be used for all
 	// cluster machines unless a machine specifies a different ImageLookupOrg.
-	// Supports substitutions for {{.BaseOS}} and {{.K8sVersion}} with the base
-	// OS and kubernetes version, respectively. The BaseOS will be the value in
+	// Supports substitutions for {{.BaseOS}}, {{.K8sVersion}} and {{.Arch}} with the
+	// base OS, kubernetes version and architecture of the machine, respectively. The BaseOS will be the value in
 	// ImageLookupBaseOS or ubuntu (the default), and the kubernetes version as
 	// defined by the packages produced by kubernetes/release without v as a
 	// prefix: 1.13.0, 1.12.5-mybuild.1, or 1.17.3. For example, the default
@@ -82,14 +86,93 @@ type AWSClusterSpec struct {
 	// Bastion contains options to configure the bastion host.
 	// +optional
 	Bastion Bastion `json:"bastion"`
+
+	// InstanceEvents enables the processing of EC2 instance events, such as Spot
+	// interruption warnings, for the machines of this cluster.
+	// Requires the EventBridgeInstanceState feature gate.
+	// +optional
+	InstanceEvents *InstanceEventsSpec `json:"instanceEvents,omitempty"`
+
+	// S3Bucket is an S3 bucket owned by the cluster, used to pass bootstrap data to instances
+	// which can't fetch it from AWS Secrets Manager, such as Ignition configs.
+	// +optional
+	S3Bucket *S3Bucket `json:"s3Bucket,omitempty"`
+
+	// Adoption takes ownership of the existing VPC, subnets, gateways, route tables, security groups
+	// and API server load balancer of the cluster, which are then managed, and deleted, like the
+	// ones created by Cluster API Provider AWS.
+	// +optional
+	Adoption *InfrastructureAdoption `json:"adoption,omitempty"`
+}
+
+// InfrastructureAdoptionMode is how existing infrastructure is adopted.
+type InfrastructureAdoptionMode string
+
+var (
+	// InfrastructureAdoptionModeDryRun only reports the changes adopting the infrastructure would make.
+	InfrastructureAdoptionModeDryRun = InfrastructureAdoptionMode("DryRun")
+
+	// InfrastructureAdoptionModeAdopt tags the infrastructure as owned by the cluster.
+	InfrastructureAdoptionModeAdopt = InfrastructureAdoptionMode("Adopt")
+)
+
+// InfrastructureAdoption configures the adoption of the existing infrastructure of a cluster.
+// The VPC is the one set in networkSpec.vpc.id, and the subnets are the ones set in networkSpec.subnets,
+// along with their route tables and NAT gateways, and the internet gateway of the VPC.
+type InfrastructureAdoption struct {
+	// Mode is DryRun to report the tags which would be added to the existing resources in the status of the
+	// cluster, without reconciling it any further, or Adopt to tag them as owned by the cluster.
+	// +kubebuilder:validation:Enum=DryRun;Adopt
+	Mode InfrastructureAdoptionMode `json:"mode"`
+
+	// SecurityGroups are the IDs of the existing security groups to adopt for each role.
+	// Security groups are created for the roles which aren't listed.
+	// +optional
+	SecurityGroups map[SecurityGroupRole]string `json:"securityGroups,omitempty"`
 }
 
+// InstanceEventsSpec configures the SQS queue EC2 instance events are consumed from.
+type InstanceEventsSpec struct {
+	// QueueURL is the URL of an existing SQS queue to consume instance events from.
+	// The queue and the EventBridge rules feeding it are then managed outside of
+	// Cluster API Provider AWS. When omitted, a queue and rules owned by the cluster
+	// are created and deleted along with it.
+	// +optional
+	QueueURL string `json:"queueURL,omitempty"`
+}
+
+// BastionMode is how the private network of the VPC is accessed.
+type BastionMode string
+
+var (
+	// BastionModeSSH creates a bastion host instance with a public ip, reached with SSH.
+	BastionModeSSH = BastionMode("ssh")
+
+	// BastionModeSSM creates no bastion host, instances are reached through AWS Systems Manager
+	// Session Manager instead.
+	BastionModeSSM = BastionMode("ssm")
+)
+
 type Bastion struct {
 	// Enabled allows this provider to create a bastion host instance
 	// with a public ip to access the VPC private network.
 	// +optional
 	Enabled bool `json:"enabled"`
 
+	// Mode is how the VPC private network is accessed when the bastion is enabled (defaults to ssh).
+	// With ssm, no bastion host instance is created, and the control plane instances are listed
+	// in the status as AWS Systems Manager Session Manager targets instead. Their instance profile
+	// must allow them to be managed by AWS Systems Manager.
+	// +kubebuilder:validation:Enum=ssh;ssm
+	// +optional
+	Mode BastionMode `json:"mode,omitempty"`
+
+	// HighAvailability runs the bastion host in an Auto Scaling group of one instance spanning
+	// all public subnets, so that it is replaced in another availability zone when it fails.
+	// The bastion host is then reached through an Elastic IP address, which follows the instance.
+	// +optional
+	HighAvailability bool `json:"highAvailability,omitempty"`
+
 	// DisableIngressRules will ensure there are no Ingress rules in the bastion host's security group.
 	// Requires AllowedCIDRBlocks to be empty.
 	// +optional
@@ -111,6 +194,11 @@ type Bastion struct {
 	AMI string `json:"ami,omitempty"`
 }
 
+// IsSSM returns whether the bastion is enabled in ssm mode.
+func (b *Bastion) IsSSM() bool {
+	return b.Enabled && b.Mode == BastionModeSSM
+}
+
 // AWSLoadBalancerSpec defines the desired state of an AWS load balancer
 type AWSLoadBalancerSpec struct {
 	// Scheme sets the scheme of the load balancer (defaults to Internet-facing)
@@ -131,6 +219,11 @@ type AWSLoadBalancerSpec struct {
 	// Subnets sets the subnets that should be applied to the control plane load balancer (defaults to discovered subnets for managed VPCs or an empty set for unmanaged VPCs)
 	// +optional
 	Subnets []string `json:"subnets,omitempty"`
+
+	// Name sets the name of the classic ELB load balancer, to use an existing load balancer.
+	// Defaults to a name generated from the cluster name. Cannot be changed.
+	// +optional
+	Name *string `json:"name,omitempty"`
 }
 
 // AWSClusterStatus defines the observed state of AWSCluster
@@ -141,6 +234,72 @@ type AWSClusterStatus struct {
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Bastion        *Instance                `json:"bastion,omitempty"`
 	Conditions     clusterv1.Conditions     `json:"conditions,omitempty"`
+
+	// InstanceEventsQueueURL is the URL of the SQS queue instance events are consumed from.
+	// +optional
+	InstanceEventsQueueURL string `json:"instanceEventsQueueURL,omitempty"`
+
+	// SessionManagerTargets are the control plane instances which can be reached through
+	// AWS Systems Manager Session Manager, when the bastion is enabled in ssm mode.
+	// +optional
+	SessionManagerTargets []SessionManagerTarget `json:"sessionManagerTargets,omitempty"`
+
+	// Adoption reports the existing resources adopted by the cluster, or which would be adopted in dry run mode.
+	// +optional
+	Adoption *InfrastructureAdoptionStatus `json:"adoption,omitempty"`
+}
+
+// InfrastructureAdoptionStatus reports the adoption of the existing infrastructure of a cluster.
+type InfrastructureAdoptionStatus struct {
+	// Mode is the adoption mode the resources were reported in.
+	Mode InfrastructureAdoptionMode `json:"mode"`
+
+	// Adopted is true once the resources have been tagged as owned by the cluster.
+	Adopted bool `json:"adopted"`
+
+	// Resources are the adopted resources, and the changes made to their tags.
+	// +optional
+	Resources []AdoptedResource `json:"resources,omitempty"`
+}
+
+// AdoptedResource describes an existing resource adopted by a cluster.
+type AdoptedResource struct {
+	// Kind is the kind of the resource, such as VPC, Subnet or SecurityGroup.
+	Kind string `json:"kind"`
+
+	// ID is the ID of the resource, or the name of the load balancer.
+	ID string `json:"id"`
+
+	// Tags are the tags added to the resource.
+	// +optional
+	Tags Tags `json:"tags,omitempty"`
+
+	// RemovedTags are the keys of the tags removed from the resource.
+	// +optional
+	RemovedTags []string `json:"removedTags,omitempty"`
+
+	// IngressRules are the existing ingress rules of an adopted security group. They are kept when the
+	// ingress rules of the security group are reconciled, alongside the rules of its role.
+	// +optional
+	IngressRules IngressRules `json:"ingressRules,omitempty"`
+
+	// Changes describe the changes made to the resource, besides its tags, when the cluster is reconciled
+	// after adoption, such as the routes which are replaced or the route table associations which are changed.
+	// +optional
+	Changes []string `json:"changes,omitempty"`
+}
+
+// SessionManagerTarget is an instance which can be reached through AWS Systems Manager Session Manager.
+type SessionManagerTarget struct {
+	// InstanceID is the ID of the instance.
+	InstanceID string `json:"instanceID"`
+
+	// AvailabilityZone is the availability zone of the instance.
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// Command starts a session on the instance with the AWS CLI.
+	Command string `json:"command"`
 }
 
 // +kubebuilder:object:root=true
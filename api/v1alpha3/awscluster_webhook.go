@@ -51,6 +51,14 @@ func (r *AWSCluster) ValidateCreate() error {
 
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, isValidSSHKey(r.Spec.SSHKeyName)...)
+	allErrs = append(allErrs, r.Spec.Adoption.Validate(field.NewPath("spec", "adoption"), &r.Spec.NetworkSpec)...)
+
+	// An existing load balancer is taken over by the cluster, so it must be adopted.
+	if lb := r.Spec.ControlPlaneLoadBalancer; lb != nil && lb.Name != nil && r.Spec.Adoption == nil {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec", "controlPlaneLoadBalancer", "name"), "can only be set when adopting existing infrastructure"),
+		)
+	}
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -89,6 +97,13 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 		)
 	}
 
+	if !reflect.DeepEqual(existingLoadBalancer.Name, newLoadBalancer.Name) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "name"),
+				newLoadBalancer.Name, "field is immutable"),
+		)
+	}
+
 	if !reflect.DeepEqual(oldC.Spec.ControlPlaneEndpoint, clusterv1.APIEndpoint{}) &&
 		!reflect.DeepEqual(r.Spec.ControlPlaneEndpoint, oldC.Spec.ControlPlaneEndpoint) {
 		allErrs = append(allErrs,
@@ -96,7 +111,15 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) error {
 		)
 	}
 
+	// A bucket can be added to an existing cluster, but not replaced, as it would be orphaned.
+	if oldC.Spec.S3Bucket != nil && !reflect.DeepEqual(r.Spec.S3Bucket, oldC.Spec.S3Bucket) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "s3Bucket"), r.Spec.S3Bucket, "field is immutable once set"),
+		)
+	}
+
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
+	allErrs = append(allErrs, r.Spec.Adoption.Validate(field.NewPath("spec", "adoption"), &r.Spec.NetworkSpec)...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
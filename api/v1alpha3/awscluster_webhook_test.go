@@ -48,6 +48,60 @@ func TestAWSCluster_ValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "adoption of the existing network",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC:     VPCSpec{ID: "vpc-1"},
+						Subnets: Subnets{{ID: "subnet-1"}},
+					},
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{Name: aws.String("legacy-apiserver")},
+					Adoption: &InfrastructureAdoption{
+						Mode:           InfrastructureAdoptionModeDryRun,
+						SecurityGroups: map[SecurityGroupRole]string{SecurityGroupNode: "sg-1"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "adoption requires the vpc and subnet IDs",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{{CidrBlock: "10.0.0.0/24"}},
+					},
+					Adoption: &InfrastructureAdoption{Mode: InfrastructureAdoptionModeAdopt},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "adoption requires security group IDs for supported roles",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC:     VPCSpec{ID: "vpc-1"},
+						Subnets: Subnets{{ID: "subnet-1"}},
+					},
+					Adoption: &InfrastructureAdoption{
+						Mode:           InfrastructureAdoptionModeAdopt,
+						SecurityGroups: map[SecurityGroupRole]string{"other": "legacy-nodes"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "controlPlaneLoadBalancer name requires adoption",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{Name: aws.String("legacy-apiserver")},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -152,6 +206,46 @@ func TestAWSCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "s3Bucket can be added",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					S3Bucket: &S3Bucket{Name: "cluster-api-provider-aws-test"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "s3Bucket is immutable once set",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					S3Bucket: &S3Bucket{Name: "cluster-api-provider-aws-test"},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					S3Bucket: &S3Bucket{Name: "cluster-api-provider-aws-other"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "controlPlaneLoadBalancer name is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{Name: aws.String("legacy-apiserver")},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -259,6 +353,27 @@ func TestAWSCluster_DefaultCNIIngressRules(t *testing.T) {
 	}
 }
 
+func TestCNISpec_IngressRules(t *testing.T) {
+	g := NewWithT(t)
+
+	antrea := &CNIIngressRule{
+		Description: "Antrea 1",
+		Protocol:    SecurityGroupProtocolTCP,
+		FromPort:    10349,
+		ToPort:      10349,
+	}
+	cni := &CNISpec{CNIIngressRules: CNIIngressRules{antrea}}
+	g.Expect(cni.IngressRules()).To(Equal(CNIIngressRules{antrea}))
+
+	cni.WindowsIngressRules = true
+	rules := cni.IngressRules()
+	g.Expect(rules).To(HaveLen(3))
+	g.Expect(rules[0]).To(Equal(antrea))
+	g.Expect(rules[1].Protocol).To(Equal(SecurityGroupProtocolUDP))
+	g.Expect(rules[1].FromPort).To(BeEquivalentTo(4789))
+	g.Expect(cni.CNIIngressRules).To(HaveLen(1))
+}
+
 func TestAWSCluster_ValidateAllowedCIDRBlocks(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -332,6 +447,32 @@ func TestAWSCluster_ValidateAllowedCIDRBlocks(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ssm mode allowed with CIDR blocks",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Bastion: Bastion{
+						Enabled:           true,
+						Mode:              BastionModeSSM,
+						AllowedCIDRBlocks: []string{"0.0.0.0/0"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ssm mode not allowed with instance type",
+			awsc: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Bastion: Bastion{
+						Enabled:      true,
+						Mode:         BastionModeSSM,
+						InstanceType: "t3.micro",
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -385,6 +526,25 @@ func TestAWSCluster_DefaultAllowedCIDRBlocks(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "empty AllowedCIDRBlocks is kept in ssm mode",
+			beforeCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Bastion: Bastion{
+						Enabled: true,
+						Mode:    BastionModeSSM,
+					},
+				},
+			},
+			afterCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					Bastion: Bastion{
+						Enabled: true,
+						Mode:    BastionModeSSM,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
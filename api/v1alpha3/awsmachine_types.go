@@ -26,6 +26,33 @@ const (
 	// MachineFinalizer allows ReconcileAWSMachine to clean up AWS resources associated with AWSMachine before
 	// removing it from the apiserver.
 	MachineFinalizer = "awsmachine.infrastructure.cluster.x-k8s.io"
+
+	// MutableInstanceTypeAnnotation, when set to "true" on an AWSMachine, allows spec.instanceType to be changed.
+	// The instance is stopped, modified and started again rather than replaced.
+	MutableInstanceTypeAnnotation = "sigs.k8s.io/cluster-api-provider-aws-mutable-instance-type"
+
+	// PowerStateAnnotation sets the desired power state of the instance of an AWSMachine, either
+	// PowerStateRunning or PowerStateStopped. Instances are left as they are when it is not set.
+	PowerStateAnnotation = "sigs.k8s.io/cluster-api-provider-aws-power-state"
+
+	// RebootAnnotation requests a reboot of the instance of an AWSMachine. The annotation is removed
+	// once the reboot has been requested from EC2.
+	RebootAnnotation = "sigs.k8s.io/cluster-api-provider-aws-reboot"
+
+	// AdoptInstanceAnnotation names an existing instance to adopt as the instance of an AWSMachine, rather
+	// than creating one. The annotation is removed once the instance has been adopted.
+	AdoptInstanceAnnotation = "sigs.k8s.io/cluster-api-provider-aws-adopt-instance"
+)
+
+// PowerState describes the desired power state of an instance.
+type PowerState string
+
+var (
+	// PowerStateRunning requests the instance to be started when it is stopped.
+	PowerStateRunning = PowerState("running")
+
+	// PowerStateStopped requests the instance to be stopped when it is running.
+	PowerStateStopped = PowerState("stopped")
 )
 
 // SecretBackend defines variants for backend secret storage.
@@ -37,6 +64,9 @@ var (
 
 	// SecretBackendSecretsManager defines AWS Secrets Manager as the secret backend
 	SecretBackendSecretsManager = SecretBackend("secrets-manager")
+
+	// SecretBackendS3 defines an S3 bucket owned by the cluster as the secret backend
+	SecretBackendS3 = SecretBackend("s3")
 )
 
 // AWSMachineSpec defines the desired state of AWSMachine
@@ -45,12 +75,13 @@ type AWSMachineSpec struct {
 	ProviderID *string `json:"providerID,omitempty"`
 
 	// AMI is the reference to the AMI from which to create the machine instance.
+	// AMIs referenced by SSM parameter or looked up are pinned in status.image.
 	AMI AWSResourceReference `json:"ami,omitempty"`
 
 	// ImageLookupFormat is the AMI naming format to look up the image for this
 	// machine It will be ignored if an explicit AMI is set. Supports
-	// substitutions for {{.BaseOS}} and {{.K8sVersion}} with the base OS and
-	// kubernetes version, respectively. The BaseOS will be the value in
+	// substitutions for {{.BaseOS}}, {{.K8sVersion}} and {{.Arch}} with the base OS,
+	// kubernetes version and architecture, respectively. The BaseOS will be the value in
 	// ImageLookupBaseOS or ubuntu (the default), and the kubernetes version as
 	// defined by the packages produced by kubernetes/release without v as a
 	// prefix: 1.13.0, 1.12.5-mybuild.1, or 1.17.3. For example, the default
@@ -68,7 +99,21 @@ type AWSMachineSpec struct {
 	// image lookup the AMI is not set.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// ImageLookupArchitecture is the CPU architecture of the image to look up if AMI is not set.
+	// Defaults to the architecture of the instance type.
+	// +kubebuilder:validation:Enum=x86_64;arm64
+	// +optional
+	ImageLookupArchitecture string `json:"imageLookupArchitecture,omitempty"`
+
+	// Platform is the operating system platform of the instance, either linux or windows. Windows
+	// instances use Windows AMIs and are bootstrapped with PowerShell userdata. Defaults to linux.
+	// +kubebuilder:validation:Enum=linux;windows
+	// +optional
+	Platform Platform `json:"platform,omitempty"`
+
 	// InstanceType is the type of instance to create. Example: m4.xlarge
+	// It can only be changed if the AWSMachine has the MutableInstanceTypeAnnotation, in which case the
+	// instance is stopped and started again with the new instance type.
 	InstanceType string `json:"instanceType,omitempty"`
 
 	// AdditionalTags is an optional set of tags to add to an instance, in addition to the ones added by default by the
@@ -123,6 +168,11 @@ type AWSMachineSpec struct {
 	// +kubebuilder:validation:MaxItems=2
 	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
 
+	// SecondaryNetworkInterfaces is a list of additional ENIs to create and attach to the instance.
+	// Secondary network interfaces are deleted when the instance is terminated.
+	// +optional
+	SecondaryNetworkInterfaces SecondaryNetworkInterfaces `json:"secondaryNetworkInterfaces,omitempty"`
+
 	// UncompressedUserData specify whether the user data is gzip-compressed before it is sent to ec2 instance.
 	// cloud-init has built-in support for gzip-compressed user data
 	// user data stored in aws secret manager is always gzip-compressed.
@@ -130,6 +180,18 @@ type AWSMachineSpec struct {
 	// +optional
 	UncompressedUserData *bool `json:"uncompressedUserData,omitempty"`
 
+	// UserDataFormat is the format of the bootstrap data, either cloud-config or ignition.
+	// Ignition configs are stored in the cluster's S3 bucket and referenced from the userdata.
+	// Defaults to cloud-config.
+	// +kubebuilder:validation:Enum=cloud-config;ignition
+	// +optional
+	UserDataFormat UserDataFormat `json:"userDataFormat,omitempty"`
+
+	// Ignition defines options related to the bootstrapping systems where
+	// Ignition is used.
+	// +optional
+	Ignition Ignition `json:"ignition,omitempty"`
+
 	// CloudInit defines options related to the bootstrapping systems where
 	// CloudInit is used.
 	// +optional
@@ -143,6 +205,36 @@ type AWSMachineSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum:=default;dedicated;host
 	Tenancy string `json:"tenancy,omitempty"`
+
+	// PlacementGroup is the placement group to launch the instance into.
+	// +optional
+	PlacementGroup *PlacementGroup `json:"placementGroup,omitempty"`
+
+	// CapacityReservation sets the On-Demand Capacity Reservation preference of the instance.
+	// +optional
+	CapacityReservation *CapacityReservationSpec `json:"capacityReservation,omitempty"`
+
+	// DedicatedHost selects the Dedicated Host the instance is launched onto.
+	// Requires Tenancy to be set to host.
+	// +optional
+	DedicatedHost *DedicatedHostSpec `json:"dedicatedHost,omitempty"`
+
+	// BootDiagnostics enables the capture of the console output of the instance when it stops
+	// unexpectedly, or runs for too long without becoming a node. The output is stored in a
+	// Secret referenced from the status of the AWSMachine.
+	// +optional
+	BootDiagnostics *BootDiagnostics `json:"bootDiagnostics,omitempty"`
+}
+
+// Ignition defines options related to the bootstrapping systems where
+// Ignition is used.
+type Ignition struct {
+	// InsecureInlineUserData, when set to true will pass the Ignition config
+	// as plaintext userdata if the AWSCluster has no S3 bucket to store it in.
+	// By default, machines using Ignition fail to be created without a bucket,
+	// as userdata can be read by anyone allowed to describe the instance.
+	// +optional
+	InsecureInlineUserData bool `json:"insecureInlineUserData,omitempty"`
 }
 
 // CloudInit defines options related to the bootstrapping systems where
@@ -165,12 +257,19 @@ type CloudInit struct {
 	SecretPrefix string `json:"secretPrefix,omitempty"`
 
 	// SecureSecretsBackend, when set to parameter-store will utilize the AWS Systems Manager
-	// Parameter Storage to distribute secrets. By default or with the value of secrets-manager,
-	// will use AWS Secrets Manager instead.
+	// Parameter Storage to distribute secrets. When set to s3, will use the S3 bucket of the
+	// cluster, which must be set. By default or with the value of secrets-manager, will use
+	// AWS Secrets Manager instead.
 	// +optional
 	// +kubebuilder:default=secrets-manager
-	// +kubebuilder:validation:Enum=secrets-manager;ssm-parameter-store
+	// +kubebuilder:validation:Enum=secrets-manager;ssm-parameter-store;s3
 	SecureSecretsBackend SecretBackend `json:"secureSecretsBackend,omitempty"`
+
+	// KMSKeyID is the ID, ARN, alias name or alias ARN of the customer managed KMS key with which
+	// the secrets holding the userdata are encrypted. When unset, the AWS managed key of the secret
+	// backend is used.
+	// +optional
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
 }
 
 // AWSMachineStatus defines the observed state of AWSMachine
@@ -186,6 +285,34 @@ type AWSMachineStatus struct {
 	// +optional
 	InstanceState *InstanceState `json:"instanceState,omitempty"`
 
+	// NetworkInterfaces describes the secondary network interfaces attached to the instance.
+	// +optional
+	NetworkInterfaces []NetworkInterfaceStatus `json:"networkInterfaces,omitempty"`
+
+	// ScheduledEvents lists the upcoming events scheduled by AWS for the instance.
+	// +optional
+	ScheduledEvents []InstanceScheduledEvent `json:"scheduledEvents,omitempty"`
+
+	// LastHealthCheck is the time the status checks and scheduled events of the instance were last
+	// described. They are described again once the health check interval has passed.
+	// +optional
+	LastHealthCheck *metav1.Time `json:"lastHealthCheck,omitempty"`
+
+	// Image is the AMI resolved for the instance. AWSMachines cloned from the same
+	// AWSMachineTemplate reuse it while it is resolved from the same source, so that
+	// they run the same image until the template or Kubernetes version changes.
+	// +optional
+	Image *ResolvedImage `json:"image,omitempty"`
+
+	// BootDiagnostics describes the boot diagnostics captured for the instance.
+	// +optional
+	BootDiagnostics *BootDiagnosticsStatus `json:"bootDiagnostics,omitempty"`
+
+	// BootstrapDataObject is the key of the object holding the Ignition config of the
+	// instance in the cluster's S3 bucket. It is unset once the object is deleted.
+	// +optional
+	BootstrapDataObject string `json:"bootstrapDataObject,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -268,3 +395,18 @@ type AWSMachineList struct {
 func init() {
 	SchemeBuilder.Register(&AWSMachine{}, &AWSMachineList{})
 }
+
+// HasMutableInstanceType returns true if the instance type of the AWSMachine can be changed in place.
+func (r *AWSMachine) HasMutableInstanceType() bool {
+	return r.GetAnnotations()[MutableInstanceTypeAnnotation] == "true"
+}
+
+// DesiredPowerState returns the power state requested through the PowerStateAnnotation, if any.
+func (r *AWSMachine) DesiredPowerState() PowerState {
+	return PowerState(r.GetAnnotations()[PowerStateAnnotation])
+}
+
+// InstanceToAdopt returns the ID of the instance requested through the AdoptInstanceAnnotation, if any.
+func (r *AWSMachine) InstanceToAdopt() string {
+	return r.GetAnnotations()[AdoptInstanceAnnotation]
+}
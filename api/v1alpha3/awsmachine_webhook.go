@@ -18,6 +18,8 @@ package v1alpha3
 
 import (
 	"reflect"
+	"regexp"
+	"strings"
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -28,6 +30,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// instanceIDPattern matches the IDs of EC2 instances.
+var instanceIDPattern = regexp.MustCompile(`^i-[0-9a-f]+$`)
+
 // log is for logging in this package.
 var _ = logf.Log.WithName("awsmachine-resource")
 
@@ -53,6 +58,15 @@ func (r *AWSMachine) ValidateCreate() error {
 	allErrs = append(allErrs, r.validateRootVolume()...)
 	allErrs = append(allErrs, r.validateNonRootVolumes()...)
 	allErrs = append(allErrs, isValidSSHKey(r.Spec.SSHKeyName)...)
+	allErrs = append(allErrs, r.Spec.AMI.ValidateAMI(field.NewPath("spec", "ami"))...)
+	allErrs = append(allErrs, r.Spec.Platform.Validate(field.NewPath("spec", "platform"), r.Spec.ImageLookupArchitecture)...)
+	allErrs = append(allErrs, r.Spec.UserDataFormat.Validate(field.NewPath("spec", "userDataFormat"), r.Spec.Platform)...)
+	allErrs = append(allErrs, r.Spec.PlacementGroup.Validate(field.NewPath("spec", "placementGroup"))...)
+	allErrs = append(allErrs, r.Spec.CapacityReservation.Validate(field.NewPath("spec", "capacityReservation"))...)
+	allErrs = append(allErrs, r.Spec.DedicatedHost.Validate(field.NewPath("spec", "dedicatedHost"), r.Spec.Tenancy)...)
+	allErrs = append(allErrs, r.Spec.SecondaryNetworkInterfaces.Validate(field.NewPath("spec", "secondaryNetworkInterfaces"), len(r.Spec.NetworkInterfaces))...)
+	allErrs = append(allErrs, r.validatePowerState()...)
+	allErrs = append(allErrs, r.validateAdoptInstance()...)
 
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -75,14 +89,44 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, r.validateCloudInitSecret()...)
+	allErrs = append(allErrs, r.validateRootVolume()...)
+	allErrs = append(allErrs, r.validateNonRootVolumes()...)
+	allErrs = append(allErrs, r.validateVolumeUpdates(old.(*AWSMachine))...)
+	allErrs = append(allErrs, r.validateInstanceTypeUpdate(old.(*AWSMachine))...)
+	allErrs = append(allErrs, r.validatePowerState()...)
+	allErrs = append(allErrs, r.validateAdoptInstance()...)
 
 	newAWSMachineSpec := newAWSMachine["spec"].(map[string]interface{})
 	oldAWSMachineSpec := oldAWSMachine["spec"].(map[string]interface{})
 
+	// allow changes to the size, iops, throughput and tags of volumes
+	for _, spec := range []map[string]interface{}{oldAWSMachineSpec, newAWSMachineSpec} {
+		if rootVolume, ok := spec["rootVolume"].(map[string]interface{}); ok {
+			deleteMutableVolumeFields(rootVolume)
+		}
+		if nonRootVolumes, ok := spec["nonRootVolumes"].([]interface{}); ok {
+			for _, volume := range nonRootVolumes {
+				if volume, ok := volume.(map[string]interface{}); ok {
+					deleteMutableVolumeFields(volume)
+				}
+			}
+		}
+	}
+
+	// allow changes to instanceType when opted in, validated above
+	if r.HasMutableInstanceType() {
+		delete(oldAWSMachineSpec, "instanceType")
+		delete(newAWSMachineSpec, "instanceType")
+	}
+
 	// allow changes to providerID
 	delete(oldAWSMachineSpec, "providerID")
 	delete(newAWSMachineSpec, "providerID")
 
+	// allow changes to bootDiagnostics
+	delete(oldAWSMachineSpec, "bootDiagnostics")
+	delete(newAWSMachineSpec, "bootDiagnostics")
+
 	// allow changes to additionalTags
 	delete(oldAWSMachineSpec, "additionalTags")
 	delete(newAWSMachineSpec, "additionalTags")
@@ -112,27 +156,97 @@ func (r *AWSMachine) ValidateUpdate(old runtime.Object) error {
 }
 
 func (r *AWSMachine) validateCloudInitSecret() field.ErrorList {
+	return r.Spec.CloudInit.Validate(field.NewPath("spec", "cloudInit"))
+}
+
+// validateVolumeUpdates ensures volumes are only ever grown, as EBS volumes cannot be shrunk.
+func (r *AWSMachine) validateVolumeUpdates(old *AWSMachine) field.ErrorList {
 	var allErrs field.ErrorList
 
-	if r.Spec.CloudInit.InsecureSkipSecretsManager {
-		if r.Spec.CloudInit.SecretPrefix != "" {
-			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit", "secretPrefix"), "cannot be set if spec.cloudInit.insecureSkipSecretsManager is true"))
+	if r.Spec.RootVolume != nil && old.Spec.RootVolume != nil && r.Spec.RootVolume.Size < old.Spec.RootVolume.Size {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "rootVolume", "size"), r.Spec.RootVolume.Size, "cannot be decreased"))
+	}
+
+	for i, volume := range r.Spec.NonRootVolumes {
+		if i >= len(old.Spec.NonRootVolumes) || volume == nil || old.Spec.NonRootVolumes[i] == nil {
+			continue
 		}
-		if r.Spec.CloudInit.SecretCount != 0 {
-			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit", "secretCount"), "cannot be set if spec.cloudInit.insecureSkipSecretsManager is true"))
+		if volume.Size < old.Spec.NonRootVolumes[i].Size {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "nonRootVolumes").Index(i).Child("size"), volume.Size, "cannot be decreased"))
 		}
-		if r.Spec.CloudInit.SecureSecretsBackend != "" {
-			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit", "secureSecretsBackend"), "cannot be set if spec.cloudInit.insecureSkipSecretsManager is true"))
+	}
+
+	return allErrs
+}
+
+// validateInstanceTypeUpdate ensures the instance type is only changed in place for instances which can be stopped.
+func (r *AWSMachine) validateInstanceTypeUpdate(old *AWSMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !r.HasMutableInstanceType() || r.Spec.InstanceType == old.Spec.InstanceType {
+		return allErrs
+	}
+
+	if r.Spec.InstanceType == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "instanceType"), "cannot be removed"))
+	}
+
+	if r.Spec.SpotMarketOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "instanceType"), "cannot be changed in place for spot instances"))
+	}
+
+	return allErrs
+}
+
+// validatePowerState ensures the requested power state is known and can be applied to the instance.
+func (r *AWSMachine) validatePowerState() field.ErrorList {
+	var allErrs field.ErrorList
+
+	fldPath := field.NewPath("metadata", "annotations").Key(PowerStateAnnotation)
+
+	switch r.DesiredPowerState() {
+	case "", PowerStateRunning:
+	case PowerStateStopped:
+		if r.Spec.SpotMarketOptions != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "spot instances cannot be stopped"))
 		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, r.DesiredPowerState(), []string{string(PowerStateRunning), string(PowerStateStopped)}))
 	}
 
-	if (r.Spec.CloudInit.SecretPrefix != "") != (r.Spec.CloudInit.SecretCount != 0) {
-		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit", "secretCount"), "must be set together with spec.CloudInit.SecretPrefix"))
+	return allErrs
+}
+
+// validateAdoptInstance ensures the instance to adopt is an instance ID, and that the AWSMachine doesn't
+// already have another instance.
+func (r *AWSMachine) validateAdoptInstance() field.ErrorList {
+	var allErrs field.ErrorList
+
+	instanceID, ok := r.GetAnnotations()[AdoptInstanceAnnotation]
+	if !ok {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("metadata", "annotations").Key(AdoptInstanceAnnotation)
+
+	if !instanceIDPattern.MatchString(instanceID) {
+		allErrs = append(allErrs, field.Invalid(fldPath, instanceID, "must be the ID of an EC2 instance"))
+	}
+
+	if r.Spec.ProviderID != nil && *r.Spec.ProviderID != "" && !strings.HasSuffix(*r.Spec.ProviderID, "/"+instanceID) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot adopt an instance into an AWSMachine which already has one"))
 	}
 
 	return allErrs
 }
 
+func deleteMutableVolumeFields(volume map[string]interface{}) {
+	delete(volume, "size")
+	delete(volume, "iops")
+	delete(volume, "throughput")
+	delete(volume, "tags")
+}
+
 func (r *AWSMachine) validateRootVolume() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -148,6 +262,10 @@ func (r *AWSMachine) validateRootVolume() field.ErrorList {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.rootVolumeOptions.deviceName"), "root volume shouldn't have device name"))
 	}
 
+	if r.Spec.RootVolume.Throughput != nil && r.Spec.RootVolume.Type != "gp3" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.rootVolumeOptions.throughput"), "throughput can only be set if type is 'gp3'"))
+	}
+
 	return allErrs
 }
 
@@ -166,6 +284,10 @@ func (r *AWSMachine) validateNonRootVolumes() field.ErrorList {
 		if volume.DeviceName == "" {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec.nonRootVolumes.volumeOptions.deviceName"), "non root volume should have device name"))
 		}
+
+		if volume.Throughput != nil && volume.Type != "gp3" {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec.nonRootVolumes.volumeOptions.throughput"), "throughput can only be set if type is 'gp3'"))
+		}
 	}
 
 	return allErrs
@@ -20,6 +20,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
 
@@ -29,6 +30,95 @@ func TestAWSMachine_ValidateCreate(t *testing.T) {
 		machine *AWSMachine
 		wantErr bool
 	}{
+		{
+			name: "allow ami referenced by ssm parameter",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI: AWSResourceReference{SSMParameter: aws.String("/golden-images/ubuntu/ami-id")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ami ssm parameter cannot be empty",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI: AWSResourceReference{SSMParameter: aws.String("")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ami ssm parameter cannot be set with id",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					AMI: AWSResourceReference{
+						ID:           aws.String("ami-1"),
+						SSMParameter: aws.String("/golden-images/ubuntu/ami-id"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "allow windows instances",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Platform: PlatformWindows,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "windows instances cannot use arm64 images",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Platform:                PlatformWindows,
+					ImageLookupArchitecture: "arm64",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "allow ignition userdata",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					UserDataFormat: UserDataFormatIgnition,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ignition userdata is not supported on windows",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Platform:       PlatformWindows,
+					UserDataFormat: UserDataFormatIgnition,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "allow kms key for secrets",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CloudInit: CloudInit{KMSKeyID: "alias/bootstrap-secrets"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "kms key cannot be set without secure secrets",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CloudInit: CloudInit{
+						InsecureSkipSecretsManager: true,
+						KMSKeyID:                   "alias/bootstrap-secrets",
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "ensure IOPS exists if type equal to io1",
 			machine: &AWSMachine{
@@ -119,6 +209,281 @@ func TestAWSMachine_ValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "placement group partition number requires partition strategy when managed",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					PlacementGroup: &PlacementGroup{
+						Name:            "pg",
+						Strategy:        PlacementGroupStrategySpread,
+						PartitionNumber: aws.Int64(1),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "placement group partition count requires partition strategy",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					PlacementGroup: &PlacementGroup{
+						Name:           "pg",
+						PartitionCount: aws.Int64(2),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "placement group partition number must fit partition count",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					PlacementGroup: &PlacementGroup{
+						Name:            "pg",
+						Strategy:        PlacementGroupStrategyPartition,
+						PartitionCount:  aws.Int64(2),
+						PartitionNumber: aws.Int64(3),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid managed partition placement group",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					PlacementGroup: &PlacementGroup{
+						Name:            "pg",
+						Strategy:        PlacementGroupStrategyPartition,
+						PartitionCount:  aws.Int64(3),
+						PartitionNumber: aws.Int64(2),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "capacity reservation preference and target are mutually exclusive",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityReservation: &CapacityReservationSpec{
+						Preference: CapacityReservationPreferenceOpen,
+						ID:         aws.String("cr-1234"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid targeted capacity reservation",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					CapacityReservation: &CapacityReservationSpec{
+						ID: aws.String("cr-1234"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dedicated host requires host tenancy",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					DedicatedHost: &DedicatedHostSpec{
+						ID: aws.String("h-1234"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "dedicated host id and managed allocation are mutually exclusive",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Tenancy: "host",
+					DedicatedHost: &DedicatedHostSpec{
+						ID:      aws.String("h-1234"),
+						Managed: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid managed dedicated host with host affinity",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					Tenancy: "host",
+					DedicatedHost: &DedicatedHostSpec{
+						Managed:  true,
+						Affinity: DedicatedHostAffinityHost,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "secondary network interfaces require unique device indexes",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SecondaryNetworkInterfaces: SecondaryNetworkInterfaces{
+						{DeviceIndex: 1},
+						{DeviceIndex: 1},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "secondary network interface device index cannot overlap network interfaces",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					NetworkInterfaces: []string{"eni-1", "eni-2"},
+					SecondaryNetworkInterfaces: SecondaryNetworkInterfaces{
+						{DeviceIndex: 1},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "secondary network interface security groups require an id",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SecondaryNetworkInterfaces: SecondaryNetworkInterfaces{
+						{
+							DeviceIndex:    1,
+							SecurityGroups: []AWSResourceReference{{ARN: aws.String("arn:aws:ec2:sg")}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid secondary network interfaces",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					SecondaryNetworkInterfaces: SecondaryNetworkInterfaces{
+						{
+							DeviceIndex:             1,
+							Subnet:                  &AWSResourceReference{ID: aws.String("subnet-1")},
+							SecurityGroups:          []AWSResourceReference{{ID: aws.String("sg-1")}},
+							SecondaryPrivateIPCount: aws.Int64(2),
+						},
+						{
+							DeviceIndex:     2,
+							IPv4PrefixCount: aws.Int64(1),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "throughput requires gp3 volumes",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Type:       "gp2",
+						Throughput: aws.Int64(250),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid throughput on gp3 volumes",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{
+						Type:       "gp3",
+						Throughput: aws.Int64(250),
+					},
+					NonRootVolumes: []*Volume{
+						{
+							DeviceName: "/dev/sdb",
+							Type:       "gp3",
+							Throughput: aws.Int64(500),
+							Tags:       Tags{"team": "storage"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown power state",
+			machine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{PowerStateAnnotation: "hibernated"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "spot instances cannot be stopped",
+			machine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{PowerStateAnnotation: "stopped"},
+				},
+				Spec: AWSMachineSpec{
+					SpotMarketOptions: &SpotMarketOptions{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid stopped power state",
+			machine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{PowerStateAnnotation: "stopped"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "adopt an instance",
+			machine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AdoptInstanceAnnotation: "i-0123456789abcdef0"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "adopt something which isn't an instance",
+			machine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AdoptInstanceAnnotation: "vol-0123456789abcdef0"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "adopt an instance into a machine which has another one",
+			machine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AdoptInstanceAnnotation: "i-0123456789abcdef0"},
+				},
+				Spec: AWSMachineSpec{
+					ProviderID: aws.String("aws:///us-east-1a/i-0fedcba9876543210"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid unmanaged placement group with partition number",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					PlacementGroup: &PlacementGroup{
+						Name:            "pg",
+						PartitionNumber: aws.Int64(2),
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -190,6 +555,109 @@ func TestAWSMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "grow volumes and change iops, throughput and tags",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 8, Type: "gp3"},
+					NonRootVolumes: []*Volume{
+						{DeviceName: "/dev/sdb", Size: 100, Type: "gp3"},
+					},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 16, Type: "gp3", Throughput: aws.Int64(250)},
+					NonRootVolumes: []*Volume{
+						{DeviceName: "/dev/sdb", Size: 200, Type: "gp3", IOPS: 6000, Tags: Tags{"team": "storage"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enable boot diagnostics",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{InstanceType: "m5.large"},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:    "m5.large",
+					BootDiagnostics: &BootDiagnostics{Screenshot: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "change in instance type with the mutable instance type annotation",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{InstanceType: "m5.large"},
+			},
+			newMachine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{MutableInstanceTypeAnnotation: "true"},
+				},
+				Spec: AWSMachineSpec{InstanceType: "m5.xlarge"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "change in instance type without the mutable instance type annotation",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{InstanceType: "m5.large"},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{InstanceType: "m5.xlarge"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "change in instance type of a spot instance",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:      "m5.large",
+					SpotMarketOptions: &SpotMarketOptions{},
+				},
+			},
+			newMachine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{MutableInstanceTypeAnnotation: "true"},
+				},
+				Spec: AWSMachineSpec{
+					InstanceType:      "m5.xlarge",
+					SpotMarketOptions: &SpotMarketOptions{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "shrink volume",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 16},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 8},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "change volume type",
+			oldMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 8, Type: "gp2"},
+				},
+			},
+			newMachine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Size: 8, Type: "gp3"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -55,6 +55,14 @@ func (r *AWSMachineTemplate) ValidateCreate() error {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "providerID"), "cannot be set in templates"))
 	}
 
+	allErrs = append(allErrs, spec.AMI.ValidateAMI(field.NewPath("spec", "template", "spec", "ami"))...)
+	allErrs = append(allErrs, spec.Platform.Validate(field.NewPath("spec", "template", "spec", "platform"), spec.ImageLookupArchitecture)...)
+	allErrs = append(allErrs, spec.UserDataFormat.Validate(field.NewPath("spec", "template", "spec", "userDataFormat"), spec.Platform)...)
+	allErrs = append(allErrs, spec.PlacementGroup.Validate(field.NewPath("spec", "template", "spec", "placementGroup"))...)
+	allErrs = append(allErrs, spec.CapacityReservation.Validate(field.NewPath("spec", "template", "spec", "capacityReservation"))...)
+	allErrs = append(allErrs, spec.DedicatedHost.Validate(field.NewPath("spec", "template", "spec", "dedicatedHost"), spec.Tenancy)...)
+	allErrs = append(allErrs, spec.SecondaryNetworkInterfaces.Validate(field.NewPath("spec", "template", "spec", "secondaryNetworkInterfaces"), len(spec.NetworkInterfaces))...)
+
 	return aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
 
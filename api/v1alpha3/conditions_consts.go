@@ -76,6 +76,17 @@ const (
 	BastionCreationStartedReason = "BastionCreationStarted"
 	// BastionHostFailedReason used when an error occurs during the creation of a bastion host
 	BastionHostFailedReason = "BastionHostFailed"
+	// BastionReplacementPendingReason used when the bastion host no longer matches its spec and is being replaced
+	BastionReplacementPendingReason = "BastionReplacementPending"
+)
+
+const (
+	// InfrastructureAdoptedCondition reports on the adoption of the existing infrastructure of a cluster.
+	InfrastructureAdoptedCondition clusterv1.ConditionType = "InfrastructureAdopted"
+	// InfrastructureAdoptionDryRunReason used while the changes adopting the infrastructure would make are only reported.
+	InfrastructureAdoptionDryRunReason = "InfrastructureAdoptionDryRun"
+	// InfrastructureAdoptionFailedReason used when the existing infrastructure cannot be adopted.
+	InfrastructureAdoptionFailedReason = "InfrastructureAdoptionFailed"
 )
 
 const (
@@ -99,12 +110,22 @@ const (
 	InstanceTerminatedReason = "InstanceTerminated"
 	// InstanceStoppedReason instance is in a stopped state.
 	InstanceStoppedReason = "InstanceStopped"
+	// InstanceStoppedOnRequestReason instance is stopping or stopped as requested through the PowerStateAnnotation.
+	InstanceStoppedOnRequestReason = "InstanceStoppedOnRequest"
+	// InstancePowerStateChangeFailedReason used when the instance could not be stopped, started or rebooted as requested.
+	InstancePowerStateChangeFailedReason = "InstancePowerStateChangeFailed"
 	// InstanceNotReadyReason used when the instance is in a pending state.
 	InstanceNotReadyReason = "InstanceNotReady"
+	// InstanceTypeChangingReason used while the instance is stopped to change its instance type.
+	InstanceTypeChangingReason = "InstanceTypeChanging"
 	// InstanceProvisionStartedReason set when the provisioning of an instance started.
 	InstanceProvisionStartedReason = "InstanceProvisionStarted"
 	// InstanceProvisionFailedReason used for failures during instance provisioning.
 	InstanceProvisionFailedReason = "InstanceProvisionFailed"
+	// InstanceAdoptionFailedReason used when an existing instance cannot be adopted.
+	InstanceAdoptionFailedReason = "InstanceAdoptionFailed"
+	// CapacityReservationExhaustedReason used when the capacity reservation targeted by the instance has no available capacity.
+	CapacityReservationExhaustedReason = "CapacityReservationExhausted"
 	// WaitingForClusterInfrastructureReason used when machine is waiting for cluster infrastructure to be ready before proceeding.
 	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
 	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
@@ -119,6 +140,64 @@ const (
 	SecurityGroupsFailedReason = "SecurityGroupsSyncFailed"
 )
 
+const (
+	// NetworkInterfacesReadyCondition indicates the secondary network interfaces are attached to the AWSMachine's instance.
+	NetworkInterfacesReadyCondition clusterv1.ConditionType = "NetworkInterfacesReady"
+
+	// NetworkInterfacesFailedReason used when the secondary network interfaces could not be reconciled
+	NetworkInterfacesFailedReason = "NetworkInterfacesFailed"
+)
+
+const (
+	// VolumesReadyCondition indicates the volumes of the AWSMachine's instance match the requested size, IOPS and throughput.
+	VolumesReadyCondition clusterv1.ConditionType = "VolumesReady"
+
+	// VolumeModificationInProgressReason used while the volumes of the instance are being modified
+	VolumeModificationInProgressReason = "VolumeModificationInProgress"
+	// VolumeModificationFailedReason used when the volumes of the instance could not be modified
+	VolumeModificationFailedReason = "VolumeModificationFailed"
+)
+
+const (
+	// InstanceTypeReadyCondition indicates the instance type of the AWSMachine's instance matches the requested instance type.
+	// Only reported for AWSMachines which allow in-place instance type changes.
+	InstanceTypeReadyCondition clusterv1.ConditionType = "InstanceTypeReady"
+
+	// InstanceStoppingForTypeChangeReason used while the instance is stopped before changing its instance type
+	InstanceStoppingForTypeChangeReason = "InstanceStoppingForTypeChange"
+	// InstanceStartingAfterTypeChangeReason used while the instance is started after changing its instance type
+	InstanceStartingAfterTypeChangeReason = "InstanceStartingAfterTypeChange"
+	// InstanceTypeChangeFailedReason used when the instance type of the instance could not be changed
+	InstanceTypeChangeFailedReason = "InstanceTypeChangeFailed"
+)
+
+const (
+	// SystemStatusCheckPassedCondition reports on the EC2 system status check of the AWSMachine's instance,
+	// which detects problems with the AWS systems the instance runs on.
+	SystemStatusCheckPassedCondition clusterv1.ConditionType = "SystemStatusCheckPassed"
+	// InstanceStatusCheckPassedCondition reports on the EC2 instance status check of the AWSMachine's instance,
+	// which detects problems with the software and network configuration of the instance.
+	InstanceStatusCheckPassedCondition clusterv1.ConditionType = "InstanceStatusCheckPassed"
+	// EBSStatusCheckPassedCondition reports on the EBS status checks of the volumes attached to the AWSMachine's instance.
+	EBSStatusCheckPassedCondition clusterv1.ConditionType = "EBSStatusCheckPassed"
+
+	// StatusCheckImpairedReason used when a status check failed
+	StatusCheckImpairedReason = "StatusCheckImpaired"
+	// StatusCheckWarningReason used when a volume is degraded or severely degraded
+	StatusCheckWarningReason = "StatusCheckWarning"
+	// StatusCheckInitializingReason used while a status check is being initialized
+	StatusCheckInitializingReason = "StatusCheckInitializing"
+	// StatusCheckInsufficientDataReason used when AWS does not have enough data to run a status check
+	StatusCheckInsufficientDataReason = "StatusCheckInsufficientData"
+
+	// NoScheduledEventsCondition reports whether AWS has scheduled events, such as a retirement or a maintenance,
+	// for the AWSMachine's instance.
+	NoScheduledEventsCondition clusterv1.ConditionType = "NoScheduledEvents"
+
+	// InstanceEventScheduledReason used when AWS has scheduled an event for the instance
+	InstanceEventScheduledReason = "InstanceEventScheduled"
+)
+
 const (
 	// Only applicable to control plane machines. ELBAttachedCondition will report true when a control plane is successfully registered with an ELB
 	// When set to false, severity can be an Error if the subnet is not found or unavailable in the instance's AZ
@@ -21,7 +21,7 @@ package v1alpha3
 // SetDefaults_Bastion is used by defaulter-gen
 func SetDefaults_Bastion(obj *Bastion) { //nolint:golint,stylecheck
 	// Default to allow open access to the bastion host if no CIDR Blocks have been set
-	if len(obj.AllowedCIDRBlocks) == 0 && !obj.DisableIngressRules {
+	if len(obj.AllowedCIDRBlocks) == 0 && !obj.DisableIngressRules && !obj.IsSSM() {
 		obj.AllowedCIDRBlocks = []string{"0.0.0.0/0"}
 	}
 }
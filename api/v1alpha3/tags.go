@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"reflect"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"strings"
 )
 
 // Tags defines a map of tags.
@@ -43,6 +44,17 @@ func (t Tags) HasAWSCloudProviderOwned(cluster string) bool {
 	return ok && ResourceLifecycle(value) == ResourceLifecycleOwned
 }
 
+// GetOtherOwner returns the name of another cluster than the given one the tags mark the resource as owned by,
+// from the perspective of this management tooling, or an empty string if there is none.
+func (t Tags) GetOtherOwner(cluster string) string {
+	for key, value := range t {
+		if owner := strings.TrimPrefix(key, NameAWSProviderOwned); owner != key && owner != cluster && ResourceLifecycle(value) == ResourceLifecycleOwned {
+			return owner
+		}
+	}
+	return ""
+}
+
 // GetRole returns the Cluster API role for the tagged resource
 func (t Tags) GetRole() string {
 	return t[NameAWSClusterAPIRole]
@@ -121,6 +133,9 @@ const (
 	// PrivateRoleTagValue describes the value for the private role
 	PrivateRoleTagValue = "private"
 
+	// SecondaryNetworkInterfaceRoleTagValue describes the value for the secondary network interface role
+	SecondaryNetworkInterfaceRoleTagValue = "secondary-network-interface"
+
 	// MachineNameTagKey is the key for machine name
 	MachineNameTagKey = "MachineName"
 )
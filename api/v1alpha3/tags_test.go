@@ -161,3 +161,46 @@ func TestTags_Difference(t *testing.T) {
 		})
 	}
 }
+
+func TestTags_GetOtherOwner(t *testing.T) {
+	tests := []struct {
+		name     string
+		self     Tags
+		expected string
+	}{
+		{
+			name:     "nil tags",
+			self:     nil,
+			expected: "",
+		},
+		{
+			name: "owned by the cluster",
+			self: Tags{
+				ClusterTagKey("test"): string(ResourceLifecycleOwned),
+			},
+			expected: "",
+		},
+		{
+			name: "shared with another cluster",
+			self: Tags{
+				ClusterTagKey("other"): string(ResourceLifecycleShared),
+			},
+			expected: "",
+		},
+		{
+			name: "owned by another cluster",
+			self: Tags{
+				"Name":                 "test",
+				ClusterTagKey("other"): string(ResourceLifecycleOwned),
+			},
+			expected: "other",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if e, a := tc.expected, tc.self.GetOtherOwner("test"); e != a {
+				t.Errorf("expected %q, got %q", e, a)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"sort"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 )
@@ -30,14 +31,20 @@ const (
 	DefaultNameSuffix = ".cluster-api-provider-aws.sigs.k8s.io"
 )
 
-// AWSResourceReference is a reference to a specific AWS resource by ID, ARN, or filters.
-// Only one of ID, ARN or Filters may be specified. Specifying more than one will result in
+// AWSResourceReference is a reference to a specific AWS resource by ID, ARN, filters, or SSM parameter.
+// Only one of ID, ARN, Filters or SSMParameter may be specified. Specifying more than one will result in
 // a validation error.
 type AWSResourceReference struct {
 	// ID of resource
 	// +optional
 	ID *string `json:"id,omitempty"`
 
+	// SSMParameter is the name of an SSM parameter whose value is the ID of the resource,
+	// for example /aws/service/canonical/ubuntu/server/20.04/stable/current/amd64/hvm/ebs-gp2/ami-id.
+	// Only supported for AMIs.
+	// +optional
+	SSMParameter *string `json:"ssmParameter,omitempty"`
+
 	// ARN of resource
 	// +optional
 	ARN *string `json:"arn,omitempty"`
@@ -49,6 +56,65 @@ type AWSResourceReference struct {
 	Filters []Filter `json:"filters,omitempty"`
 }
 
+// Platform is the operating system platform of an instance.
+type Platform string
+
+var (
+	// PlatformLinux is the platform of Linux instances.
+	PlatformLinux = Platform("linux")
+
+	// PlatformWindows is the platform of Windows instances.
+	PlatformWindows = Platform("windows")
+)
+
+// IsWindows returns true if the platform is Windows.
+func (p Platform) IsWindows() bool {
+	return p == PlatformWindows
+}
+
+// UserDataFormat is the format of the bootstrap data passed to instances.
+type UserDataFormat string
+
+var (
+	// UserDataFormatCloudConfig is the format of bootstrap data processed by cloud-init.
+	UserDataFormatCloudConfig = UserDataFormat("cloud-config")
+
+	// UserDataFormatIgnition is the format of bootstrap data processed by Ignition, as used by
+	// Flatcar Container Linux and Fedora CoreOS.
+	UserDataFormatIgnition = UserDataFormat("ignition")
+)
+
+// IsIgnition returns true if the bootstrap data is an Ignition config.
+func (f UserDataFormat) IsIgnition() bool {
+	return f == UserDataFormatIgnition
+}
+
+// S3Bucket defines an S3 bucket owned by the cluster, used to pass bootstrap data to instances.
+type S3Bucket struct {
+	// Name of the bucket. The bucket is created if it doesn't exist, and deleted with the cluster.
+	// The IAM policies created by clusterawsadm only grant access to buckets whose name starts with
+	// the prefix configured there, cluster-api-provider-aws- by default.
+	// +kubebuilder:validation:MinLength:=3
+	// +kubebuilder:validation:MaxLength:=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`
+	Name string `json:"name"`
+}
+
+// ResolvedImage is an AMI resolved from an SSM parameter or an image lookup.
+type ResolvedImage struct {
+	// ID of the AMI.
+	ID string `json:"id"`
+
+	// Source describes what the AMI was resolved from: the SSM parameter, or the image
+	// lookup parameters and Kubernetes version. The AMI is resolved again when it changes.
+	Source string `json:"source"`
+
+	// ResolvedAt is the time the AMI was resolved from its source. Machines cloned from the same
+	// template reuse the most recently resolved AMI.
+	// +optional
+	ResolvedAt *metav1.Time `json:"resolvedAt,omitempty"`
+}
+
 // AWSMachineTemplateResource describes the data needed to create am AWSMachine from a template
 type AWSMachineTemplateResource struct {
 	// Spec is the specification of the desired behavior of the machine.
@@ -366,6 +432,34 @@ type CNISpec struct {
 	// CNIIngressRules specify rules to apply to control plane and worker node security groups.
 	// The source for the rule will be set to control plane and worker security group IDs.
 	CNIIngressRules CNIIngressRules `json:"cniIngressRules,omitempty"`
+
+	// WindowsIngressRules adds the rules needed by the overlay networks of CNI plugins for
+	// Windows nodes, such as Calico and Flannel in VXLAN mode, to the CNI ingress rules.
+	// +optional
+	WindowsIngressRules bool `json:"windowsIngressRules,omitempty"`
+}
+
+// IngressRules returns the CNI ingress rules, including the rules for Windows nodes when enabled.
+func (c *CNISpec) IngressRules() CNIIngressRules {
+	if !c.WindowsIngressRules {
+		return c.CNIIngressRules
+	}
+
+	rules := append(CNIIngressRules{}, c.CNIIngressRules...)
+	return append(rules,
+		&CNIIngressRule{
+			Description: "VXLAN (windows)",
+			Protocol:    SecurityGroupProtocolUDP,
+			FromPort:    4789,
+			ToPort:      4789,
+		},
+		&CNIIngressRule{
+			Description: "typha (calico windows)",
+			Protocol:    SecurityGroupProtocolTCP,
+			FromPort:    5473,
+			ToPort:      5473,
+		},
+	)
 }
 
 // CNIIngressRules is a slice of CNIIngressRule
@@ -635,6 +729,10 @@ type Instance struct {
 	// Indicates whether the instance is optimized for Amazon EBS I/O.
 	EBSOptimized *bool `json:"ebsOptimized,omitempty"`
 
+	// The device name of the root volume of the instance.
+	// +optional
+	RootDeviceName string `json:"rootDeviceName,omitempty"`
+
 	// Configuration options for the root storage volume.
 	// +optional
 	RootVolume *Volume `json:"rootVolume,omitempty"`
@@ -658,6 +756,30 @@ type Instance struct {
 	// Tenancy indicates if instance should run on shared or single-tenant hardware.
 	// +optional
 	Tenancy string `json:"tenancy,omitempty"`
+
+	// PlacementGroupName is the name of the placement group the instance runs in, if any.
+	// +optional
+	PlacementGroupName string `json:"placementGroupName,omitempty"`
+
+	// PlacementGroupPartition is the partition of the placement group the instance runs in, if any.
+	// +optional
+	PlacementGroupPartition *int64 `json:"placementGroupPartition,omitempty"`
+
+	// CapacityReservation is the capacity reservation preference the instance was launched with.
+	// +optional
+	CapacityReservation *CapacityReservationSpec `json:"capacityReservation,omitempty"`
+
+	// HostID is the ID of the Dedicated Host the instance runs on, if any.
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// HostAffinity is the affinity setting between the instance and its Dedicated Host, if any.
+	// +optional
+	HostAffinity *string `json:"hostAffinity,omitempty"`
+
+	// HostResourceGroupARN is the ARN of the host resource group to launch the instance into, if any.
+	// +optional
+	HostResourceGroupARN *string `json:"hostResourceGroupARN,omitempty"`
 }
 
 // Volume encapsulates the configuration options for the storage device
@@ -679,6 +801,10 @@ type Volume struct {
 	// +optional
 	IOPS int64 `json:"iops,omitempty"`
 
+	// Throughput is the throughput in MiB/s requested for the disk. Only applicable to gp3 volumes.
+	// +optional
+	Throughput *int64 `json:"throughput,omitempty"`
+
 	// Encrypted is whether the volume should be encrypted or not.
 	// +optional
 	Encrypted bool `json:"encrypted,omitempty"`
@@ -688,6 +814,10 @@ type Volume struct {
 	// The key must already exist and be accessible by the controller.
 	// +optional
 	EncryptionKey string `json:"encryptionKey,omitempty"`
+
+	// Tags is a map of tags to add to the volume in addition to the cluster ownership tags.
+	// +optional
+	Tags Tags `json:"tags,omitempty"`
 }
 
 // SpotMarketOptions defines the options available to a user when configuring
@@ -699,3 +829,250 @@ type SpotMarketOptions struct {
 	// +kubebuilder:validation:pattern="^[0-9]+(\.[0-9]+)?$"
 	MaxPrice *string `json:"maxPrice,omitempty"`
 }
+
+// PlacementGroupStrategy is the strategy used to place instances within a placement group.
+type PlacementGroupStrategy string
+
+var (
+	// PlacementGroupStrategyCluster packs instances close together inside a single availability zone.
+	PlacementGroupStrategyCluster = PlacementGroupStrategy("cluster")
+
+	// PlacementGroupStrategySpread places instances on distinct underlying hardware.
+	PlacementGroupStrategySpread = PlacementGroupStrategy("spread")
+
+	// PlacementGroupStrategyPartition spreads instances across logical partitions
+	// that do not share underlying hardware.
+	PlacementGroupStrategyPartition = PlacementGroupStrategy("partition")
+)
+
+// PlacementGroup defines the EC2 placement group instances are launched into.
+type PlacementGroup struct {
+	// Name is the name of the placement group.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Strategy is the placement strategy of the group. When set, the placement group is
+	// managed by the provider: it is created with this strategy if it does not already exist
+	// and is deleted together with the cluster. When omitted, the placement group must already exist.
+	// +optional
+	// +kubebuilder:validation:Enum=cluster;spread;partition
+	Strategy PlacementGroupStrategy `json:"strategy,omitempty"`
+
+	// PartitionCount is the number of partitions of a managed partition placement group.
+	// Only valid when Strategy is partition.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=7
+	PartitionCount *int64 `json:"partitionCount,omitempty"`
+
+	// PartitionNumber is the partition to launch instances into.
+	// Only applicable to partition placement groups.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PartitionNumber *int64 `json:"partitionNumber,omitempty"`
+}
+
+// IsManaged returns true if the placement group is managed by the provider.
+func (p *PlacementGroup) IsManaged() bool {
+	return p != nil && p.Strategy != ""
+}
+
+// CapacityReservationPreference describes the preference of an instance for running in open capacity reservations.
+type CapacityReservationPreference string
+
+var (
+	// CapacityReservationPreferenceOpen runs the instance in any open capacity reservation
+	// that has matching attributes, falling back to On-Demand capacity.
+	CapacityReservationPreferenceOpen = CapacityReservationPreference("open")
+
+	// CapacityReservationPreferenceNone avoids running the instance in a capacity reservation.
+	CapacityReservationPreferenceNone = CapacityReservationPreference("none")
+)
+
+// CapacityReservationSpec defines the On-Demand Capacity Reservation an instance should be launched into.
+// Only one of Preference, ID or ResourceGroupARN may be set.
+type CapacityReservationSpec struct {
+	// Preference is the capacity reservation preference of the instance.
+	// +optional
+	// +kubebuilder:validation:Enum=open;none
+	Preference CapacityReservationPreference `json:"preference,omitempty"`
+
+	// ID is the ID of a specific capacity reservation to launch the instance into.
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// ResourceGroupARN is the ARN of a capacity reservation resource group to launch the instance into.
+	// +optional
+	ResourceGroupARN *string `json:"resourceGroupARN,omitempty"`
+}
+
+// IsTargeted returns true if the spec targets a specific capacity reservation or resource group.
+func (c *CapacityReservationSpec) IsTargeted() bool {
+	return c != nil && (c.ID != nil || c.ResourceGroupARN != nil)
+}
+
+// DedicatedHostAffinity describes the affinity between an instance and a Dedicated Host.
+type DedicatedHostAffinity string
+
+var (
+	// DedicatedHostAffinityDefault lets a stopped instance restart on any available Dedicated Host.
+	DedicatedHostAffinityDefault = DedicatedHostAffinity("default")
+
+	// DedicatedHostAffinityHost restarts a stopped instance on the Dedicated Host it was launched on.
+	DedicatedHostAffinityHost = DedicatedHostAffinity("host")
+)
+
+// DedicatedHostSpec defines the Dedicated Host an instance with host tenancy is launched onto.
+// Only one of ID, ResourceGroupARN or Managed may be set.
+type DedicatedHostSpec struct {
+	// ID is the ID of the Dedicated Host to launch the instance onto.
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// ResourceGroupARN is the ARN of the host resource group to launch the instance into.
+	// +optional
+	ResourceGroupARN *string `json:"resourceGroupARN,omitempty"`
+
+	// Managed enables allocation of Dedicated Hosts by the provider. A cluster-owned Dedicated
+	// Host with free capacity for the instance type is reused, or allocated in the availability zone
+	// of the instance if none exists. Managed hosts are released once they no longer run any instances.
+	// +optional
+	Managed bool `json:"managed,omitempty"`
+
+	// Affinity is the affinity between the instance and the Dedicated Host.
+	// +optional
+	// +kubebuilder:validation:Enum=default;host
+	Affinity DedicatedHostAffinity `json:"affinity,omitempty"`
+}
+
+// IsManaged returns true if Dedicated Hosts are allocated by the provider.
+func (d *DedicatedHostSpec) IsManaged() bool {
+	return d != nil && d.Managed
+}
+
+// SecondaryNetworkInterface defines an additional elastic network interface which is created
+// and attached to an instance by the provider.
+type SecondaryNetworkInterface struct {
+	// DeviceIndex is the index of the device on the instance for the network interface attachment.
+	// Index 0 is reserved for the primary network interface.
+	// +kubebuilder:validation:Minimum=1
+	DeviceIndex int64 `json:"deviceIndex"`
+
+	// Subnet is a reference to the subnet to create the network interface in. If not specified,
+	// the subnet of the instance is used.
+	// +optional
+	Subnet *AWSResourceReference `json:"subnet,omitempty"`
+
+	// SecurityGroups is a list of references to the security groups to apply to the network
+	// interface. If not specified, the core security groups of the machine are applied.
+	// +optional
+	SecurityGroups []AWSResourceReference `json:"securityGroups,omitempty"`
+
+	// SecondaryPrivateIPCount is the number of secondary private IPv4 addresses to assign
+	// to the network interface.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	SecondaryPrivateIPCount *int64 `json:"secondaryPrivateIPCount,omitempty"`
+
+	// IPv4PrefixCount is the number of /28 IPv4 prefixes to delegate to the network interface.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	IPv4PrefixCount *int64 `json:"ipv4PrefixCount,omitempty"`
+
+	// Description is the description of the network interface.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// SecondaryNetworkInterfaces is a slice of SecondaryNetworkInterface.
+type SecondaryNetworkInterfaces []SecondaryNetworkInterface
+
+// InstanceScheduledEvent describes an event scheduled by AWS for an instance, such as a retirement or a maintenance.
+type InstanceScheduledEvent struct {
+	// ID is the ID of the event.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Code is the type of the event, for example instance-retirement, instance-stop, system-reboot,
+	// system-maintenance or instance-reboot.
+	Code string `json:"code"`
+
+	// Description is the description of the event.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// NotBefore is the earliest scheduled start time of the event.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the latest scheduled end time of the event.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// NetworkInterfaceStatus describes a secondary network interface attached to an instance.
+type NetworkInterfaceStatus struct {
+	// ID is the ID of the network interface.
+	ID string `json:"id"`
+
+	// DeviceIndex is the index of the device on the instance the network interface is attached to.
+	DeviceIndex int64 `json:"deviceIndex"`
+
+	// SubnetID is the ID of the subnet of the network interface.
+	// +optional
+	SubnetID string `json:"subnetID,omitempty"`
+
+	// MACAddress is the MAC address of the network interface.
+	// +optional
+	MACAddress string `json:"macAddress,omitempty"`
+
+	// PrivateIPs is the list of private IPv4 addresses of the network interface,
+	// starting with the primary private IPv4 address.
+	// +optional
+	PrivateIPs []string `json:"privateIPs,omitempty"`
+
+	// IPv4Prefixes is the list of IPv4 prefixes delegated to the network interface.
+	// +optional
+	IPv4Prefixes []string `json:"ipv4Prefixes,omitempty"`
+}
+
+// BootDiagnosticsTrigger is the reason the boot diagnostics of an instance were captured.
+type BootDiagnosticsTrigger string
+
+var (
+	// BootDiagnosticsTriggerNodeTimeout is used when the instance has been running longer than the
+	// boot diagnostics timeout without becoming a node.
+	BootDiagnosticsTriggerNodeTimeout = BootDiagnosticsTrigger("NodeTimeout")
+
+	// BootDiagnosticsTriggerInstanceStopped is used when the instance stopped without being asked to.
+	BootDiagnosticsTriggerInstanceStopped = BootDiagnosticsTrigger("InstanceStopped")
+)
+
+// BootDiagnostics configures the capture of the console output of an instance which fails to bootstrap.
+type BootDiagnostics struct {
+	// Timeout is how long the instance may run without its Machine getting a node reference before
+	// its console output is captured. Defaults to 15m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Screenshot also captures a screenshot of the console of the instance. Screenshots are not
+	// supported by all instance types.
+	// +optional
+	Screenshot bool `json:"screenshot,omitempty"`
+}
+
+// BootDiagnosticsStatus describes the boot diagnostics captured for an instance.
+type BootDiagnosticsStatus struct {
+	// InstanceID is the ID of the instance the boot diagnostics were captured from.
+	InstanceID string `json:"instanceID"`
+
+	// Trigger is the reason the boot diagnostics were captured.
+	Trigger BootDiagnosticsTrigger `json:"trigger"`
+
+	// CapturedAt is the time the boot diagnostics were captured.
+	CapturedAt metav1.Time `json:"capturedAt"`
+
+	// SecretName is the name of the Secret holding the console output, under the console-output key,
+	// and the screenshot when one was captured, under the screenshot.jpg key.
+	SecretName string `json:"secretName"`
+}
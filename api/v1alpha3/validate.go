@@ -19,6 +19,7 @@ package v1alpha3
 import (
 	"fmt"
 	"net"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -34,6 +35,24 @@ func (b *Bastion) Validate() []*field.Error {
 		return errs
 	}
 
+	if b.IsSSM() {
+		if b.InstanceType != "" {
+			errs = append(errs,
+				field.Forbidden(field.NewPath("spec", "bastion", "instanceType"), "cannot be set if spec.bastion.mode is ssm"),
+			)
+		}
+		if b.AMI != "" {
+			errs = append(errs,
+				field.Forbidden(field.NewPath("spec", "bastion", "ami"), "cannot be set if spec.bastion.mode is ssm"),
+			)
+		}
+		if b.HighAvailability {
+			errs = append(errs,
+				field.Forbidden(field.NewPath("spec", "bastion", "highAvailability"), "cannot be set if spec.bastion.mode is ssm"),
+			)
+		}
+	}
+
 	for i, cidr := range b.AllowedCIDRBlocks {
 		if _, _, err := net.ParseCIDR(cidr); err != nil {
 			errs = append(errs,
@@ -43,3 +62,265 @@ func (b *Bastion) Validate() []*field.Error {
 	}
 	return errs
 }
+
+// ValidateAMI will validate a reference to an AMI
+func (r *AWSResourceReference) ValidateAMI(fldPath *field.Path) []*field.Error {
+	var errs field.ErrorList
+
+	if r.SSMParameter == nil {
+		return errs
+	}
+
+	if *r.SSMParameter == "" {
+		errs = append(errs,
+			field.Invalid(fldPath.Child("ssmParameter"), *r.SSMParameter, "must not be empty"),
+		)
+	}
+
+	if r.ID != nil || r.ARN != nil || len(r.Filters) > 0 {
+		errs = append(errs,
+			field.Forbidden(fldPath, "only one of id, arn, filters or ssmParameter can be set"),
+		)
+	}
+
+	return errs
+}
+
+// Validate will validate the platform against the architecture of the image to look up
+func (p Platform) Validate(fldPath *field.Path, imageLookupArchitecture string) []*field.Error {
+	var errs field.ErrorList
+
+	if p.IsWindows() && imageLookupArchitecture == "arm64" {
+		errs = append(errs,
+			field.Forbidden(fldPath, "windows instances are not supported on the arm64 architecture"),
+		)
+	}
+
+	return errs
+}
+
+// Validate will validate the userdata format against the platform of the instance
+func (f UserDataFormat) Validate(fldPath *field.Path, platform Platform) []*field.Error {
+	var errs field.ErrorList
+
+	if f.IsIgnition() && platform.IsWindows() {
+		errs = append(errs,
+			field.Forbidden(fldPath, "ignition is not supported on windows instances"),
+		)
+	}
+
+	return errs
+}
+
+// Validate will validate that the secrets holding the userdata are only configured when they are used
+func (c CloudInit) Validate(fldPath *field.Path) []*field.Error {
+	var errs field.ErrorList
+
+	if c.InsecureSkipSecretsManager {
+		insecure := fmt.Sprintf("cannot be set if %s is true", fldPath.Child("insecureSkipSecretsManager"))
+		if c.SecretPrefix != "" {
+			errs = append(errs, field.Forbidden(fldPath.Child("secretPrefix"), insecure))
+		}
+		if c.SecretCount != 0 {
+			errs = append(errs, field.Forbidden(fldPath.Child("secretCount"), insecure))
+		}
+		if c.SecureSecretsBackend != "" {
+			errs = append(errs, field.Forbidden(fldPath.Child("secureSecretsBackend"), insecure))
+		}
+		if c.KMSKeyID != "" {
+			errs = append(errs, field.Forbidden(fldPath.Child("kmsKeyID"), insecure))
+		}
+	}
+
+	if (c.SecretPrefix != "") != (c.SecretCount != 0) {
+		errs = append(errs, field.Forbidden(fldPath.Child("secretCount"), fmt.Sprintf("must be set together with %s", fldPath.Child("secretPrefix"))))
+	}
+
+	return errs
+}
+
+// Validate will validate the placement group fields
+func (p *PlacementGroup) Validate(fldPath *field.Path) []*field.Error {
+	var errs field.ErrorList
+
+	if p == nil {
+		return errs
+	}
+
+	if p.PartitionCount != nil && p.Strategy != PlacementGroupStrategyPartition {
+		errs = append(errs,
+			field.Forbidden(fldPath.Child("partitionCount"), "can only be set if strategy is partition"),
+		)
+	}
+
+	if p.PartitionNumber != nil && p.IsManaged() && p.Strategy != PlacementGroupStrategyPartition {
+		errs = append(errs,
+			field.Forbidden(fldPath.Child("partitionNumber"), "can only be set if strategy is partition"),
+		)
+	}
+
+	if p.PartitionNumber != nil && p.PartitionCount != nil && *p.PartitionNumber > *p.PartitionCount {
+		errs = append(errs,
+			field.Invalid(fldPath.Child("partitionNumber"), *p.PartitionNumber, "must not be greater than partitionCount"),
+		)
+	}
+
+	return errs
+}
+
+// Validate will validate the capacity reservation fields
+func (c *CapacityReservationSpec) Validate(fldPath *field.Path) []*field.Error {
+	var errs field.ErrorList
+
+	if c == nil {
+		return errs
+	}
+
+	set := 0
+	for _, isSet := range []bool{c.Preference != "", c.ID != nil, c.ResourceGroupARN != nil} {
+		if isSet {
+			set++
+		}
+	}
+
+	if set > 1 {
+		errs = append(errs,
+			field.Forbidden(fldPath, "only one of preference, id or resourceGroupARN can be set"),
+		)
+	}
+
+	return errs
+}
+
+// Validate will validate the dedicated host fields
+func (d *DedicatedHostSpec) Validate(fldPath *field.Path, tenancy string) []*field.Error {
+	var errs field.ErrorList
+
+	if d == nil {
+		return errs
+	}
+
+	if tenancy != "host" {
+		errs = append(errs,
+			field.Forbidden(fldPath, "can only be set if tenancy is host"),
+		)
+	}
+
+	set := 0
+	for _, isSet := range []bool{d.ID != nil, d.ResourceGroupARN != nil, d.Managed} {
+		if isSet {
+			set++
+		}
+	}
+
+	if set > 1 {
+		errs = append(errs,
+			field.Forbidden(fldPath, "only one of id, resourceGroupARN or managed can be set"),
+		)
+	}
+
+	return errs
+}
+
+// Validate will validate the secondary network interface fields.
+// primaryInterfaces is the number of existing network interfaces attached from device index 0.
+func (n SecondaryNetworkInterfaces) Validate(fldPath *field.Path, primaryInterfaces int) []*field.Error {
+	var errs field.ErrorList
+
+	deviceIndexes := make(map[int64]bool, len(n))
+	for i, eni := range n {
+		idxPath := fldPath.Index(i)
+
+		switch {
+		case eni.DeviceIndex < 1:
+			errs = append(errs,
+				field.Invalid(idxPath.Child("deviceIndex"), eni.DeviceIndex, "must be greater than 0"),
+			)
+		case eni.DeviceIndex < int64(primaryInterfaces):
+			errs = append(errs,
+				field.Invalid(idxPath.Child("deviceIndex"), eni.DeviceIndex, "is already used by spec.networkInterfaces"),
+			)
+		case deviceIndexes[eni.DeviceIndex]:
+			errs = append(errs,
+				field.Duplicate(idxPath.Child("deviceIndex"), eni.DeviceIndex),
+			)
+		}
+		deviceIndexes[eni.DeviceIndex] = true
+
+		if eni.Subnet != nil && eni.Subnet.ID == nil && len(eni.Subnet.Filters) == 0 {
+			errs = append(errs,
+				field.Required(idxPath.Child("subnet"), "either id or filters must be set"),
+			)
+		}
+
+		for j, sg := range eni.SecurityGroups {
+			if sg.ID == nil {
+				errs = append(errs,
+					field.Required(idxPath.Child("securityGroups").Index(j).Child("id"), "must be set"),
+				)
+			}
+		}
+	}
+
+	return errs
+}
+
+// Validate ensures the existing infrastructure to adopt is named in the network spec of the cluster.
+func (a *InfrastructureAdoption) Validate(fldPath *field.Path, network *NetworkSpec) []*field.Error {
+	var errs field.ErrorList
+
+	if a == nil {
+		return errs
+	}
+
+	switch a.Mode {
+	case InfrastructureAdoptionModeDryRun, InfrastructureAdoptionModeAdopt:
+	default:
+		errs = append(errs,
+			field.NotSupported(fldPath.Child("mode"), a.Mode, []string{string(InfrastructureAdoptionModeDryRun), string(InfrastructureAdoptionModeAdopt)}),
+		)
+	}
+
+	networkPath := field.NewPath("spec", "networkSpec")
+	if network.VPC.ID == "" {
+		errs = append(errs,
+			field.Required(networkPath.Child("vpc", "id"), "must be set to adopt an existing VPC"),
+		)
+	}
+	if len(network.Subnets) == 0 {
+		errs = append(errs,
+			field.Required(networkPath.Child("subnets"), "must be set to adopt existing subnets"),
+		)
+	}
+	for i, subnet := range network.Subnets {
+		if subnet.ID == "" {
+			errs = append(errs,
+				field.Required(networkPath.Child("subnets").Index(i).Child("id"), "must be set to adopt an existing subnet"),
+			)
+		}
+	}
+
+	ids := make(map[string]bool, len(a.SecurityGroups))
+	for role, id := range a.SecurityGroups {
+		rolePath := fldPath.Child("securityGroups").Key(string(role))
+		if ids[id] {
+			errs = append(errs, field.Duplicate(rolePath, id))
+		}
+		ids[id] = true
+		switch role {
+		case SecurityGroupBastion, SecurityGroupAPIServerLB, SecurityGroupLB, SecurityGroupControlPlane, SecurityGroupNode:
+		default:
+			errs = append(errs,
+				field.NotSupported(rolePath, role, []string{
+					string(SecurityGroupBastion), string(SecurityGroupAPIServerLB), string(SecurityGroupLB),
+					string(SecurityGroupControlPlane), string(SecurityGroupNode),
+				}),
+			)
+		}
+		if !strings.HasPrefix(id, "sg-") {
+			errs = append(errs, field.Invalid(rolePath, id, "must be the ID of a security group"))
+		}
+	}
+
+	return errs
+}
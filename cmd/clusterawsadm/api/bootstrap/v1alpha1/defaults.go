@@ -28,6 +28,8 @@ const (
 	DefaultBootstrapUserName = "bootstrapper.cluster-api-provider-aws.sigs.k8s.io"
 	// DefaultStackName is the default CloudFormation stack name.
 	DefaultStackName = "cluster-api-provider-aws-sigs-k8s-io"
+	// DefaultS3BucketPrefix is the default prefix of the names of S3 buckets.
+	DefaultS3BucketPrefix = "cluster-api-provider-aws-"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -63,6 +65,9 @@ func SetDefaults_AWSIAMConfigurationSpec(obj *AWSIAMConfigurationSpec) { //nolin
 			Disable: true,
 		}
 	}
+	if obj.S3Buckets != nil && obj.S3Buckets.NamePrefix == "" {
+		obj.S3Buckets.NamePrefix = DefaultS3BucketPrefix
+	}
 	if len(obj.SecureSecretsBackends) == 0 {
 		obj.SecureSecretsBackends = []infrav1.SecretBackend{
 			infrav1.SecretBackendSecretsManager,
@@ -107,6 +107,34 @@ type EKSConfig struct {
 	ManagedMachinePool *AWSIAMRoleSpec `json:"managedMachinePool,omitempty"`
 }
 
+// EventBridgeConfig represents the configuration for consuming EC2 instance events
+// through EventBridge and SQS
+type EventBridgeConfig struct {
+	// Enable controls whether permissions are granted to manage EventBridge rules and
+	// SQS queues, and to consume EC2 instance events from them
+	Enable bool `json:"enable,omitempty"`
+}
+
+// S3BucketsConfig represents the configuration for the S3 buckets in which clusters
+// store the Ignition configs of their instances
+type S3BucketsConfig struct {
+	// Enable controls whether permissions are granted to manage S3 buckets, and to
+	// let instances read their Ignition config from them
+	Enable bool `json:"enable,omitempty"`
+
+	// NamePrefix is the prefix of the names of the S3 buckets which can be managed.
+	// Defaults to cluster-api-provider-aws-
+	NamePrefix string `json:"namePrefix,omitempty"`
+}
+
+// SessionManagerConfig represents the configuration for reaching instances through
+// AWS Systems Manager Session Manager
+type SessionManagerConfig struct {
+	// Enable controls whether the control plane and node roles are allowed to be
+	// managed by AWS Systems Manager, so that sessions can be started on their instances
+	Enable bool `json:"enable,omitempty"`
+}
+
 // ClusterAPIControllers controls the configuration of the AWS IAM role for
 // the Kubernetes Cluster API Provider AWS controller.
 type ClusterAPIControllers struct {
@@ -174,11 +202,27 @@ type AWSIAMConfigurationSpec struct {
 	// and nodes roles
 	EKS *EKSConfig `json:"eks,omitempty"`
 
+	// EventBridge controls the configuration for consuming EC2 instance events, such as
+	// Spot interruption warnings, through EventBridge and SQS
+	EventBridge *EventBridgeConfig `json:"eventBridge,omitempty"`
+
+	// S3Buckets controls the configuration for storing Ignition configs in S3 buckets
+	S3Buckets *S3BucketsConfig `json:"s3Buckets,omitempty"`
+
+	// SessionManager controls the configuration for reaching instances through AWS Systems
+	// Manager Session Manager, as with the ssm bastion mode
+	SessionManager *SessionManagerConfig `json:"sessionManager,omitempty"`
+
 	// SecureSecretsBackend, when set to parameter-store will create AWS Systems Manager
-	// Parameter Storage policies. By default or with the value of secrets-manager,
-	// will generate AWS Secrets Manager policies instead.
-	// +kubebuilder:validation:Enum=secrets-manager;ssm-parameter-store
+	// Parameter Storage policies. When set to s3, will generate S3 bucket policies. By default or
+	// with the value of secrets-manager, will generate AWS Secrets Manager policies instead.
+	// +kubebuilder:validation:Enum=secrets-manager;ssm-parameter-store;s3
 	SecureSecretsBackends []infrav1.SecretBackend `json:"secureSecretBackends,omitempty"`
+
+	// SecureSecretsKMSKeyARNs are the ARNs of the customer managed KMS keys with which secure
+	// secrets are encrypted. The controllers are allowed to encrypt with them, and instances to
+	// decrypt with them.
+	SecureSecretsKMSKeyARNs []string `json:"secureSecretsKMSKeyARNs,omitempty"`
 }
 
 func (obj *AWSIAMConfiguration) GetObjectKind() schema.ObjectKind {
@@ -68,11 +68,31 @@ func (in *AWSIAMConfigurationSpec) DeepCopyInto(out *AWSIAMConfigurationSpec) {
 		*out = new(EKSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EventBridge != nil {
+		in, out := &in.EventBridge, &out.EventBridge
+		*out = new(EventBridgeConfig)
+		**out = **in
+	}
+	if in.S3Buckets != nil {
+		in, out := &in.S3Buckets, &out.S3Buckets
+		*out = new(S3BucketsConfig)
+		**out = **in
+	}
+	if in.SessionManager != nil {
+		in, out := &in.SessionManager, &out.SessionManager
+		*out = new(SessionManagerConfig)
+		**out = **in
+	}
 	if in.SecureSecretsBackends != nil {
 		in, out := &in.SecureSecretsBackends, &out.SecureSecretsBackends
 		*out = make([]v1alpha3.SecretBackend, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecureSecretsKMSKeyARNs != nil {
+		in, out := &in.SecureSecretsKMSKeyARNs, &out.SecureSecretsKMSKeyARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSIAMConfigurationSpec.
@@ -223,6 +243,21 @@ func (in *EKSConfig) DeepCopy() *EKSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventBridgeConfig) DeepCopyInto(out *EventBridgeConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventBridgeConfig.
+func (in *EventBridgeConfig) DeepCopy() *EventBridgeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventBridgeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Nodes) DeepCopyInto(out *Nodes) {
 	*out = *in
@@ -238,3 +273,33 @@ func (in *Nodes) DeepCopy() *Nodes {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BucketsConfig) DeepCopyInto(out *S3BucketsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BucketsConfig.
+func (in *S3BucketsConfig) DeepCopy() *S3BucketsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BucketsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionManagerConfig) DeepCopyInto(out *SessionManagerConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionManagerConfig.
+func (in *SessionManagerConfig) DeepCopy() *SessionManagerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionManagerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
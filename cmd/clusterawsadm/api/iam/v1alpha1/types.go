@@ -59,6 +59,9 @@ const (
 
 	// StringNotLike is an AWS IAM policy condition operator.
 	StringNotLike ConditionOperator = "StringNotLike"
+
+	// ArnEquals is an AWS IAM policy condition operator.
+	ArnEquals ConditionOperator = "ArnEquals"
 )
 
 // PolicyDocument represents an AWS IAM policy document, and can be
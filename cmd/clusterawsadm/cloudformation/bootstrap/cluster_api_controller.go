@@ -63,11 +63,18 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 			Resource: iamv1.Resources{iamv1.Any},
 			Action: iamv1.Actions{
 				"ec2:AllocateAddress",
+				"ec2:AllocateHosts",
+				"ec2:AssociateAddress",
 				"ec2:AssociateRouteTable",
 				"ec2:AttachInternetGateway",
+				"ec2:AttachNetworkInterface",
 				"ec2:AuthorizeSecurityGroupIngress",
 				"ec2:CreateInternetGateway",
+				"ec2:CreateLaunchTemplate",
+				"ec2:CreateLaunchTemplateVersion",
 				"ec2:CreateNatGateway",
+				"ec2:CreateNetworkInterface",
+				"ec2:CreatePlacementGroup",
 				"ec2:CreateRoute",
 				"ec2:CreateRouteTable",
 				"ec2:CreateSecurityGroup",
@@ -76,7 +83,10 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 				"ec2:CreateVpc",
 				"ec2:ModifyVpcAttribute",
 				"ec2:DeleteInternetGateway",
+				"ec2:DeleteLaunchTemplate",
 				"ec2:DeleteNatGateway",
+				"ec2:DeleteNetworkInterface",
+				"ec2:DeletePlacementGroup",
 				"ec2:DeleteRouteTable",
 				"ec2:DeleteSecurityGroup",
 				"ec2:DeleteSubnet",
@@ -85,27 +95,41 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 				"ec2:DescribeAccountAttributes",
 				"ec2:DescribeAddresses",
 				"ec2:DescribeAvailabilityZones",
+				"ec2:DescribeHosts",
 				"ec2:DescribeInstances",
+				"ec2:DescribeInstanceStatus",
+				"ec2:DescribeInstanceTypes",
 				"ec2:DescribeInternetGateways",
+				"ec2:DescribeLaunchTemplateVersions",
 				"ec2:DescribeImages",
 				"ec2:DescribeNatGateways",
 				"ec2:DescribeNetworkInterfaces",
 				"ec2:DescribeNetworkInterfaceAttribute",
+				"ec2:DescribePlacementGroups",
 				"ec2:DescribeRouteTables",
 				"ec2:DescribeSecurityGroups",
 				"ec2:DescribeSubnets",
 				"ec2:DescribeVpcs",
 				"ec2:DescribeVpcAttribute",
 				"ec2:DescribeVolumes",
+				"ec2:DescribeVolumesModifications",
+				"ec2:DescribeVolumeStatus",
 				"ec2:DetachInternetGateway",
+				"ec2:GetConsoleOutput",
+				"ec2:GetConsoleScreenshot",
 				"ec2:DisassociateRouteTable",
 				"ec2:DisassociateAddress",
 				"ec2:ModifyInstanceAttribute",
 				"ec2:ModifyNetworkInterfaceAttribute",
 				"ec2:ModifySubnetAttribute",
+				"ec2:ModifyVolume",
+				"ec2:RebootInstances",
 				"ec2:ReleaseAddress",
+				"ec2:ReleaseHosts",
 				"ec2:RevokeSecurityGroupIngress",
 				"ec2:RunInstances",
+				"ec2:StartInstances",
+				"ec2:StopInstances",
 				"ec2:TerminateInstances",
 				"tag:GetResources",
 				"elasticloadbalancing:AddTags",
@@ -119,6 +143,22 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 				"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
 				"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
 				"elasticloadbalancing:RemoveTags",
+				"autoscaling:CreateAutoScalingGroup",
+				"autoscaling:DeleteAutoScalingGroup",
+				"autoscaling:DescribeAutoScalingGroups",
+				"autoscaling:StartInstanceRefresh",
+			},
+		},
+		{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"arn:*:iam::*:role/aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling",
+			},
+			Action: iamv1.Actions{
+				"iam:CreateServiceLinkedRole",
+			},
+			Condition: iamv1.Conditions{
+				iamv1.StringLike: map[string]string{"iam:AWSServiceName": "autoscaling.amazonaws.com"},
 			},
 		},
 		{
@@ -152,6 +192,15 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 				"iam:PassRole",
 			},
 		},
+		{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"arn:*:ssm:*:*:parameter/aws/service/*",
+			},
+			Action: iamv1.Actions{
+				"ssm:GetParameter",
+			},
+		},
 	}
 	for _, secureSecretBackend := range t.Spec.SecureSecretsBackends {
 		switch secureSecretBackend {
@@ -166,6 +215,14 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 					"secretsmanager:DeleteSecret",
 					"secretsmanager:TagResource",
 				},
+			}, iamv1.StatementEntry{
+				Effect: iamv1.EffectAllow,
+				Resource: iamv1.Resources{
+					iamv1.Any,
+				},
+				Action: iamv1.Actions{
+					"secretsmanager:ListSecrets",
+				},
 			})
 		case infrav1.SecretBackendSSMParameterStore:
 			statement = append(statement, iamv1.StatementEntry{
@@ -178,9 +235,70 @@ func (t Template) controllersPolicy() *iamv1.PolicyDocument {
 					"ssm:DeleteParameter",
 					"ssm:AddTagsToResource",
 				},
+			}, iamv1.StatementEntry{
+				Effect: iamv1.EffectAllow,
+				Resource: iamv1.Resources{
+					iamv1.Any,
+				},
+				Action: iamv1.Actions{
+					"ssm:DescribeParameters",
+				},
 			})
 		}
 	}
+	if len(t.Spec.SecureSecretsKMSKeyARNs) > 0 {
+		statement = append(statement, iamv1.StatementEntry{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources(t.Spec.SecureSecretsKMSKeyARNs),
+			Action: iamv1.Actions{
+				"kms:Decrypt",
+				"kms:Encrypt",
+				"kms:GenerateDataKey",
+			},
+		})
+	}
+	if t.s3BucketsEnabled() {
+		statement = append(statement, iamv1.StatementEntry{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				fmt.Sprintf("arn:*:s3:::%s*", t.s3BucketPrefix()),
+			},
+			Action: iamv1.Actions{
+				"s3:CreateBucket",
+				"s3:DeleteBucket",
+				"s3:DeleteObject",
+				"s3:GetBucketPublicAccessBlock",
+				"s3:GetBucketTagging",
+				"s3:GetEncryptionConfiguration",
+				"s3:ListBucket",
+				"s3:PutBucketPublicAccessBlock",
+				"s3:PutBucketTagging",
+				"s3:PutEncryptionConfiguration",
+				"s3:PutObject",
+			},
+		})
+	}
+	if t.Spec.EventBridge != nil && t.Spec.EventBridge.Enable {
+		statement = append(statement, iamv1.StatementEntry{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources{iamv1.Any},
+			Action: iamv1.Actions{
+				"events:DeleteRule",
+				"events:PutRule",
+				"events:PutTargets",
+				"events:RemoveTargets",
+				"events:TagResource",
+				"sqs:CreateQueue",
+				"sqs:DeleteMessage",
+				"sqs:DeleteQueue",
+				"sqs:GetQueueAttributes",
+				"sqs:GetQueueUrl",
+				"sqs:ReceiveMessage",
+				"sqs:SetQueueAttributes",
+				"sqs:TagQueue",
+			},
+		})
+	}
 	if t.Spec.EKS.Enable {
 		allowedIAMActions := iamv1.Actions{
 			"iam:GetRole",
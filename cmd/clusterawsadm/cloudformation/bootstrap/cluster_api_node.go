@@ -17,7 +17,11 @@ limitations under the License.
 package bootstrap
 
 import (
+	"fmt"
+
+	cfn_iam "github.com/awslabs/goformation/v4/cloudformation/iam"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	bootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/cmd/clusterawsadm/api/bootstrap/v1alpha1"
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/cmd/clusterawsadm/api/iam/v1alpha1"
 )
 
@@ -45,6 +49,16 @@ func (t Template) secretPolicy(secureSecretsBackend infrav1.SecretBackend) iamv1
 				"ssm:GetParameter",
 			},
 		}
+	case infrav1.SecretBackendS3:
+		return iamv1.StatementEntry{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				fmt.Sprintf("arn:*:s3:::%s*/secrets/*", t.s3BucketPrefix()),
+			},
+			Action: iamv1.Actions{
+				"s3:GetObject",
+			},
+		}
 	}
 	return iamv1.StatementEntry{}
 }
@@ -80,6 +94,23 @@ func (t Template) nodeManagedPolicies() []string {
 	return policies
 }
 
+// sessionManagerEnabled returns whether instances are to be managed by AWS Systems Manager.
+func (t Template) sessionManagerEnabled() bool {
+	return t.Spec.SessionManager != nil && t.Spec.SessionManager.Enable
+}
+
+// sessionManagerRolePolicy lets the instances of a role be managed by AWS Systems Manager, for roles
+// the nodes policy, which includes the session manager policy, is not attached to.
+func (t Template) sessionManagerRolePolicy() cfn_iam.Role_Policy {
+	return cfn_iam.Role_Policy{
+		PolicyName: "session-manager",
+		PolicyDocument: iamv1.PolicyDocument{
+			Statement: []iamv1.StatementEntry{t.sessionManagerPolicy()},
+			Version:   iamv1.CurrentVersion,
+		},
+	}
+}
+
 func (t Template) nodePolicy() *iamv1.PolicyDocument {
 	policyDocument := t.cloudProviderNodeAwsPolicy()
 	for _, secureSecretsBackend := range t.Spec.SecureSecretsBackends {
@@ -88,10 +119,52 @@ func (t Template) nodePolicy() *iamv1.PolicyDocument {
 			t.secretPolicy(secureSecretsBackend),
 		)
 	}
+	if len(t.Spec.SecureSecretsKMSKeyARNs) > 0 {
+		policyDocument.Statement = append(policyDocument.Statement, iamv1.StatementEntry{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources(t.Spec.SecureSecretsKMSKeyARNs),
+			Action: iamv1.Actions{
+				"kms:Decrypt",
+			},
+		})
+	}
 	policyDocument.Statement = append(
 		policyDocument.Statement,
 		t.sessionManagerPolicy(),
 	)
+	if t.Spec.S3Buckets != nil && t.Spec.S3Buckets.Enable {
+		policyDocument.Statement = append(policyDocument.Statement, iamv1.StatementEntry{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				fmt.Sprintf("arn:*:s3:::%s*/*", t.s3BucketPrefix()),
+			},
+			Action: iamv1.Actions{
+				"s3:GetObject",
+			},
+		})
+	}
 
 	return policyDocument
 }
+
+// s3BucketsEnabled returns whether the controllers can manage S3 buckets, either to store Ignition
+// configs or bootstrap data with the s3 secure secrets backend.
+func (t Template) s3BucketsEnabled() bool {
+	if t.Spec.S3Buckets != nil && t.Spec.S3Buckets.Enable {
+		return true
+	}
+	for _, secureSecretsBackend := range t.Spec.SecureSecretsBackends {
+		if secureSecretsBackend == infrav1.SecretBackendS3 {
+			return true
+		}
+	}
+	return false
+}
+
+// s3BucketPrefix returns the prefix of the names of the S3 buckets which can be managed.
+func (t Template) s3BucketPrefix() string {
+	if t.Spec.S3Buckets != nil && t.Spec.S3Buckets.NamePrefix != "" {
+		return t.Spec.S3Buckets.NamePrefix
+	}
+	return bootstrapv1.DefaultS3BucketPrefix
+}
@@ -35,6 +35,9 @@ func (t Template) controlPlanePolicies() []cfn_iam.Role_Policy {
 			},
 		)
 	}
+	if t.sessionManagerEnabled() && (t.Spec.ControlPlane.DisableCloudProviderPolicy || t.Spec.Nodes.DisableCloudProviderPolicy) {
+		policies = append(policies, t.sessionManagerRolePolicy())
+	}
 	return policies
 }
 
@@ -34,6 +34,9 @@ func (t Template) nodePolicies() []cfn_iam.Role_Policy {
 			},
 		)
 	}
+	if t.sessionManagerEnabled() && t.Spec.Nodes.DisableCloudProviderPolicy {
+		policies = append(policies, t.sessionManagerRolePolicy())
+	}
 	return policies
 }
 
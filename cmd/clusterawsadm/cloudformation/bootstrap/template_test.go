@@ -25,6 +25,7 @@ import (
 	"github.com/awslabs/goformation/v4/cloudformation"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"k8s.io/utils/pointer"
+	bootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/cmd/clusterawsadm/api/bootstrap/v1alpha1"
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/cmd/clusterawsadm/api/iam/v1alpha1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	"sigs.k8s.io/yaml"
@@ -51,6 +52,26 @@ func Test_RenderCloudformation(t *testing.T) {
 				return t
 			},
 		},
+		{
+			fixture: "with_s3_secret_backend",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.SecureSecretsBackends = []infrav1.SecretBackend{
+					infrav1.SecretBackendS3,
+				}
+				return t
+			},
+		},
+		{
+			fixture: "with_secure_secrets_kms_keys",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.SecureSecretsKMSKeyARNs = []string{
+					"arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+				}
+				return t
+			},
+		},
 		{
 			fixture: "with_all_secret_backends",
 			template: func() Template {
@@ -58,6 +79,7 @@ func Test_RenderCloudformation(t *testing.T) {
 				t.Spec.SecureSecretsBackends = []infrav1.SecretBackend{
 					infrav1.SecretBackendSecretsManager,
 					infrav1.SecretBackendSSMParameterStore,
+					infrav1.SecretBackendS3,
 				}
 				return t
 			},
@@ -106,6 +128,37 @@ func Test_RenderCloudformation(t *testing.T) {
 				return t
 			},
 		},
+		{
+			fixture: "with_event_bridge",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.EventBridge = &bootstrapv1.EventBridgeConfig{
+					Enable: true,
+				}
+				return t
+			},
+		},
+		{
+			fixture: "with_s3_buckets",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.S3Buckets = &bootstrapv1.S3BucketsConfig{
+					Enable: true,
+				}
+				return t
+			},
+		},
+		{
+			fixture: "with_session_manager",
+			template: func() Template {
+				t := NewTemplate()
+				t.Spec.SessionManager = &bootstrapv1.SessionManagerConfig{
+					Enable: true,
+				}
+				t.Spec.Nodes.DisableCloudProviderPolicy = true
+				return t
+			},
+		},
 		{
 			fixture: "with_extra_statements",
 			template: func() Template {
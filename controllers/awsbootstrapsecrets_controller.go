@@ -0,0 +1,291 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/exp/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/metrics"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/secretsmanager"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ssm"
+)
+
+const (
+	// DefaultBootstrapSecretsSweepInterval is how often the bootstrap secrets of each cluster are swept.
+	DefaultBootstrapSecretsSweepInterval = 30 * time.Minute
+
+	// bootstrapSecretsGracePeriod is how long secrets are left alone after being created, as machines
+	// only record them once all have been created.
+	bootstrapSecretsGracePeriod = 15 * time.Minute
+)
+
+// AWSBootstrapSecretsReconciler periodically deletes the Secrets Manager secrets and SSM parameters
+// holding bootstrap data which were leaked by the machines of a cluster.
+type AWSBootstrapSecretsReconciler struct {
+	client.Client
+	Log           logr.Logger
+	Recorder      record.EventRecorder
+	Endpoints     []scope.ServiceEndpoint
+	SweepInterval time.Duration
+
+	// MachinePools makes the secrets of AWSMachinePools be kept too, when the MachinePool feature is enabled.
+	MachinePools bool
+
+	secretsManagerServiceFactory func(cloud.ClusterScoper) services.SecretSweeperInterface
+	ssmServiceFactory            func(cloud.ClusterScoper) services.SecretSweeperInterface
+}
+
+func (r *AWSBootstrapSecretsReconciler) getSecretsManagerService(scope cloud.ClusterScoper) services.SecretSweeperInterface {
+	if r.secretsManagerServiceFactory != nil {
+		return r.secretsManagerServiceFactory(scope)
+	}
+	return secretsmanager.NewService(scope)
+}
+
+func (r *AWSBootstrapSecretsReconciler) getSSMService(scope cloud.ClusterScoper) services.SecretSweeperInterface {
+	if r.ssmServiceFactory != nil {
+		return r.ssmServiceFactory(scope)
+	}
+	return ssm.NewService(scope)
+}
+
+func (r *AWSBootstrapSecretsReconciler) sweepInterval() time.Duration {
+	if r.SweepInterval > 0 {
+		return r.SweepInterval
+	}
+	return DefaultBootstrapSecretsSweepInterval
+}
+
+// bootstrapSecretReference is a set of secrets recorded in a machine or machine pool.
+type bootstrapSecretReference struct {
+	count int32
+	// consumable is set for the secrets of machines, which their instance deletes once fetched.
+	consumable bool
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
+
+func (r *AWSBootstrapSecretsReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.TODO()
+	log := r.Log.WithValues("namespace", req.Namespace, "awsCluster", req.Name)
+
+	awsCluster := &infrav1.AWSCluster{}
+	if err := r.Get(ctx, req.NamespacedName, awsCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Machines delete their secrets while the cluster is deleted.
+	if !awsCluster.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, awsCluster.ObjectMeta)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if cluster == nil {
+		log.Info("Cluster Controller has not yet set OwnerRef")
+		return reconcile.Result{}, nil
+	}
+
+	if util.IsPaused(cluster, awsCluster) {
+		// The Cluster isn't watched, so check again later for it to be unpaused.
+		log.Info("AWSCluster or linked Cluster is marked as paused. Won't reconcile")
+		return reconcile.Result{RequeueAfter: r.sweepInterval()}, nil
+	}
+
+	log = log.WithValues("cluster", cluster.Name)
+
+	// The scope is not closed, this controller never modifies the AWSCluster.
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:         r.Client,
+		Logger:         log,
+		Cluster:        cluster,
+		AWSCluster:     awsCluster,
+		ControllerName: "awsbootstrapsecrets",
+		Endpoints:      r.Endpoints,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Secrets are listed before the machines referring to them, so that the secrets of machines
+	// created in between are found recent enough to be left alone.
+	sweepers := map[infrav1.SecretBackend]services.SecretSweeperInterface{
+		infrav1.SecretBackendSecretsManager:    r.getSecretsManagerService(clusterScope),
+		infrav1.SecretBackendSSMParameterStore: r.getSSMService(clusterScope),
+	}
+	owned := map[infrav1.SecretBackend][]*services.BootstrapSecret{}
+	for backend, sweeper := range sweepers {
+		secrets, err := sweeper.ListOwnedSecrets()
+		// Controllers are only granted the permissions of the secure secrets backends in use.
+		if awserrors.IsAccessDenied(errors.Cause(err)) {
+			log.V(4).Info("Not allowed to list bootstrap secrets, skipping backend", "backend", backend)
+			continue
+		}
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		owned[backend] = secrets
+	}
+
+	references, err := r.bootstrapSecretReferences(ctx, cluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	var errs []error
+	for backend, secrets := range owned {
+		for _, secret := range secrets {
+			reason, orphaned := isOrphanedBootstrapSecret(secret, references)
+			if !orphaned {
+				continue
+			}
+			if err := r.sweepBootstrapSecret(log, cluster, awsCluster, backend, sweepers[backend], secret, reason); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return reconcile.Result{}, kerrors.NewAggregate(errs)
+	}
+
+	return reconcile.Result{RequeueAfter: r.sweepInterval()}, nil
+}
+
+// bootstrapSecretReferences returns the secrets recorded in the spec of the machines and machine
+// pools of the cluster, and in the status of the machine pools, by prefix.
+func (r *AWSBootstrapSecretsReconciler) bootstrapSecretReferences(ctx context.Context, cluster *clusterv1.Cluster) (map[string]bootstrapSecretReference, error) {
+	references := map[string]bootstrapSecretReference{}
+
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.List(ctx, awsMachines, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: cluster.Name}); err != nil {
+		return nil, errors.Wrap(err, "failed to list AWSMachines")
+	}
+	for _, m := range awsMachines.Items {
+		if m.Spec.CloudInit.SecretPrefix != "" {
+			references[m.Spec.CloudInit.SecretPrefix] = bootstrapSecretReference{count: m.Spec.CloudInit.SecretCount, consumable: true}
+		}
+	}
+
+	if !r.MachinePools {
+		return references, nil
+	}
+
+	awsMachinePools := &expinfrav1.AWSMachinePoolList{}
+	if err := r.List(ctx, awsMachinePools, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: cluster.Name}); err != nil {
+		return nil, errors.Wrap(err, "failed to list AWSMachinePools")
+	}
+	for _, m := range awsMachinePools.Items {
+		if m.Spec.CloudInit.SecretPrefix != "" {
+			references[m.Spec.CloudInit.SecretPrefix] = bootstrapSecretReference{count: m.Spec.CloudInit.SecretCount}
+		}
+		// The secrets of previous launch template versions are deleted by the pool once their grace period has passed.
+		for _, replaced := range m.Status.ReplacedSecrets {
+			references[replaced.SecretPrefix] = bootstrapSecretReference{count: replaced.SecretCount}
+		}
+	}
+
+	return references, nil
+}
+
+// isOrphanedBootstrapSecret returns whether a set of secrets is no longer used, and why. Secrets are
+// orphaned when no machine refers to them anymore, or when the instance of the machine referring to
+// them started deleting them, having fetched them already.
+func isOrphanedBootstrapSecret(secret *services.BootstrapSecret, references map[string]bootstrapSecretReference) (string, bool) {
+	if time.Since(secret.LastModified) < bootstrapSecretsGracePeriod {
+		return "", false
+	}
+
+	reference, ok := references[secret.Prefix]
+	if !ok {
+		return "no machine refers to them", true
+	}
+	if reference.consumable && int32(len(secret.Names)) < reference.count {
+		return "the instance deleted them partially", true
+	}
+
+	return "", false
+}
+
+func (r *AWSBootstrapSecretsReconciler) sweepBootstrapSecret(log logr.Logger, cluster *clusterv1.Cluster, awsCluster *infrav1.AWSCluster, backend infrav1.SecretBackend, sweeper services.SecretSweeperInterface, secret *services.BootstrapSecret, reason string) error {
+	log = log.WithValues("backend", backend, "secretPrefix", secret.Prefix, "owner", secret.Owner)
+
+	if err := sweeper.DeleteOwnedSecret(secret); err != nil {
+		r.Recorder.Eventf(awsCluster, corev1.EventTypeWarning, "FailedDeleteOrphanedSecrets", "Failed to delete orphaned %s bootstrap secrets %q: %v", backend, secret.Prefix, err)
+		return errors.Wrapf(err, "failed to delete orphaned %s bootstrap secrets %q", backend, secret.Prefix)
+	}
+
+	log.Info("Deleted orphaned bootstrap secrets", "count", len(secret.Names), "reason", reason)
+	r.Recorder.Eventf(awsCluster, corev1.EventTypeNormal, "SuccessfulDeleteOrphanedSecrets", "Deleted %d orphaned %s bootstrap secrets %q, as %s", len(secret.Names), backend, secret.Prefix, reason)
+	metrics.RecordSweptSecrets(cluster.Namespace, cluster.Name, string(backend), len(secret.Names))
+
+	return nil
+}
+
+func (r *AWSBootstrapSecretsReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.AWSCluster{}).
+		Named("awsbootstrapsecrets").
+		WithEventFilter(pausedPredicates(r.Log)).
+		WithEventFilter(sweepTriggerPredicates()).
+		Complete(r)
+}
+
+// sweepTriggerPredicates only lets through the AWSCluster updates which can change the outcome of a sweep: changes
+// of its spec, and of the owner references and paused annotation it waits for. Sweeps are otherwise only run every
+// sweep interval, rather than on each status patch of the AWSCluster.
+func sweepTriggerPredicates() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration() ||
+				!reflect.DeepEqual(e.MetaOld.GetOwnerReferences(), e.MetaNew.GetOwnerReferences()) ||
+				util.HasPausedAnnotation(e.MetaOld) != util.HasPausedAnnotation(e.MetaNew)
+		},
+	}
+}
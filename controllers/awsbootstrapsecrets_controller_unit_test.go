@@ -0,0 +1,289 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/klogr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/exp/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+)
+
+type fakeSecretSweeper struct {
+	secrets []*services.BootstrapSecret
+	err     error
+	deleted []string
+}
+
+func (f *fakeSecretSweeper) ListOwnedSecrets() ([]*services.BootstrapSecret, error) {
+	return f.secrets, f.err
+}
+
+func (f *fakeSecretSweeper) DeleteOwnedSecret(secret *services.BootstrapSecret) error {
+	f.deleted = append(f.deleted, secret.Prefix)
+	return nil
+}
+
+func newSecretsMachine(clusterName, name, secretPrefix string, secretCount int32) *infrav1.AWSMachine {
+	return &infrav1.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: clusterName},
+		},
+		Spec: infrav1.AWSMachineSpec{
+			CloudInit: infrav1.CloudInit{
+				SecretPrefix: secretPrefix,
+				SecretCount:  secretCount,
+			},
+		},
+	}
+}
+
+func TestAWSBootstrapSecretsReconciler_Reconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+
+	clusterName := "my-cluster"
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       clusterName,
+				},
+			},
+		},
+		Spec: infrav1.AWSClusterSpec{Region: "us-east-1"},
+	}
+
+	ctx := context.TODO()
+	client := fake.NewFakeClientWithScheme(scheme)
+	for _, obj := range []runtime.Object{
+		newCluster(clusterName),
+		awsCluster,
+		newSecretsMachine(clusterName, "in-use", "aws.cluster.x-k8s.io/in-use", 2),
+		newSecretsMachine(clusterName, "fetched", "aws.cluster.x-k8s.io/fetched", 2),
+		newSecretsMachine("other-cluster", "other", "aws.cluster.x-k8s.io/other", 1),
+	} {
+		g.Expect(client.Create(ctx, obj)).To(Succeed())
+	}
+
+	old := time.Now().Add(-time.Hour)
+	secretsManager := &fakeSecretSweeper{
+		secrets: []*services.BootstrapSecret{
+			{Prefix: "aws.cluster.x-k8s.io/in-use", Names: []string{"aws.cluster.x-k8s.io/in-use-0", "aws.cluster.x-k8s.io/in-use-1"}, LastModified: old},
+			{Prefix: "aws.cluster.x-k8s.io/fetched", Names: []string{"aws.cluster.x-k8s.io/fetched-1"}, LastModified: old},
+			{Prefix: "aws.cluster.x-k8s.io/orphaned", Names: []string{"aws.cluster.x-k8s.io/orphaned-0"}, LastModified: old},
+			{Prefix: "aws.cluster.x-k8s.io/other", Names: []string{"aws.cluster.x-k8s.io/other-0"}, LastModified: old},
+			{Prefix: "aws.cluster.x-k8s.io/recent", Names: []string{"aws.cluster.x-k8s.io/recent-0"}, LastModified: time.Now()},
+		},
+	}
+	ssm := &fakeSecretSweeper{
+		err: errors.Wrap(awserr.New(awserrors.AccessDenied, "denied", nil), "failed to list SSM parameters"),
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &AWSBootstrapSecretsReconciler{
+		Client:   client,
+		Log:      klogr.New(),
+		Recorder: recorder,
+		secretsManagerServiceFactory: func(cloud.ClusterScoper) services.SecretSweeperInterface {
+			return secretsManager
+		},
+		ssmServiceFactory: func(cloud.ClusterScoper) services.SecretSweeperInterface {
+			return ssm
+		},
+	}
+
+	result, err := reconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: clusterName}})
+	g.Expect(err).To(BeNil())
+	g.Expect(result.RequeueAfter).To(Equal(DefaultBootstrapSecretsSweepInterval))
+	g.Expect(secretsManager.deleted).To(ConsistOf(
+		"aws.cluster.x-k8s.io/fetched",
+		"aws.cluster.x-k8s.io/orphaned",
+		"aws.cluster.x-k8s.io/other",
+	))
+	g.Expect(ssm.deleted).To(BeEmpty())
+	g.Expect(recorder.Events).To(HaveLen(3))
+}
+
+func TestAWSBootstrapSecretsReconciler_ReconcileListFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+
+	clusterName := "my-cluster"
+	client := fake.NewFakeClientWithScheme(scheme, newCluster(clusterName), &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       clusterName,
+				},
+			},
+		},
+		Spec: infrav1.AWSClusterSpec{Region: "us-east-1"},
+	})
+
+	// Secrets of one backend are not swept when listing the other fails, as machines may still refer to them.
+	secretsManager := &fakeSecretSweeper{
+		secrets: []*services.BootstrapSecret{
+			{Prefix: "aws.cluster.x-k8s.io/orphaned", Names: []string{"aws.cluster.x-k8s.io/orphaned-0"}, LastModified: time.Now().Add(-time.Hour)},
+		},
+	}
+	reconciler := &AWSBootstrapSecretsReconciler{
+		Client:   client,
+		Log:      klogr.New(),
+		Recorder: record.NewFakeRecorder(10),
+		secretsManagerServiceFactory: func(cloud.ClusterScoper) services.SecretSweeperInterface {
+			return secretsManager
+		},
+		ssmServiceFactory: func(cloud.ClusterScoper) services.SecretSweeperInterface {
+			return &fakeSecretSweeper{err: errors.New("some error")}
+		},
+	}
+
+	_, err = reconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: clusterName}})
+	g.Expect(err).NotTo(BeNil())
+	g.Expect(secretsManager.deleted).To(BeEmpty())
+}
+
+func TestAWSBootstrapSecretsReconciler_MachinePoolReferences(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+	g.Expect(expinfrav1.AddToScheme(scheme)).To(Succeed())
+
+	clusterName := "my-cluster"
+	cluster := newCluster(clusterName)
+	client := fake.NewFakeClientWithScheme(scheme, &expinfrav1.AWSMachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: clusterName},
+		},
+		Spec: expinfrav1.AWSMachinePoolSpec{
+			CloudInit: infrav1.CloudInit{
+				SecretPrefix: "aws.cluster.x-k8s.io/current",
+				SecretCount:  1,
+			},
+		},
+		Status: expinfrav1.AWSMachinePoolStatus{
+			ReplacedSecrets: []expinfrav1.ReplacedBootstrapSecrets{
+				{
+					SecureSecretsBackend: infrav1.SecretBackendSecretsManager,
+					SecretPrefix:         "aws.cluster.x-k8s.io/replaced",
+					SecretCount:          2,
+					ReplacedAt:           metav1.Now(),
+				},
+			},
+		},
+	})
+
+	reconciler := &AWSBootstrapSecretsReconciler{Client: client, MachinePools: true}
+
+	// The secrets of a previous launch template version are left to the pool, which deletes them
+	// once the instances launched from it have fetched them.
+	references, err := reconciler.bootstrapSecretReferences(context.TODO(), cluster)
+	g.Expect(err).To(BeNil())
+	g.Expect(references).To(Equal(map[string]bootstrapSecretReference{
+		"aws.cluster.x-k8s.io/current":  {count: 1},
+		"aws.cluster.x-k8s.io/replaced": {count: 2},
+	}))
+}
+
+func TestAWSBootstrapSecretsReconciler_SweepTriggerPredicates(t *testing.T) {
+	old := &infrav1.AWSCluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1}}
+
+	testCases := []struct {
+		name   string
+		update func(c *infrav1.AWSCluster)
+		expect bool
+	}{
+		{
+			name: "ignores status updates",
+			update: func(c *infrav1.AWSCluster) {
+				c.Status.Ready = true
+			},
+		},
+		{
+			name: "sweeps on spec changes",
+			update: func(c *infrav1.AWSCluster) {
+				c.Generation = 2
+			},
+			expect: true,
+		},
+		{
+			name: "sweeps once the cluster sets its owner reference",
+			update: func(c *infrav1.AWSCluster) {
+				c.OwnerReferences = []metav1.OwnerReference{{Kind: "Cluster", Name: "test"}}
+			},
+			expect: true,
+		},
+		{
+			name: "sweeps when the cluster is paused or unpaused",
+			update: func(c *infrav1.AWSCluster) {
+				c.Annotations = map[string]string{clusterv1.PausedAnnotation: ""}
+			},
+			expect: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			updated := old.DeepCopy()
+			tc.update(updated)
+			g.Expect(sweepTriggerPredicates().Update(event.UpdateEvent{
+				MetaOld:   old,
+				ObjectOld: old,
+				MetaNew:   updated,
+				ObjectNew: updated,
+			})).To(Equal(tc.expect))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/securitygroup"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileAdoption adopts the existing infrastructure of the cluster until it has been adopted, and reports the
+// adopted resources in the status of the AWSCluster. Returns true in dry run mode, where the cluster must not be
+// reconciled any further, so that no resources are created alongside the existing ones.
+func reconcileAdoption(clusterScope *scope.ClusterScope, networkSvc *network.Service, sgService *securitygroup.Service, elbService *elb.Service) (bool, error) {
+	awsCluster := clusterScope.AWSCluster
+	adoption := awsCluster.Spec.Adoption
+	if adoption == nil || (awsCluster.Status.Adoption != nil && awsCluster.Status.Adoption.Adopted) {
+		return false, nil
+	}
+
+	dryRun := adoption.Mode == infrav1.InfrastructureAdoptionModeDryRun
+
+	failed := func(err error) (bool, error) {
+		record.Warnf(awsCluster, "FailedAdoptInfrastructure", "Failed to adopt existing infrastructure: %v", err)
+		conditions.MarkFalse(awsCluster, infrav1.InfrastructureAdoptedCondition, infrav1.InfrastructureAdoptionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return false, err
+	}
+
+	securityGroups, err := sgService.AdoptSecurityGroups(adoption.SecurityGroups, dryRun)
+	if err != nil {
+		return failed(err)
+	}
+
+	loadBalancers, err := elbService.AdoptLoadBalancer(dryRun)
+	if err != nil {
+		return failed(err)
+	}
+
+	// The network is adopted last, as the VPC remains unmanaged until it is tagged, so that adoption
+	// starts over when any resource fails to be adopted.
+	networkResources, err := networkSvc.AdoptNetwork(dryRun)
+	if err != nil {
+		return failed(err)
+	}
+
+	resources := append(append(networkResources, securityGroups...), loadBalancers...)
+
+	awsCluster.Status.Adoption = &infrav1.InfrastructureAdoptionStatus{
+		Mode:      adoption.Mode,
+		Adopted:   !dryRun,
+		Resources: resources,
+	}
+
+	if dryRun {
+		conditions.MarkFalse(awsCluster, infrav1.InfrastructureAdoptedCondition, infrav1.InfrastructureAdoptionDryRunReason, clusterv1.ConditionSeverityInfo,
+			"%d existing resources would be adopted, see status.adoption", len(resources))
+		record.Eventf(awsCluster, "InfrastructureAdoptionDryRun", "%d existing resources would be adopted", len(resources))
+		clusterScope.Info("Reported existing infrastructure to adopt in dry run mode", "resources", len(resources))
+		return true, nil
+	}
+
+	conditions.MarkTrue(awsCluster, infrav1.InfrastructureAdoptedCondition)
+	record.Eventf(awsCluster, "SuccessfulAdoptInfrastructure", "Adopted %d existing resources", len(resources))
+	clusterScope.Info("Adopted existing infrastructure", "resources", len(resources))
+	return false, nil
+}
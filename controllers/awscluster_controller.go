@@ -42,13 +42,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/s3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/securitygroup"
 )
 
+// bastionGroupResyncPeriod is how often clusters with a highly available bastion host are resynced.
+const bastionGroupResyncPeriod = time.Minute
+
 // AWSClusterReconciler reconciles a AwsCluster object
 type AWSClusterReconciler struct {
 	client.Client
@@ -133,6 +139,16 @@ func reconcileDelete(clusterScope *scope.ClusterScope) (reconcile.Result, error)
 
 	awsCluster := clusterScope.AWSCluster
 
+	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+		if err := instancestate.NewService(clusterScope).DeleteInstanceEvents(); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "error deleting instance events queue for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+		}
+	}
+
+	if err := s3.NewService(clusterScope).DeleteBucket(); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "error deleting S3 bucket for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+	}
+
 	if err := elbsvc.DeleteLoadbalancers(); err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "error deleting load balancer for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
 	}
@@ -141,6 +157,14 @@ func reconcileDelete(clusterScope *scope.ClusterScope) (reconcile.Result, error)
 		return reconcile.Result{}, errors.Wrapf(err, "error deleting bastion for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
 	}
 
+	if err := ec2svc.DeletePlacementGroups(); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "error deleting placement groups for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+	}
+
+	if err := ec2svc.DeleteDedicatedHosts(); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "error deleting dedicated hosts for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+	}
+
 	if err := sgService.DeleteSecurityGroups(); err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "error deleting security groups for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
 	}
@@ -168,11 +192,23 @@ func reconcileNormal(clusterScope *scope.ClusterScope) (reconcile.Result, error)
 		return reconcile.Result{}, err
 	}
 
+	if _, ok := awsCluster.Annotations[infrav1.InvalidateLookupCacheAnnotation]; ok {
+		ec2.InvalidateLookupCaches(clusterScope.Region())
+		delete(awsCluster.Annotations, infrav1.InvalidateLookupCacheAnnotation)
+		clusterScope.Info("Invalidated cached image and instance type lookups", "region", clusterScope.Region())
+	}
+
 	ec2Service := ec2.NewService(clusterScope)
 	elbService := elb.NewService(clusterScope)
 	networkSvc := network.NewService(clusterScope)
 	sgService := securitygroup.NewService(clusterScope)
 
+	if stop, err := reconcileAdoption(clusterScope, networkSvc, sgService, elbService); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to adopt existing infrastructure for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+	} else if stop {
+		return reconcile.Result{}, nil
+	}
+
 	if err := networkSvc.ReconcileNetwork(); err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile network for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
 	}
@@ -192,6 +228,16 @@ func reconcileNormal(clusterScope *scope.ClusterScope) (reconcile.Result, error)
 		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile load balancers for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
 	}
 
+	if err := s3.NewService(clusterScope).ReconcileBucket(); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile S3 bucket for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+	}
+
+	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+		if err := instancestate.NewService(clusterScope).ReconcileInstanceEvents(); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile instance events queue for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+		}
+	}
+
 	if awsCluster.Status.Network.APIServerELB.DNSName == "" {
 		conditions.MarkFalse(awsCluster, infrav1.LoadBalancerReadyCondition, infrav1.WaitForDNSNameReason, clusterv1.ConditionSeverityInfo, "")
 		clusterScope.Info("Waiting on API server ELB DNS name")
@@ -225,6 +271,17 @@ func reconcileNormal(clusterScope *scope.ClusterScope) (reconcile.Result, error)
 	}
 
 	awsCluster.Status.Ready = true
+
+	// Instances of the highly available bastion host are replaced by its Auto Scaling group, so the cluster is
+	// resynced for the Elastic IP address of the bastion host to follow them.
+	if awsCluster.Spec.Bastion.HighAvailability && !awsCluster.Spec.Bastion.IsSSM() {
+		if !conditions.IsTrue(awsCluster, infrav1.BastionHostReadyCondition) {
+			clusterScope.Info("Waiting on bastion host instance")
+			return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
+		}
+		return reconcile.Result{RequeueAfter: bastionGroupResyncPeriod}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -0,0 +1,271 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	"sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/instancestate"
+)
+
+const (
+	// DefaultInstanceEventsPollInterval is how often the instance events queue of a cluster is polled.
+	DefaultInstanceEventsPollInterval = 10 * time.Second
+
+	// deleteMachineAnnotation marks a Machine to be deleted first when its MachineSet scales down.
+	deleteMachineAnnotation = "cluster.x-k8s.io/delete-machine"
+)
+
+// AWSInstanceStateReconciler consumes the EC2 instance events queue of AWSClusters with
+// instance event processing enabled, and acts on the machines the events refer to.
+type AWSInstanceStateReconciler struct {
+	client.Client
+	Log          logr.Logger
+	Recorder     record.EventRecorder
+	Endpoints    []scope.ServiceEndpoint
+	PollInterval time.Duration
+
+	// InstanceStateEvents receives the AWSMachines whose instance changed state, so
+	// that they are reconciled without waiting for the next resync.
+	InstanceStateEvents chan<- event.GenericEvent
+
+	instanceStateServiceFactory func(instancestate.Scope) services.InstanceStateInterface
+}
+
+func (r *AWSInstanceStateReconciler) getInstanceStateService(scope instancestate.Scope) services.InstanceStateInterface {
+	if r.instanceStateServiceFactory != nil {
+		return r.instanceStateServiceFactory(scope)
+	}
+
+	return instancestate.NewService(scope)
+}
+
+func (r *AWSInstanceStateReconciler) pollInterval() time.Duration {
+	if r.PollInterval > 0 {
+		return r.PollInterval
+	}
+	return DefaultInstanceEventsPollInterval
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;patch;delete
+
+func (r *AWSInstanceStateReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.TODO()
+	log := r.Log.WithValues("namespace", req.Namespace, "awsCluster", req.Name)
+
+	awsCluster := &infrav1.AWSCluster{}
+	if err := r.Get(ctx, req.NamespacedName, awsCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if awsCluster.Spec.InstanceEvents == nil || !awsCluster.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, awsCluster.ObjectMeta)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if cluster == nil {
+		log.Info("Cluster Controller has not yet set OwnerRef")
+		return reconcile.Result{}, nil
+	}
+
+	if util.IsPaused(cluster, awsCluster) {
+		log.Info("AWSCluster or linked Cluster is marked as paused. Won't reconcile")
+		return reconcile.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", cluster.Name)
+
+	if awsCluster.Status.InstanceEventsQueueURL == "" {
+		log.Info("Waiting for the instance events queue to be reconciled")
+		return reconcile.Result{RequeueAfter: r.pollInterval()}, nil
+	}
+
+	// The scope is not closed, this controller never modifies the AWSCluster.
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:         r.Client,
+		Logger:         log,
+		Cluster:        cluster,
+		AWSCluster:     awsCluster,
+		ControllerName: "awsinstancestate",
+		Endpoints:      r.Endpoints,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	svc := r.getInstanceStateService(clusterScope)
+
+	events, err := svc.ReceiveInstanceEvents()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	for _, e := range events {
+		// Events failing to be handled stay on the queue and are retried once visible again.
+		if err := r.handleInstanceEvent(ctx, log, cluster, e); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "failed to handle %q event for instance %q", e.Type, e.InstanceID)
+		}
+
+		if err := svc.DeleteInstanceEvent(e); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: r.pollInterval()}, nil
+}
+
+func (r *AWSInstanceStateReconciler) handleInstanceEvent(ctx context.Context, log logr.Logger, cluster *clusterv1.Cluster, e *instancestate.InstanceEvent) error {
+	log = log.WithValues("instance-id", e.InstanceID, "event-type", e.Type)
+
+	awsMachine, err := r.findAWSMachine(ctx, cluster, e.InstanceID)
+	if err != nil {
+		return err
+	}
+
+	// Events are delivered for every instance of the account and region.
+	if awsMachine == nil {
+		log.V(4).Info("Instance does not belong to the cluster, ignoring event")
+		return nil
+	}
+
+	if e.Type == instancestate.EventTypeStateChange {
+		r.enqueueAWSMachine(log.WithValues("state", e.State), awsMachine)
+		return nil
+	}
+
+	machine, err := util.GetOwnerMachine(ctx, r.Client, awsMachine.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	if machine == nil || !machine.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	log = log.WithValues("machine", machine.Name)
+
+	switch e.Type {
+	case instancestate.EventTypeSpotInterruption:
+		log.Info("Spot instance is about to be interrupted, deleting Machine")
+		r.Recorder.Eventf(awsMachine, corev1.EventTypeWarning, "SpotInterruption", "Spot instance %s is about to be interrupted, deleting Machine %s", e.InstanceID, machine.Name)
+
+		if err := r.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete Machine %s/%s", machine.Namespace, machine.Name)
+		}
+
+	case instancestate.EventTypeRebalanceRecommendation:
+		if _, ok := machine.Annotations[deleteMachineAnnotation]; ok {
+			return nil
+		}
+
+		log.Info("Spot instance is at elevated risk of interruption, marking Machine for deletion")
+		r.Recorder.Eventf(awsMachine, corev1.EventTypeWarning, "RebalanceRecommendation", "Spot instance %s is at elevated risk of interruption, marking Machine %s for deletion", e.InstanceID, machine.Name)
+
+		patch := client.MergeFrom(machine.DeepCopy())
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		machine.Annotations[deleteMachineAnnotation] = "yes"
+		if err := r.Patch(ctx, machine, patch); err != nil {
+			return errors.Wrapf(err, "failed to mark Machine %s/%s for deletion", machine.Namespace, machine.Name)
+		}
+	}
+
+	return nil
+}
+
+// enqueueAWSMachine triggers the reconciliation of the AWSMachine by the AWSMachine controller.
+// Events that cannot be delivered right away are dropped, the AWSMachine then notices the
+// instance state change on its next resync.
+func (r *AWSInstanceStateReconciler) enqueueAWSMachine(log logr.Logger, awsMachine *infrav1.AWSMachine) {
+	if r.InstanceStateEvents == nil {
+		return
+	}
+
+	select {
+	case r.InstanceStateEvents <- event.GenericEvent{Meta: awsMachine, Object: awsMachine}:
+		log.V(2).Info("Instance changed state, enqueued AWSMachine", "awsMachine", awsMachine.Name)
+	default:
+		log.Info("Instance state events are not being consumed, dropping event", "awsMachine", awsMachine.Name)
+	}
+}
+
+// findAWSMachine returns the AWSMachine of the cluster running the instance, if any.
+func (r *AWSInstanceStateReconciler) findAWSMachine(ctx context.Context, cluster *clusterv1.Cluster, instanceID string) (*infrav1.AWSMachine, error) {
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.List(ctx, awsMachines, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: cluster.Name},
+		client.MatchingFields{scope.InstanceIDIndex: instanceID}); err != nil {
+		return nil, errors.Wrap(err, "failed to list AWSMachines")
+	}
+
+	for i := range awsMachines.Items {
+		m := &awsMachines.Items[i]
+		if m.Spec.ProviderID == nil {
+			continue
+		}
+		providerID, err := noderefutil.NewProviderID(*m.Spec.ProviderID)
+		if err != nil {
+			continue
+		}
+		if providerID.ID() == instanceID {
+			return m, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *AWSInstanceStateReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	// The AWSMachine of the instance of each event is looked up by instance ID.
+	if err := mgr.GetFieldIndexer().IndexField(&infrav1.AWSMachine{}, scope.InstanceIDIndex, scope.InstanceID); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.AWSCluster{}).
+		Named("awsinstancestate").
+		WithEventFilter(pausedPredicates(r.Log)).
+		Complete(r)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/klogr"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/instancestate"
+)
+
+type fakeInstanceStateService struct {
+	events  []*instancestate.InstanceEvent
+	deleted []*instancestate.InstanceEvent
+}
+
+func (f *fakeInstanceStateService) ReceiveInstanceEvents() ([]*instancestate.InstanceEvent, error) {
+	return f.events, nil
+}
+
+func (f *fakeInstanceStateService) DeleteInstanceEvent(event *instancestate.InstanceEvent) error {
+	f.deleted = append(f.deleted, event)
+	return nil
+}
+
+func newSpotMachine(clusterName, name, instanceID string) (*clusterv1.Machine, *infrav1.AWSMachine) {
+	machine := newMachine(clusterName, name)
+	awsMachine := &infrav1.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws" + name,
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: clusterName},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Machine",
+					Name:       name,
+				},
+			},
+		},
+		Spec: infrav1.AWSMachineSpec{
+			ProviderID: pointer.StringPtr("aws:///us-east-1a/" + instanceID),
+		},
+	}
+	return machine, awsMachine
+}
+
+func TestAWSInstanceStateReconciler_Reconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+
+	clusterName := "my-cluster"
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       clusterName,
+				},
+			},
+		},
+		Spec: infrav1.AWSClusterSpec{
+			InstanceEvents: &infrav1.InstanceEventsSpec{},
+		},
+		Status: infrav1.AWSClusterStatus{
+			InstanceEventsQueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/my-cluster-instance-events",
+		},
+	}
+	interrupted, awsInterrupted := newSpotMachine(clusterName, "interrupted", "i-interrupted")
+	rebalanced, awsRebalanced := newSpotMachine(clusterName, "rebalanced", "i-rebalanced")
+
+	ctx := context.TODO()
+	client := fake.NewFakeClientWithScheme(scheme)
+	for _, obj := range []runtime.Object{newCluster(clusterName), awsCluster, interrupted, awsInterrupted, rebalanced, awsRebalanced} {
+		g.Expect(client.Create(ctx, obj)).To(Succeed())
+	}
+
+	svc := &fakeInstanceStateService{
+		events: []*instancestate.InstanceEvent{
+			{Type: instancestate.EventTypeSpotInterruption, InstanceID: "i-interrupted"},
+			{Type: instancestate.EventTypeRebalanceRecommendation, InstanceID: "i-rebalanced"},
+			{Type: instancestate.EventTypeStateChange, InstanceID: "i-rebalanced", State: "running"},
+			{Type: instancestate.EventTypeSpotInterruption, InstanceID: "i-other-cluster"},
+		},
+	}
+
+	instanceStateEvents := make(chan event.GenericEvent, 10)
+	reconciler := &AWSInstanceStateReconciler{
+		Client:              client,
+		Log:                 klogr.New(),
+		Recorder:            record.NewFakeRecorder(10),
+		InstanceStateEvents: instanceStateEvents,
+		instanceStateServiceFactory: func(instancestate.Scope) services.InstanceStateInterface {
+			return svc
+		},
+	}
+
+	result, err := reconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: clusterName}})
+	g.Expect(err).To(BeNil())
+	g.Expect(result.RequeueAfter).To(Equal(DefaultInstanceEventsPollInterval))
+	g.Expect(svc.deleted).To(Equal(svc.events))
+
+	err = client.Get(ctx, types.NamespacedName{Namespace: "default", Name: "interrupted"}, &clusterv1.Machine{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	machine := &clusterv1.Machine{}
+	g.Expect(client.Get(ctx, types.NamespacedName{Namespace: "default", Name: "rebalanced"}, machine)).To(Succeed())
+	g.Expect(machine.Annotations).To(HaveKeyWithValue(deleteMachineAnnotation, "yes"))
+
+	g.Expect(instanceStateEvents).To(HaveLen(1))
+	e := <-instanceStateEvents
+	g.Expect(e.Meta.GetName()).To(Equal(awsRebalanced.Name))
+}
+
+func TestAWSInstanceStateReconciler_EnqueueAWSMachineDoesNotBlock(t *testing.T) {
+	g := NewWithT(t)
+
+	reconciler := &AWSInstanceStateReconciler{
+		Log:                 klogr.New(),
+		InstanceStateEvents: make(chan event.GenericEvent),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		reconciler.enqueueAWSMachine(reconciler.Log, &infrav1.AWSMachine{})
+		close(done)
+	}()
+
+	g.Eventually(done).Should(BeClosed())
+}
+
+func TestAWSInstanceStateReconciler_ReconcileWithoutInstanceEvents(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+
+	client := fake.NewFakeClientWithScheme(scheme, &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+	})
+
+	reconciler := &AWSInstanceStateReconciler{
+		Client: client,
+		Log:    klogr.New(),
+		instanceStateServiceFactory: func(instancestate.Scope) services.InstanceStateInterface {
+			t.Fatal("instance events queue should not be polled")
+			return nil
+		},
+	}
+
+	result, err := reconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-cluster"}})
+	g.Expect(err).To(BeNil())
+	g.Expect(result.RequeueAfter).To(BeZero())
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileAdoption adopts the existing instance requested through the AdoptInstanceAnnotation, and sets the
+// provider ID of the AWSMachine to it so that it is found rather than created. The rest of the reconcile,
+// including the registration of control plane instances with the API server load balancer, then applies to it
+// as to any other instance.
+func (r *AWSMachineReconciler) reconcileAdoption(machineScope *scope.MachineScope, ec2svc service.EC2MachineInterface) error {
+	instanceID := machineScope.AWSMachine.InstanceToAdopt()
+	if instanceID == "" {
+		return nil
+	}
+
+	// The provider ID is already set if the instance was adopted, but the annotation couldn't be removed.
+	if machineScope.GetProviderID() == "" {
+		instance, err := ec2svc.AdoptInstance(machineScope, instanceID)
+		if err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedAdoptInstance", "Failed to adopt instance %q: %v", instanceID, err)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceAdoptionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return err
+		}
+
+		machineScope.SetProviderID(instance.ID, instance.AvailabilityZone)
+		machineScope.Info("Adopted existing EC2 instance", "instance-id", instance.ID)
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulAdoptInstance", "Adopted instance %q", instance.ID)
+	}
+
+	annotations := machineScope.AWSMachine.GetAnnotations()
+	delete(annotations, infrav1.AdoptInstanceAnnotation)
+	machineScope.AWSMachine.SetAnnotations(annotations)
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/mock_services"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestAWSMachineReconciler_reconcileAdoption(t *testing.T) {
+	t.Run("does nothing without the annotation", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		r := AWSMachineReconciler{Client: c, Recorder: record.NewFakeRecorder(1)}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now())
+
+		g.Expect(r.reconcileAdoption(machineScope, ec2Svc)).To(Succeed())
+		g.Expect(machineScope.GetProviderID()).To(BeEmpty())
+	})
+
+	t.Run("adopts the instance and sets the provider ID", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		recorder := record.NewFakeRecorder(1)
+		r := AWSMachineReconciler{Client: c, Recorder: recorder}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now())
+		machineScope.AWSMachine.Annotations = map[string]string{infrav1.AdoptInstanceAnnotation: "i-1"}
+		ec2Svc.EXPECT().AdoptInstance(machineScope, "i-1").Return(&infrav1.Instance{ID: "i-1", AvailabilityZone: "us-east-1a"}, nil)
+
+		g.Expect(r.reconcileAdoption(machineScope, ec2Svc)).To(Succeed())
+		g.Expect(machineScope.GetProviderID()).To(Equal("aws:///us-east-1a/i-1"))
+		g.Expect(machineScope.AWSMachine.Annotations).NotTo(HaveKey(infrav1.AdoptInstanceAnnotation))
+		g.Expect(<-recorder.Events).To(ContainSubstring("SuccessfulAdoptInstance"))
+	})
+
+	t.Run("only removes the annotation once the instance was adopted", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		r := AWSMachineReconciler{Client: c, Recorder: record.NewFakeRecorder(1)}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now())
+		machineScope.AWSMachine.Annotations = map[string]string{infrav1.AdoptInstanceAnnotation: "i-1"}
+		machineScope.AWSMachine.Spec.ProviderID = pointer.StringPtr("aws:///us-east-1a/i-1")
+
+		g.Expect(r.reconcileAdoption(machineScope, ec2Svc)).To(Succeed())
+		g.Expect(machineScope.AWSMachine.Annotations).NotTo(HaveKey(infrav1.AdoptInstanceAnnotation))
+	})
+
+	t.Run("reports instances which can't be adopted", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		recorder := record.NewFakeRecorder(1)
+		r := AWSMachineReconciler{Client: c, Recorder: recorder}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now())
+		machineScope.AWSMachine.Annotations = map[string]string{infrav1.AdoptInstanceAnnotation: "i-1"}
+		ec2Svc.EXPECT().AdoptInstance(machineScope, "i-1").Return(nil, errors.New("security groups sg-1 of the cluster are not attached"))
+
+		g.Expect(r.reconcileAdoption(machineScope, ec2Svc)).NotTo(Succeed())
+		g.Expect(machineScope.GetProviderID()).To(BeEmpty())
+		g.Expect(machineScope.AWSMachine.Annotations).To(HaveKey(infrav1.AdoptInstanceAnnotation))
+		g.Expect(conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition)).To(Equal(infrav1.InstanceAdoptionFailedReason))
+		g.Expect(<-recorder.Events).To(ContainSubstring("FailedAdoptInstance"))
+	})
+}
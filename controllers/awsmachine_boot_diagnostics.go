@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// defaultBootDiagnosticsTimeout is how long an instance may run without becoming a node before its boot
+	// diagnostics are captured, when the AWSMachine doesn't set a timeout.
+	defaultBootDiagnosticsTimeout = 15 * time.Minute
+
+	// bootDiagnosticsEventTailBytes is the size of the tail of the console output reported in events.
+	bootDiagnosticsEventTailBytes = 512
+
+	// bootDiagnosticsConsoleOutputKey is the key of the console output in the boot diagnostics Secret.
+	bootDiagnosticsConsoleOutputKey = "console-output"
+
+	// bootDiagnosticsScreenshotKey is the key of the console screenshot in the boot diagnostics Secret.
+	bootDiagnosticsScreenshotKey = "screenshot.jpg"
+)
+
+// reconcileBootDiagnostics captures the console output of instances which stopped unexpectedly, or have been
+// running for longer than the boot diagnostics timeout without their Machine getting a node reference, and
+// stores it in a Secret owned by the AWSMachine. The output is captured once per instance and trigger.
+// Returns how long to wait before the timeout of a running instance expires.
+func (r *AWSMachineReconciler) reconcileBootDiagnostics(machineScope *scope.MachineScope, ec2svc service.EC2MachineInterface, instance *infrav1.Instance) (time.Duration, error) {
+	trigger, requeueAfter := bootDiagnosticsTrigger(machineScope, instance)
+	if trigger == "" {
+		return requeueAfter, nil
+	}
+
+	if captured := machineScope.AWSMachine.Status.BootDiagnostics; captured != nil && captured.InstanceID == instance.ID && captured.Trigger == trigger {
+		return 0, nil
+	}
+
+	output, err := ec2svc.GetConsoleOutput(instance.ID)
+	if err != nil {
+		// Boot diagnostics don't block the reconcile of the machine, they are retried on the next one.
+		machineScope.Error(err, "Failed to capture boot diagnostics", "instance-id", instance.ID)
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedCaptureBootDiagnostics", "Failed to capture console output of instance %q: %v", instance.ID, err)
+		return 0, nil
+	}
+
+	data := map[string][]byte{
+		bootDiagnosticsConsoleOutputKey: []byte(output),
+	}
+
+	if machineScope.AWSMachine.Spec.BootDiagnostics.Screenshot {
+		screenshot, err := ec2svc.GetConsoleScreenshot(instance.ID)
+		if err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedCaptureBootDiagnostics", "Failed to capture console screenshot of instance %q: %v", instance.ID, err)
+		} else {
+			data[bootDiagnosticsScreenshotKey] = screenshot
+		}
+	}
+
+	secretName := fmt.Sprintf("%s-boot-diagnostics", machineScope.Name())
+	if err := r.storeBootDiagnostics(machineScope, secretName, data); err != nil {
+		return 0, err
+	}
+
+	machineScope.AWSMachine.Status.BootDiagnostics = &infrav1.BootDiagnosticsStatus{
+		InstanceID: instance.ID,
+		Trigger:    trigger,
+		CapturedAt: metav1.Now(),
+		SecretName: secretName,
+	}
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "BootDiagnosticsCaptured", "Captured console output of instance %q (%s) in Secret %q:\n%s",
+		instance.ID, trigger, secretName, consoleOutputTail(output, bootDiagnosticsEventTailBytes))
+
+	return 0, nil
+}
+
+// bootDiagnosticsTrigger returns why the boot diagnostics of the instance should be captured, if they should,
+// and otherwise how long to wait before the instance runs for longer than the timeout.
+func bootDiagnosticsTrigger(machineScope *scope.MachineScope, instance *infrav1.Instance) (infrav1.BootDiagnosticsTrigger, time.Duration) {
+	switch instance.State {
+	case infrav1.InstanceStateStopped:
+		// Instances stopped on request are reported with another reason, and instances stopped to change their
+		// instance type are started again once it has been changed.
+		if conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition) != infrav1.InstanceStoppedReason {
+			return "", 0
+		}
+		if desired := machineScope.AWSMachine.Spec.InstanceType; machineScope.AWSMachine.HasMutableInstanceType() && desired != "" && desired != instance.Type {
+			return "", 0
+		}
+		return infrav1.BootDiagnosticsTriggerInstanceStopped, 0
+
+	case infrav1.InstanceStateRunning:
+		if machineScope.Machine.Status.NodeRef != nil {
+			return "", 0
+		}
+
+		ready := conditions.Get(machineScope.AWSMachine, infrav1.InstanceReadyCondition)
+		if ready == nil || ready.Status != corev1.ConditionTrue {
+			return "", 0
+		}
+
+		timeout := defaultBootDiagnosticsTimeout
+		if t := machineScope.AWSMachine.Spec.BootDiagnostics.Timeout; t != nil {
+			timeout = t.Duration
+		}
+
+		// The instance has been running since it became ready.
+		if remaining := time.Until(ready.LastTransitionTime.Add(timeout)); remaining > 0 {
+			return "", remaining
+		}
+		return infrav1.BootDiagnosticsTriggerNodeTimeout, 0
+	}
+
+	return "", 0
+}
+
+// storeBootDiagnostics creates or updates the Secret holding the boot diagnostics of the AWSMachine.
+func (r *AWSMachineReconciler) storeBootDiagnostics(machineScope *scope.MachineScope, name string, data map[string][]byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: machineScope.Namespace(),
+		},
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), r.Client, secret, func() error {
+		secret.Labels = map[string]string{
+			clusterv1.ClusterLabelName: machineScope.Cluster.Name,
+		}
+		secret.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: infrav1.GroupVersion.String(),
+				Kind:       "AWSMachine",
+				Name:       machineScope.AWSMachine.Name,
+				UID:        machineScope.AWSMachine.UID,
+				Controller: pointer.BoolPtr(true),
+			},
+		}
+		secret.Data = data
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "failed to store boot diagnostics in Secret %s/%s", machineScope.Namespace(), name)
+	}
+
+	return nil
+}
+
+// consoleOutputTail returns the last lines of the console output, fitting in maxBytes.
+func consoleOutputTail(output string, maxBytes int) string {
+	output = strings.TrimRight(strings.ToValidUTF8(output, ""), " \r\n\t")
+	if output == "" {
+		return "(no console output)"
+	}
+	if len(output) <= maxBytes {
+		return output
+	}
+
+	tail := output[len(output)-maxBytes:]
+	// Start at a whole line, unless the last line alone is longer than maxBytes.
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+	return strings.ToValidUTF8(tail, "")
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/mock_services"
+)
+
+func newBootDiagnosticsMachineScope(g *WithT, c client.Client, readySince time.Time) *scope.MachineScope {
+	awsMachine := &infrav1.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+		Spec: infrav1.AWSMachineSpec{
+			BootDiagnostics: &infrav1.BootDiagnostics{},
+		},
+		Status: infrav1.AWSMachineStatus{
+			Conditions: clusterv1.Conditions{
+				{
+					Type:               infrav1.InstanceReadyCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(readySince),
+				},
+			},
+		},
+	}
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster:    &clusterv1.Cluster{},
+		AWSCluster: &infrav1.AWSCluster{},
+	})
+	g.Expect(err).To(BeNil())
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       c,
+		Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+		Machine:      &clusterv1.Machine{},
+		InfraCluster: clusterScope,
+		AWSMachine:   awsMachine,
+	})
+	g.Expect(err).To(BeNil())
+
+	return machineScope
+}
+
+func TestAWSMachineReconciler_reconcileBootDiagnostics(t *testing.T) {
+	instance := func(state infrav1.InstanceState) *infrav1.Instance {
+		return &infrav1.Instance{ID: "i-1", State: state}
+	}
+
+	t.Run("captures the console output of instances which don't become nodes", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		recorder := record.NewFakeRecorder(1)
+		r := AWSMachineReconciler{Client: c, Recorder: recorder}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now().Add(-20*time.Minute))
+		output := strings.Repeat("cloud-init running\n", 100) + "cloud-init failed: could not reach the API server\n"
+		ec2Svc.EXPECT().GetConsoleOutput("i-1").Return(output, nil)
+
+		requeueAfter, err := r.reconcileBootDiagnostics(machineScope, ec2Svc, instance(infrav1.InstanceStateRunning))
+		g.Expect(err).To(BeNil())
+		g.Expect(requeueAfter).To(BeZero())
+
+		status := machineScope.AWSMachine.Status.BootDiagnostics
+		g.Expect(status).NotTo(BeNil())
+		g.Expect(status.InstanceID).To(Equal("i-1"))
+		g.Expect(status.Trigger).To(Equal(infrav1.BootDiagnosticsTriggerNodeTimeout))
+		g.Expect(status.SecretName).To(Equal("test-boot-diagnostics"))
+
+		secret := &corev1.Secret{}
+		g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test-boot-diagnostics"}, secret)).To(Succeed())
+		g.Expect(string(secret.Data[bootDiagnosticsConsoleOutputKey])).To(Equal(output))
+		g.Expect(secret.Data).NotTo(HaveKey(bootDiagnosticsScreenshotKey))
+		g.Expect(secret.Labels).To(HaveKeyWithValue(clusterv1.ClusterLabelName, "test-cluster"))
+		g.Expect(secret.OwnerReferences).To(HaveLen(1))
+		g.Expect(secret.OwnerReferences[0].UID).To(Equal(types.UID("test-uid")))
+
+		event := <-recorder.Events
+		g.Expect(event).To(ContainSubstring("BootDiagnosticsCaptured"))
+		g.Expect(event).To(HaveSuffix("cloud-init failed: could not reach the API server"))
+	})
+
+	t.Run("waits for the timeout to expire", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		r := AWSMachineReconciler{Client: c, Recorder: record.NewFakeRecorder(1)}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now().Add(-time.Minute))
+		machineScope.AWSMachine.Spec.BootDiagnostics.Timeout = &metav1.Duration{Duration: 10 * time.Minute}
+
+		requeueAfter, err := r.reconcileBootDiagnostics(machineScope, ec2Svc, instance(infrav1.InstanceStateRunning))
+		g.Expect(err).To(BeNil())
+		g.Expect(requeueAfter).To(BeNumerically("~", 9*time.Minute, time.Minute))
+		g.Expect(machineScope.AWSMachine.Status.BootDiagnostics).To(BeNil())
+	})
+
+	t.Run("ignores instances which became nodes", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		r := AWSMachineReconciler{Client: c, Recorder: record.NewFakeRecorder(1)}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now().Add(-time.Hour))
+		machineScope.Machine.Status.NodeRef = &corev1.ObjectReference{Name: "node"}
+
+		requeueAfter, err := r.reconcileBootDiagnostics(machineScope, ec2Svc, instance(infrav1.InstanceStateRunning))
+		g.Expect(err).To(BeNil())
+		g.Expect(requeueAfter).To(BeZero())
+		g.Expect(machineScope.AWSMachine.Status.BootDiagnostics).To(BeNil())
+	})
+
+	t.Run("captures the console output and screenshot of instances which stopped unexpectedly once", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		r := AWSMachineReconciler{Client: c, Recorder: record.NewFakeRecorder(2)}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now())
+		machineScope.AWSMachine.Spec.BootDiagnostics.Screenshot = true
+		machineScope.AWSMachine.Status.Conditions = clusterv1.Conditions{
+			{Type: infrav1.InstanceReadyCondition, Status: corev1.ConditionFalse, Reason: infrav1.InstanceStoppedReason},
+		}
+		ec2Svc.EXPECT().GetConsoleOutput("i-1").Return("Kernel panic", nil).Times(1)
+		ec2Svc.EXPECT().GetConsoleScreenshot("i-1").Return([]byte("jpg"), nil).Times(1)
+
+		for i := 0; i < 2; i++ {
+			_, err := r.reconcileBootDiagnostics(machineScope, ec2Svc, instance(infrav1.InstanceStateStopped))
+			g.Expect(err).To(BeNil())
+		}
+		g.Expect(machineScope.AWSMachine.Status.BootDiagnostics.Trigger).To(Equal(infrav1.BootDiagnosticsTriggerInstanceStopped))
+
+		secret := &corev1.Secret{}
+		g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "test-boot-diagnostics"}, secret)).To(Succeed())
+		g.Expect(secret.Data).To(HaveKeyWithValue(bootDiagnosticsConsoleOutputKey, []byte("Kernel panic")))
+		g.Expect(secret.Data).To(HaveKeyWithValue(bootDiagnosticsScreenshotKey, []byte("jpg")))
+	})
+
+	t.Run("ignores instances stopped on request", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		r := AWSMachineReconciler{Client: c, Recorder: record.NewFakeRecorder(1)}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now())
+		machineScope.AWSMachine.Status.Conditions = clusterv1.Conditions{
+			{Type: infrav1.InstanceReadyCondition, Status: corev1.ConditionFalse, Reason: infrav1.InstanceStoppedOnRequestReason},
+		}
+
+		_, err := r.reconcileBootDiagnostics(machineScope, ec2Svc, instance(infrav1.InstanceStateStopped))
+		g.Expect(err).To(BeNil())
+		g.Expect(machineScope.AWSMachine.Status.BootDiagnostics).To(BeNil())
+	})
+
+	t.Run("does not fail the reconcile when the console output can't be captured", func(t *testing.T) {
+		g := NewWithT(t)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Svc := mock_services.NewMockEC2MachineInterface(mockCtrl)
+		c := fake.NewFakeClientWithScheme(scheme.Scheme)
+		r := AWSMachineReconciler{Client: c, Recorder: record.NewFakeRecorder(1)}
+
+		machineScope := newBootDiagnosticsMachineScope(g, c, time.Now().Add(-time.Hour))
+		ec2Svc.EXPECT().GetConsoleOutput("i-1").Return("", errors.New("UnauthorizedOperation"))
+
+		_, err := r.reconcileBootDiagnostics(machineScope, ec2Svc, instance(infrav1.InstanceStateRunning))
+		g.Expect(err).To(BeNil())
+		g.Expect(machineScope.AWSMachine.Status.BootDiagnostics).To(BeNil())
+	})
+}
+
+func TestConsoleOutputTail(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(consoleOutputTail("", 10)).To(Equal("(no console output)"))
+	g.Expect(consoleOutputTail("short\n", 10)).To(Equal("short"))
+	g.Expect(consoleOutputTail("first line\nsecond\nthird\n", 14)).To(Equal("second\nthird"))
+	g.Expect(consoleOutputTail("averyveryverylongline", 8)).To(Equal("longline"))
+}
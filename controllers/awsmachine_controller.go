@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -43,10 +44,12 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/controlplane/eks/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/s3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/secretsmanager"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ssm"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/userdata"
@@ -60,12 +63,25 @@ type AWSMachineReconciler struct {
 	ec2ServiceFactory            func(scope.EC2Scope) services.EC2MachineInterface
 	secretsManagerServiceFactory func(cloud.ClusterScoper) services.SecretInterface
 	SSMServiceFactory            func(cloud.ClusterScoper) services.SecretInterface
+	S3ServiceFactory             func(scope.S3Scope) services.SecretInterface
+	objectStoreServiceFactory    func(scope.S3Scope) services.ObjectStoreInterface
+	elbServiceFactory            func(scope.ELBScope) services.ELBInterface
 	Endpoints                    []scope.ServiceEndpoint
+
+	// InstanceStateEvents, when set, enqueues AWSMachines whose instance changed state.
+	InstanceStateEvents <-chan event.GenericEvent
 }
 
 const (
 	// AWSManagedControlPlaneRefKind is the string value indicating that a cluster is AWS managed
 	AWSManagedControlPlaneRefKind = "AWSManagedControlPlane"
+
+	// volumeModificationRequeueAfter is how long to wait before checking on volume modifications in progress.
+	volumeModificationRequeueAfter = time.Minute
+	// instanceTypeChangeRequeueAfter is how long to wait before checking on an instance type change in progress.
+	instanceTypeChangeRequeueAfter = 30 * time.Second
+	// powerStateChangeRequeueAfter is how long to wait before checking on an instance being stopped or started.
+	powerStateChangeRequeueAfter = 30 * time.Second
 )
 
 func (r *AWSMachineReconciler) getEC2Service(scope scope.EC2Scope) services.EC2MachineInterface {
@@ -76,6 +92,14 @@ func (r *AWSMachineReconciler) getEC2Service(scope scope.EC2Scope) services.EC2M
 	return ec2.NewService(scope)
 }
 
+func (r *AWSMachineReconciler) getELBService(scope scope.ELBScope) services.ELBInterface {
+	if r.elbServiceFactory != nil {
+		return r.elbServiceFactory(scope)
+	}
+
+	return elb.NewService(scope)
+}
+
 func (r *AWSMachineReconciler) getSecretsManagerService(scope cloud.ClusterScoper) services.SecretInterface {
 	if r.secretsManagerServiceFactory != nil {
 		return r.secretsManagerServiceFactory(scope)
@@ -91,12 +115,31 @@ func (r *AWSMachineReconciler) getSSMService(scope cloud.ClusterScoper) services
 	return ssm.NewService(scope)
 }
 
-func (r *AWSMachineReconciler) getSecretService(machineScope *scope.MachineScope, scope cloud.ClusterScoper) (services.SecretInterface, error) {
+func (r *AWSMachineReconciler) getS3Service(scope scope.S3Scope) services.SecretInterface {
+	if r.S3ServiceFactory != nil {
+		return r.S3ServiceFactory(scope)
+	}
+	return s3.NewService(scope)
+}
+
+func (r *AWSMachineReconciler) getObjectStoreService(scope scope.S3Scope) services.ObjectStoreInterface {
+	if scope.Bucket() == nil {
+		return nil
+	}
+	if r.objectStoreServiceFactory != nil {
+		return r.objectStoreServiceFactory(scope)
+	}
+	return s3.NewService(scope)
+}
+
+func (r *AWSMachineReconciler) getSecretService(machineScope *scope.MachineScope, scope cloud.ClusterScoper, s3Scope scope.S3Scope) (services.SecretInterface, error) {
 	switch machineScope.SecureSecretsBackend() {
 	case infrav1.SecretBackendSSMParameterStore:
 		return r.getSSMService(scope), nil
 	case infrav1.SecretBackendSecretsManager:
 		return r.getSecretsManagerService(scope), nil
+	case infrav1.SecretBackendS3:
+		return r.getS3Service(s3Scope), nil
 	}
 	return nil, errors.New("invalid secret backend")
 }
@@ -104,7 +147,7 @@ func (r *AWSMachineReconciler) getSecretService(machineScope *scope.MachineScope
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 
 func (r *AWSMachineReconciler) Reconcile(req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -195,6 +238,11 @@ func (r *AWSMachineReconciler) Reconcile(req ctrl.Request) (_ ctrl.Result, reter
 }
 
 func (r *AWSMachineReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	// Machines cloned from the same template are listed when resolving the AMI of a new instance.
+	if err := mgr.GetFieldIndexer().IndexField(&infrav1.AWSMachine{}, scope.TemplateClonedFromIndex, scope.TemplateClonedFrom); err != nil {
+		return err
+	}
+
 	controller, err := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		For(&infrav1.AWSMachine{}).
@@ -233,6 +281,16 @@ func (r *AWSMachineReconciler) SetupWithManager(mgr ctrl.Manager, options contro
 		return err
 	}
 
+	if r.InstanceStateEvents != nil {
+		if err := controller.Watch(
+			&source.Channel{Source: r.InstanceStateEvents},
+			&handler.EnqueueRequestForObject{},
+			pausedPredicates(r.Log),
+		); err != nil {
+			return err
+		}
+	}
+
 	return controller.Watch(
 		&source.Kind{Type: &clusterv1.Cluster{}},
 		&handler.EnqueueRequestsFromMapFunc{
@@ -294,7 +352,7 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 	machineScope.Info("Handling deleted AWSMachine")
 
 	ec2Service := r.getEC2Service(ec2Scope)
-	secretSvc, err := r.getSecretService(machineScope, clusterScope)
+	secretSvc, err := r.getSecretService(machineScope, clusterScope, ec2Scope)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -303,6 +361,10 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		return ctrl.Result{}, err
 	}
 
+	if err := r.deleteIgnitionBootstrapDataObject(machineScope, r.getObjectStoreService(ec2Scope)); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	instance, err := r.findInstance(machineScope, ec2Service)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -318,6 +380,9 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		// 4. Scale controller deployment to 1
 		machineScope.V(2).Info("Unable to locate EC2 instance by ID or tags")
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "NoInstanceFound", "Unable to find matching EC2 instance")
+		if err := r.deleteSecondaryNetworkInterfaces(machineScope, ec2Service); err != nil {
+			return ctrl.Result{}, err
+		}
 		controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
 		return ctrl.Result{}, nil
 	}
@@ -391,6 +456,17 @@ func (r *AWSMachineReconciler) reconcileDelete(machineScope *scope.MachineScope,
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulTerminate", "Terminated instance %q", instance.ID)
 	}
 
+	if err := r.deleteSecondaryNetworkInterfaces(machineScope, ec2Service); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Release managed Dedicated Hosts which are no longer running any instances.
+	if machineScope.AWSMachine.Spec.DedicatedHost.IsManaged() {
+		if err := ec2Service.ReleaseDedicatedHosts(); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to release dedicated hosts")
+		}
+	}
+
 	// Instance is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(machineScope.AWSMachine, infrav1.MachineFinalizer)
 
@@ -426,10 +502,11 @@ func (r *AWSMachineReconciler) findInstance(scope *scope.MachineScope, ec2svc se
 func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope) (ctrl.Result, error) {
 	machineScope.Info("Reconciling AWSMachine")
 
-	secretSvc, err := r.getSecretService(machineScope, clusterScope)
+	secretSvc, err := r.getSecretService(machineScope, clusterScope, ec2Scope)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	objectStoreSvc := r.getObjectStoreService(ec2Scope)
 
 	// If the AWSMachine is in an error state, return early.
 	if machineScope.HasFailed() {
@@ -439,6 +516,9 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		if err := r.deleteEncryptedBootstrapDataSecret(machineScope, secretSvc); err != nil {
 			return ctrl.Result{}, err
 		}
+		if err := r.deleteIgnitionBootstrapDataObject(machineScope, objectStoreSvc); err != nil {
+			return ctrl.Result{}, err
+		}
 
 		return ctrl.Result{}, nil
 	}
@@ -465,6 +545,11 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 
 	ec2svc := r.getEC2Service(ec2Scope)
 
+	// Adopt the existing instance requested through the AdoptInstanceAnnotation, rather than creating one.
+	if err := r.reconcileAdoption(machineScope, ec2svc); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Find existing instance
 	instance, err := r.findInstance(machineScope, ec2svc)
 	if err != nil {
@@ -474,14 +559,18 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 	// Create new instance
 	if instance == nil {
 		// Avoid a flickering condition between InstanceProvisionStarted and InstanceProvisionFailed if there's a persistent failure with createInstance
-		if conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition) != infrav1.InstanceProvisionFailedReason {
+		if reason := conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceReadyCondition); reason != infrav1.InstanceProvisionFailedReason && reason != infrav1.CapacityReservationExhaustedReason {
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionStartedReason, clusterv1.ConditionSeverityInfo, "")
 			if err := machineScope.PatchObject(); err != nil {
 				return ctrl.Result{}, errors.Wrap(err, "Failed to patch conditions")
 			}
 		}
-		instance, err = r.createInstance(machineScope, ec2svc, secretSvc)
+		instance, err = r.createInstance(machineScope, ec2svc, secretSvc, objectStoreSvc)
 		if err != nil {
+			if awserrors.IsReservationCapacityExceeded(errors.Cause(err)) {
+				conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.CapacityReservationExhaustedReason, clusterv1.ConditionSeverityError, err.Error())
+				return ctrl.Result{}, err
+			}
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			return ctrl.Result{}, err
 		}
@@ -506,7 +595,11 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotReadyReason, clusterv1.ConditionSeverityWarning, "")
 	case infrav1.InstanceStateStopping, infrav1.InstanceStateStopped:
 		machineScope.SetNotReady()
-		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityError, "")
+		if machineScope.AWSMachine.DesiredPowerState() == infrav1.PowerStateStopped {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStoppedOnRequestReason, clusterv1.ConditionSeverityInfo, "")
+		} else {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityError, "")
+		}
 	case infrav1.InstanceStateRunning:
 		machineScope.SetReady()
 		conditions.MarkTrue(machineScope.AWSMachine, infrav1.InstanceReadyCondition)
@@ -524,10 +617,44 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		conditions.MarkUnknown(machineScope.AWSMachine, infrav1.InstanceReadyCondition, "", "")
 	}
 
+	var result ctrl.Result
+
+	// Capture the console output of instances which fail to bootstrap, before stopped instances are started again.
+	if machineScope.AWSMachine.Spec.BootDiagnostics != nil && machineScope.InstanceIsOperational() {
+		requeueAfter, err := r.reconcileBootDiagnostics(machineScope, ec2svc, instance)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to reconcile boot diagnostics")
+		}
+		result = ctrl.Result{RequeueAfter: requeueAfter}
+	}
+
+	// Change the power state and instance type before the load balancer attachment is reconciled. Control plane
+	// instances are deregistered from the load balancer before they are stopped.
+	if machineScope.InstanceIsOperational() {
+		transitioning, err := r.reconcilePowerState(machineScope, ec2svc, elbScope, instance)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to reconcile power state")
+		}
+		if transitioning {
+			result = ctrl.Result{RequeueAfter: powerStateChangeRequeueAfter}
+		}
+
+		changing, err := r.reconcileInstanceType(machineScope, ec2svc, elbScope, instance)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to reconcile instance type")
+		}
+		if changing {
+			result = ctrl.Result{RequeueAfter: instanceTypeChangeRequeueAfter}
+		}
+	}
+
 	// reconcile the deletion of the bootstrap data secret now that we have updated instance state
 	if err := r.deleteEncryptedBootstrapDataSecret(machineScope, secretSvc); err != nil {
 		return ctrl.Result{}, err
 	}
+	if err := r.deleteIgnitionBootstrapDataObject(machineScope, objectStoreSvc); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	if instance.State == infrav1.InstanceStateTerminated {
 		machineScope.SetFailureReason(capierrors.UpdateMachineError)
@@ -562,9 +689,54 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 			return ctrl.Result{}, errors.Errorf("failed to apply security groups: %+v", err)
 		}
 		conditions.MarkTrue(machineScope.AWSMachine, infrav1.SecurityGroupsReadyCondition)
+
+		// Ensure that the volumes are tagged and match the requested size, IOPS and throughput.
+		modifying, volumeIDs, err := ec2svc.ReconcileVolumes(machineScope, instance)
+		if err != nil {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.VolumesReadyCondition, infrav1.VolumeModificationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return ctrl.Result{}, errors.Wrap(err, "failed to reconcile volumes")
+		}
+		if modifying {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.VolumesReadyCondition, infrav1.VolumeModificationInProgressReason, clusterv1.ConditionSeverityInfo, "")
+			if result.RequeueAfter == 0 {
+				result = ctrl.Result{RequeueAfter: volumeModificationRequeueAfter}
+			}
+		} else {
+			conditions.MarkTrue(machineScope.AWSMachine, infrav1.VolumesReadyCondition)
+		}
+
+		// Surface the status checks and scheduled events of the instance.
+		if err := ec2svc.ReconcileInstanceHealth(machineScope, instance, volumeIDs); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to reconcile instance health")
+		}
 	}
 
-	return ctrl.Result{}, nil
+	// Secondary network interfaces can only be attached once the instance is running.
+	if machineScope.InstanceIsRunning() && len(machineScope.AWSMachine.Spec.SecondaryNetworkInterfaces) > 0 {
+		interfaces, err := ec2svc.ReconcileSecondaryNetworkInterfaces(machineScope, instance)
+		if err != nil {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.NetworkInterfacesReadyCondition, infrav1.NetworkInterfacesFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return ctrl.Result{}, errors.Wrap(err, "failed to reconcile secondary network interfaces")
+		}
+		machineScope.SetNetworkInterfaces(interfaces)
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.NetworkInterfacesReadyCondition)
+	}
+
+	return result, nil
+}
+
+func (r *AWSMachineReconciler) deleteSecondaryNetworkInterfaces(machineScope *scope.MachineScope, ec2Service services.EC2MachineInterface) error {
+	if len(machineScope.AWSMachine.Spec.SecondaryNetworkInterfaces) == 0 {
+		return nil
+	}
+
+	if err := ec2Service.DeleteSecondaryNetworkInterfaces(machineScope); err != nil {
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.NetworkInterfacesReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrap(err, "failed to delete secondary network interfaces")
+	}
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.NetworkInterfacesReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+
+	return nil
 }
 
 func (r *AWSMachineReconciler) deleteEncryptedBootstrapDataSecret(machineScope *scope.MachineScope, secretSvc services.SecretInterface) error {
@@ -594,7 +766,59 @@ func (r *AWSMachineReconciler) deleteEncryptedBootstrapDataSecret(machineScope *
 	return nil
 }
 
-func (r *AWSMachineReconciler) createInstance(scope *scope.MachineScope, ec2svc services.EC2MachineInterface, secretSvc services.SecretInterface) (*infrav1.Instance, error) {
+func (r *AWSMachineReconciler) deleteIgnitionBootstrapDataObject(machineScope *scope.MachineScope, objectStoreSvc services.ObjectStoreInterface) error {
+	// do nothing if there isn't an object
+	if machineScope.GetBootstrapDataObject() == "" || objectStoreSvc == nil {
+		return nil
+	}
+
+	// Do nothing if the AWSMachine is not in a failed state, and is operational from an EC2 perspective, but does not have a node reference
+	if !machineScope.HasFailed() && machineScope.InstanceIsOperational() && machineScope.Machine.Status.NodeRef == nil && !machineScope.AWSMachineIsDeleted() {
+		return nil
+	}
+	machineScope.Info("Deleting unneeded Ignition config from S3", "key", machineScope.GetBootstrapDataObject())
+	if err := objectStoreSvc.DeleteObject(machineScope.GetBootstrapDataObject()); err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDeleteBootstrapDataObject", "S3 object containing userdata not deleted: %v", err)
+		return err
+	}
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulDeleteBootstrapDataObject", "S3 object containing userdata deleted")
+
+	machineScope.SetBootstrapDataObject("")
+
+	return nil
+}
+
+// ignitionUserData stores the Ignition config of a machine in the cluster's S3 bucket, if it has one, and
+// returns a config which makes Ignition fetch it with the instance profile. Ignition cannot fetch from
+// Secrets Manager or SSM Parameter Store, so without a bucket the config is only passed as userdata as-is
+// if the machine opted into it.
+func (r *AWSMachineReconciler) ignitionUserData(scope *scope.MachineScope, objectStoreSvc services.ObjectStoreInterface, userData []byte) ([]byte, error) {
+	if objectStoreSvc == nil {
+		if !scope.InlineIgnitionUserData() {
+			return nil, errors.New("Ignition config cannot be stored without an S3 bucket on the cluster: set one, or set ignition.insecureInlineUserData to pass it as plaintext userdata")
+		}
+		if len(userData) > userdata.MaxUserDataSize {
+			return nil, errors.Errorf("Ignition config is %d bytes, more than the %d bytes allowed in userdata: set an S3 bucket on the cluster to store it", len(userData), userdata.MaxUserDataSize)
+		}
+		return userData, nil
+	}
+
+	key := s3.ObjectKey(scope.Namespace(), scope.Cluster.Name, scope.Role(), scope.Name())
+	source, err := objectStoreSvc.CreateObject(key, userData)
+	if err != nil {
+		r.Recorder.Eventf(scope.AWSMachine, corev1.EventTypeWarning, "FailedCreateBootstrapDataObject", err.Error())
+		return nil, err
+	}
+	// Register the object immediately so that it is deleted even if the instance fails to be created.
+	scope.SetBootstrapDataObject(key)
+	if err := scope.PatchObject(); err != nil {
+		return nil, err
+	}
+
+	return userdata.IgnitionReplace(userData, source)
+}
+
+func (r *AWSMachineReconciler) createInstance(scope *scope.MachineScope, ec2svc services.EC2MachineInterface, secretSvc services.SecretInterface, objectStoreSvc services.ObjectStoreInterface) (*infrav1.Instance, error) {
 	scope.Info("Creating EC2 instance")
 
 	userData, err := scope.GetRawBootstrapData()
@@ -603,7 +827,13 @@ func (r *AWSMachineReconciler) createInstance(scope *scope.MachineScope, ec2svc
 		return nil, err
 	}
 
-	if scope.UseSecretsManager() { // nolint:nestif
+	switch {
+	case scope.UseIgnition():
+		userData, err = r.ignitionUserData(scope, objectStoreSvc, userData)
+		if err != nil {
+			return nil, err
+		}
+	case scope.UseSecretsManager():
 		compressedUserData, err := userdata.GzipBytes(userData)
 		if err != nil {
 			return nil, err
@@ -623,7 +853,11 @@ func (r *AWSMachineReconciler) createInstance(scope *scope.MachineScope, ec2svc
 			scope.Error(serviceErr, "Failed to create AWS Secret entry", "secretPrefix", prefix)
 			return nil, serviceErr
 		}
-		encryptedCloudInit, err := secretSvc.UserData(scope.GetSecretPrefix(), scope.GetSecretCount(), scope.InfraCluster.Region(), r.Endpoints)
+		generateUserData := secretSvc.UserData
+		if scope.IsWindows() {
+			generateUserData = secretSvc.WindowsUserData
+		}
+		encryptedCloudInit, err := generateUserData(scope.GetSecretPrefix(), scope.GetSecretCount(), false, scope.InfraCluster.Region(), r.Endpoints)
 		if err != nil {
 			r.Recorder.Eventf(scope.AWSMachine, corev1.EventTypeWarning, "FailedGenerateAWSSecretsCloudInit", err.Error())
 			return nil, err
@@ -644,31 +878,12 @@ func (r *AWSMachineReconciler) reconcileLBAttachment(machineScope *scope.Machine
 		return nil
 	}
 
-	elbsvc := elb.NewService(clusterScope)
+	elbsvc := r.getELBService(clusterScope)
 
 	// In order to prevent sending request to a "not-ready" control plane machines, it is required to remove the machine
 	// from the ELB as soon as the machine gets deleted or when the machine is in a not running state.
 	if !machineScope.AWSMachine.DeletionTimestamp.IsZero() || !machineScope.InstanceIsRunning() {
-		registered, err := elbsvc.InstanceIsRegisteredWithAPIServerELB(i)
-		if err != nil {
-			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachControlPlaneELB",
-				"Failed to deregister control plane instance %q from load balancer: failed to determine registration status: %v", i.ID, err)
-			return errors.Wrapf(err, "could not deregister control plane instance %q from load balancer - error determining registration status", i.ID)
-		}
-		if !registered {
-			// Already deregistered - nothing more to do
-			return nil
-		}
-
-		if err := elbsvc.DeregisterInstanceFromAPIServerELB(i); err != nil {
-			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachControlPlaneELB",
-				"Failed to deregister control plane instance %q from load balancer: %v", i.ID, err)
-			conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBDetachFailedReason, clusterv1.ConditionSeverityError, err.Error())
-			return errors.Wrapf(err, "could not deregister control plane instance %q from load balancer", i.ID)
-		}
-		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulDetachControlPlaneELB",
-			"Control plane instance %q is de-registered from load balancer", i.ID)
-		return nil
+		return r.deregisterInstanceFromAPIServerELB(machineScope, elbsvc, i)
 	}
 
 	registered, err := elbsvc.InstanceIsRegisteredWithAPIServerELB(i)
@@ -694,6 +909,39 @@ func (r *AWSMachineReconciler) reconcileLBAttachment(machineScope *scope.Machine
 	return nil
 }
 
+// deregisterBeforeStop deregisters control plane instances from the API server load balancer before they are
+// stopped, so that no requests are sent to them while they shut down.
+func (r *AWSMachineReconciler) deregisterBeforeStop(machineScope *scope.MachineScope, clusterScope scope.ELBScope, i *infrav1.Instance) error {
+	if !machineScope.IsControlPlane() {
+		return nil
+	}
+
+	return r.deregisterInstanceFromAPIServerELB(machineScope, r.getELBService(clusterScope), i)
+}
+
+func (r *AWSMachineReconciler) deregisterInstanceFromAPIServerELB(machineScope *scope.MachineScope, elbsvc services.ELBInterface, i *infrav1.Instance) error {
+	registered, err := elbsvc.InstanceIsRegisteredWithAPIServerELB(i)
+	if err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachControlPlaneELB",
+			"Failed to deregister control plane instance %q from load balancer: failed to determine registration status: %v", i.ID, err)
+		return errors.Wrapf(err, "could not deregister control plane instance %q from load balancer - error determining registration status", i.ID)
+	}
+	if !registered {
+		// Already deregistered - nothing more to do
+		return nil
+	}
+
+	if err := elbsvc.DeregisterInstanceFromAPIServerELB(i); err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedDetachControlPlaneELB",
+			"Failed to deregister control plane instance %q from load balancer: %v", i.ID, err)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.ELBAttachedCondition, infrav1.ELBDetachFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return errors.Wrapf(err, "could not deregister control plane instance %q from load balancer", i.ID)
+	}
+	r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulDetachControlPlaneELB",
+		"Control plane instance %q is de-registered from load balancer", i.ID)
+	return nil
+}
+
 // AWSClusterToAWSMachines is a handler.ToRequestsFunc to be used to enqeue requests for reconciliation
 // of AWSMachines.
 func (r *AWSMachineReconciler) AWSClusterToAWSMachines(o handler.MapObject) []ctrl.Request {
@@ -148,6 +148,8 @@ var _ = Describe("AWSMachineReconciler", func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		ec2Svc = mock_services.NewMockEC2MachineInterface(mockCtrl)
 		secretSvc = mock_services.NewMockSecretInterface(mockCtrl)
+		ec2Svc.EXPECT().ReconcileVolumes(gomock.Any(), gomock.Any()).Return(false, nil, nil).AnyTimes()
+		ec2Svc.EXPECT().ReconcileInstanceHealth(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 		// If your test hangs for 9 minutes, increase the value here to the number of events during a reconciliation loop
 		recorder = record.NewFakeRecorder(2)
@@ -245,7 +247,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 				ec2Svc.EXPECT().InstanceIfExists(gomock.Any()).Return(nil, nil)
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
 				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any()).Return(nil, expectedErr)
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
 				Expect(errors.Cause(err)).To(MatchError(expectedErr))
@@ -271,7 +273,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 				})
 
 				It("should set attributes after creating an instance", func() {
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
 					Expect(ms.AWSMachine.Spec.ProviderID).To(PointTo(Equal("aws:////myMachine")))
 				})
@@ -285,7 +287,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 					})
 
 					It("should set instance to pending", func() {
-						secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+						secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 						instance.State = infrav1.InstanceStatePending
 						_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
 						Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStatePending)))
@@ -295,7 +297,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 					})
 
 					It("should set instance to running", func() {
-						secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+						secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 						instance.State = infrav1.InstanceStateRunning
 						_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
 						Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStateRunning)))
@@ -314,7 +316,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 					klog.SetOutput(buf)
 					instance.State = "NewAWSMachineState"
 					secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					_, _ = reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
 					Expect(ms.AWSMachine.Status.Ready).To(Equal(false))
 					Expect(buf.String()).To(ContainSubstring(("EC2 instance state is undefined")))
@@ -328,7 +330,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 				BeforeEach(func() {
 					ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).
 						Return(map[string][]string{"eid": {}}, nil)
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil)
 				})
 
@@ -352,6 +354,99 @@ var _ = Describe("AWSMachineReconciler", func() {
 					}
 				})
 
+				It("should reconcile secondary network interfaces once the instance is running", func() {
+					instance.State = infrav1.InstanceStateRunning
+					ms.AWSMachine.Spec.SecondaryNetworkInterfaces = infrav1.SecondaryNetworkInterfaces{{DeviceIndex: 1}}
+					interfaces := []infrav1.NetworkInterfaceStatus{{ID: "eni-1", DeviceIndex: 1, PrivateIPs: []string{"10.0.0.10"}}}
+					ec2Svc.EXPECT().ReconcileSecondaryNetworkInterfaces(ms, instance).Return(interfaces, nil)
+
+					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
+					Expect(err).To(BeNil())
+					Expect(ms.AWSMachine.Status.NetworkInterfaces).To(Equal(interfaces))
+					expectConditions(ms.AWSMachine, []conditionAssertion{{conditionType: infrav1.NetworkInterfacesReadyCondition, status: corev1.ConditionTrue}})
+				})
+
+				It("should stop the instance to change its instance type when allowed", func() {
+					instance.State = infrav1.InstanceStateRunning
+					instance.Type = "m5.large"
+					ms.AWSMachine.Spec.InstanceType = "m5.xlarge"
+					ms.AWSMachine.Annotations = map[string]string{infrav1.MutableInstanceTypeAnnotation: "true"}
+					ec2Svc.EXPECT().StopInstance(instance.ID).Return(nil)
+
+					result, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
+					Expect(err).To(BeNil())
+					Expect(result.RequeueAfter).To(Equal(instanceTypeChangeRequeueAfter))
+					Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStateStopping)))
+					Expect(ms.AWSMachine.Status.Ready).To(Equal(false))
+					expectConditions(ms.AWSMachine, []conditionAssertion{
+						{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceTypeChangingReason},
+						{infrav1.InstanceTypeReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceStoppingForTypeChangeReason},
+					})
+				})
+
+				It("should modify and start a stopped instance to change its instance type", func() {
+					instance.State = infrav1.InstanceStateStopped
+					instance.Type = "m5.large"
+					ms.AWSMachine.Spec.InstanceType = "m5.xlarge"
+					ms.AWSMachine.Annotations = map[string]string{infrav1.MutableInstanceTypeAnnotation: "true"}
+					ec2Svc.EXPECT().ModifyInstanceType(instance.ID, "m5.xlarge").Return(nil)
+					ec2Svc.EXPECT().StartInstance(instance.ID).Return(nil)
+
+					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
+					Expect(err).To(BeNil())
+					Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStatePending)))
+					expectConditions(ms.AWSMachine, []conditionAssertion{
+						{infrav1.InstanceTypeReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceStartingAfterTypeChangeReason},
+					})
+				})
+
+				It("should not change the instance type without the annotation", func() {
+					instance.State = infrav1.InstanceStateRunning
+					instance.Type = "m5.large"
+					ms.AWSMachine.Spec.InstanceType = "m5.xlarge"
+					ec2Svc.EXPECT().StopInstance(gomock.Any()).Times(0)
+
+					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
+					Expect(err).To(BeNil())
+					Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStateRunning)))
+				})
+
+				It("should stop the instance when requested", func() {
+					instance.State = infrav1.InstanceStateRunning
+					ms.AWSMachine.Annotations = map[string]string{infrav1.PowerStateAnnotation: string(infrav1.PowerStateStopped)}
+					ec2Svc.EXPECT().StopInstance(instance.ID).Return(nil)
+
+					result, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
+					Expect(err).To(BeNil())
+					Expect(result.RequeueAfter).To(Equal(powerStateChangeRequeueAfter))
+					Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStateStopping)))
+					Expect(ms.AWSMachine.Status.Ready).To(Equal(false))
+					Expect(ms.AWSMachine.Status.FailureReason).To(BeNil())
+					expectConditions(ms.AWSMachine, []conditionAssertion{
+						{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.InstanceStoppedOnRequestReason},
+					})
+				})
+
+				It("should start a stopped instance when requested", func() {
+					instance.State = infrav1.InstanceStateStopped
+					ms.AWSMachine.Annotations = map[string]string{infrav1.PowerStateAnnotation: string(infrav1.PowerStateRunning)}
+					ec2Svc.EXPECT().StartInstance(instance.ID).Return(nil)
+
+					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
+					Expect(err).To(BeNil())
+					Expect(ms.AWSMachine.Status.InstanceState).To(PointTo(Equal(infrav1.InstanceStatePending)))
+				})
+
+				It("should reboot a running instance when requested and clear the request", func() {
+					instance.State = infrav1.InstanceStateRunning
+					ms.AWSMachine.Annotations = map[string]string{infrav1.RebootAnnotation: ""}
+					ec2Svc.EXPECT().RebootInstance(instance.ID).Return(nil)
+
+					_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs)
+					Expect(err).To(BeNil())
+					Expect(ms.AWSMachine.Annotations).NotTo(HaveKey(infrav1.RebootAnnotation))
+				})
+
 				It("should tag instances from machine and cluster tags", func() {
 					ms.AWSMachine.Spec.AdditionalTags = infrav1.Tags{"kind": "alicorn"}
 					cs.AWSCluster.Spec.AdditionalTags = infrav1.Tags{"colour": "lavender"}
@@ -377,7 +472,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 					klog.SetOutput(buf)
 					ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).
 						Return(map[string][]string{"eid": {}}, nil).Times(1)
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 					ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
 				})
 
@@ -414,7 +509,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 					buf = new(bytes.Buffer)
 					klog.SetOutput(buf)
 					secretSvc.EXPECT().Delete(gomock.Any()).Return(nil).Times(1)
-					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+					secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				})
 
 				It("should warn if an instance is shutting-down", func() {
@@ -450,7 +545,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(nil, nil).AnyTimes()
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(secretPrefix, int32(1), nil).Times(1)
 				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any()).Return(instance, nil).AnyTimes()
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
 
@@ -574,7 +669,7 @@ var _ = Describe("AWSMachineReconciler", func() {
 				instance.State = infrav1.InstanceStatePending
 				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(secretPrefix, int32(1), nil).Times(1)
 				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any()).Return(instance, nil).AnyTimes()
-				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), false, gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 				ec2Svc.EXPECT().GetInstanceSecurityGroups(gomock.Any()).Return(map[string][]string{"eid": {}}, nil).Times(1)
 				ec2Svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{}, nil).Times(1)
 
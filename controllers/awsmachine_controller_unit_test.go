@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/klogr"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -109,3 +110,65 @@ func TestAWSMachineReconciler_AWSClusterToAWSMachines(t *testing.T) {
 		t.Fatalf("Expected 2 but found %d requests", len(requests))
 	}
 }
+
+func TestAWSMachineReconciler_IgnitionUserData(t *testing.T) {
+	userData := []byte(`{"ignition":{"version":"2.3.0"}}`)
+
+	testCases := []struct {
+		name        string
+		inline      bool
+		expectError bool
+	}{
+		{
+			name:        "fails without a bucket by default",
+			expectError: true,
+		},
+		{
+			name:   "passes the config as userdata when opted into",
+			inline: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       fake.NewFakeClient(),
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+				Machine:      &clusterv1.Machine{},
+				InfraCluster: clusterScope,
+				AWSMachine: &infrav1.AWSMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+					Spec: infrav1.AWSMachineSpec{
+						UserDataFormat: infrav1.UserDataFormatIgnition,
+						Ignition:       infrav1.Ignition{InsecureInlineUserData: tc.inline},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := &AWSMachineReconciler{}
+			got, err := r.ignitionUserData(machineScope, nil, userData)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("Expected an error without a bucket")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(userData) {
+				t.Fatalf("Expected the Ignition config as userdata, got %s", got)
+			}
+		})
+	}
+}
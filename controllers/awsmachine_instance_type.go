@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileInstanceType changes the instance type of the instance in place when the AWSMachine
+// allows it, by stopping the instance, modifying its instance type and starting it again. Control plane
+// instances are deregistered from the API server load balancer before they are stopped.
+// The instance state is updated as the steps are taken, so that the rest of the reconcile, such
+// as the API server load balancer registration, acts on the instance as it is being changed.
+// Returns true while a change is in progress.
+func (r *AWSMachineReconciler) reconcileInstanceType(machineScope *scope.MachineScope, ec2svc service.EC2MachineInterface, elbScope scope.ELBScope, instance *infrav1.Instance) (bool, error) {
+	desired := machineScope.AWSMachine.Spec.InstanceType
+
+	if desired == "" || desired == instance.Type {
+		if !conditions.Has(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition) || conditions.IsTrue(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition) {
+			return false, nil
+		}
+		// The instance type has been changed, wait for the instance to be running again.
+		if instance.State != infrav1.InstanceStateRunning {
+			if conditions.GetReason(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition) == infrav1.InstanceStartingAfterTypeChangeReason {
+				return true, nil
+			}
+			return false, nil
+		}
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulChangeInstanceType", "Changed instance type of instance %q to %q", instance.ID, instance.Type)
+		conditions.MarkTrue(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition)
+		return false, nil
+	}
+
+	if !machineScope.AWSMachine.HasMutableInstanceType() {
+		machineScope.V(2).Info("Instance type differs from spec but the AWSMachine does not allow in-place changes", "instance-id", instance.ID, "instance-type", instance.Type, "desired-instance-type", desired)
+		return false, nil
+	}
+
+	switch instance.State {
+	case infrav1.InstanceStateRunning:
+		if err := r.deregisterBeforeStop(machineScope, elbScope, instance); err != nil {
+			return false, err
+		}
+		if err := ec2svc.StopInstance(instance.ID); err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedChangeInstanceType", "Failed to stop instance %q to change its instance type: %v", instance.ID, err)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition, infrav1.InstanceTypeChangeFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return false, err
+		}
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "InstanceTypeChangeStarted", "Stopping instance %q to change its instance type from %q to %q", instance.ID, instance.Type, desired)
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition, infrav1.InstanceStoppingForTypeChangeReason, clusterv1.ConditionSeverityInfo, "")
+		instance.State = infrav1.InstanceStateStopping
+
+	case infrav1.InstanceStatePending, infrav1.InstanceStateStopping:
+		// Wait for the instance to settle before stopping or modifying it.
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition, infrav1.InstanceStoppingForTypeChangeReason, clusterv1.ConditionSeverityInfo, "")
+
+	case infrav1.InstanceStateStopped:
+		if err := ec2svc.ModifyInstanceType(instance.ID, desired); err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedChangeInstanceType", "Failed to change instance type of instance %q to %q: %v", instance.ID, desired, err)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition, infrav1.InstanceTypeChangeFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return false, err
+		}
+		instance.Type = desired
+
+		// Leave instances which were stopped on request stopped.
+		if machineScope.AWSMachine.DesiredPowerState() == infrav1.PowerStateStopped {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulChangeInstanceType", "Changed instance type of instance %q to %q", instance.ID, instance.Type)
+			conditions.MarkTrue(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition)
+			return false, nil
+		}
+
+		if err := ec2svc.StartInstance(instance.ID); err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedChangeInstanceType", "Failed to start instance %q after changing its instance type: %v", instance.ID, err)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition, infrav1.InstanceTypeChangeFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return false, err
+		}
+		conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceTypeReadyCondition, infrav1.InstanceStartingAfterTypeChangeReason, clusterv1.ConditionSeverityInfo, "")
+		instance.State = infrav1.InstanceStatePending
+
+	default:
+		return false, errors.Errorf("cannot change instance type of instance %q in state %q", instance.ID, instance.State)
+	}
+
+	machineScope.SetInstanceState(instance.State)
+	machineScope.SetNotReady()
+	conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceTypeChangingReason, clusterv1.ConditionSeverityInfo, "")
+
+	return true, nil
+}
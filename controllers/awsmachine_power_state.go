@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcilePowerState stops, starts or reboots the instance as requested through the PowerStateAnnotation
+// and the RebootAnnotation. Control plane instances are deregistered from the API server load balancer
+// before they are stopped. The instance state is updated as the instance is stopped or started, so that
+// the rest of the reconcile acts on the instance as it is being changed.
+// Returns true while the instance is transitioning to the requested power state.
+func (r *AWSMachineReconciler) reconcilePowerState(machineScope *scope.MachineScope, ec2svc service.EC2MachineInterface, elbScope scope.ELBScope, instance *infrav1.Instance) (bool, error) {
+	switch machineScope.AWSMachine.DesiredPowerState() {
+	case infrav1.PowerStateStopped:
+		switch instance.State {
+		case infrav1.InstanceStateRunning:
+			if err := r.deregisterBeforeStop(machineScope, elbScope, instance); err != nil {
+				return false, err
+			}
+			if err := ec2svc.StopInstance(instance.ID); err != nil {
+				r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedStopInstance", "Failed to stop instance %q: %v", instance.ID, err)
+				conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstancePowerStateChangeFailedReason, clusterv1.ConditionSeverityError, err.Error())
+				return false, err
+			}
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulStopInstance", "Stopping instance %q as requested", instance.ID)
+			r.setInstanceState(machineScope, instance, infrav1.InstanceStateStopping)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceStoppedOnRequestReason, clusterv1.ConditionSeverityInfo, "")
+			return true, nil
+		case infrav1.InstanceStatePending, infrav1.InstanceStateStopping:
+			return true, nil
+		}
+
+	case infrav1.PowerStateRunning:
+		switch instance.State {
+		case infrav1.InstanceStateStopped:
+			// Instances stopped to change their instance type are started once it has been changed.
+			if desired := machineScope.AWSMachine.Spec.InstanceType; machineScope.AWSMachine.HasMutableInstanceType() && desired != "" && desired != instance.Type {
+				return false, nil
+			}
+			if err := ec2svc.StartInstance(instance.ID); err != nil {
+				r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedStartInstance", "Failed to start instance %q: %v", instance.ID, err)
+				conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstancePowerStateChangeFailedReason, clusterv1.ConditionSeverityError, err.Error())
+				return false, err
+			}
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulStartInstance", "Starting instance %q as requested", instance.ID)
+			r.setInstanceState(machineScope, instance, infrav1.InstanceStatePending)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceNotReadyReason, clusterv1.ConditionSeverityInfo, "")
+			return true, nil
+		case infrav1.InstanceStatePending, infrav1.InstanceStateStopping:
+			return true, nil
+		}
+	}
+
+	// Reboots are only applied to running instances, and are kept until the instance is running.
+	if _, ok := machineScope.AWSMachine.GetAnnotations()[infrav1.RebootAnnotation]; ok && instance.State == infrav1.InstanceStateRunning {
+		if err := ec2svc.RebootInstance(instance.ID); err != nil {
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedRebootInstance", "Failed to reboot instance %q: %v", instance.ID, err)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstancePowerStateChangeFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return false, err
+		}
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeNormal, "SuccessfulRebootInstance", "Rebooted instance %q as requested", instance.ID)
+
+		annotations := machineScope.AWSMachine.GetAnnotations()
+		delete(annotations, infrav1.RebootAnnotation)
+		machineScope.AWSMachine.SetAnnotations(annotations)
+	}
+
+	return false, nil
+}
+
+// setInstanceState records a state the instance was moved to during this reconcile.
+func (r *AWSMachineReconciler) setInstanceState(machineScope *scope.MachineScope, instance *infrav1.Instance, state infrav1.InstanceState) {
+	instance.State = state
+	machineScope.SetInstanceState(state)
+	machineScope.SetNotReady()
+}
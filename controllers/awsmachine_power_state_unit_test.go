@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/mock_services"
+)
+
+func TestAWSMachineReconciler_DeregisterBeforeStop(t *testing.T) {
+	stopOnRequest := func(m *infrav1.AWSMachine) {
+		m.Annotations = map[string]string{infrav1.PowerStateAnnotation: string(infrav1.PowerStateStopped)}
+	}
+	changeInstanceType := func(m *infrav1.AWSMachine) {
+		m.Annotations = map[string]string{infrav1.MutableInstanceTypeAnnotation: "true"}
+		m.Spec.InstanceType = "m5.xlarge"
+	}
+	reconcilePowerState := func(r *AWSMachineReconciler, s *scope.MachineScope, ec2svc services.EC2MachineInterface, elbScope scope.ELBScope, i *infrav1.Instance) (bool, error) {
+		return r.reconcilePowerState(s, ec2svc, elbScope, i)
+	}
+	reconcileInstanceType := func(r *AWSMachineReconciler, s *scope.MachineScope, ec2svc services.EC2MachineInterface, elbScope scope.ELBScope, i *infrav1.Instance) (bool, error) {
+		return r.reconcileInstanceType(s, ec2svc, elbScope, i)
+	}
+
+	testCases := []struct {
+		name         string
+		controlPlane bool
+		setup        func(m *infrav1.AWSMachine)
+		reconcile    func(r *AWSMachineReconciler, s *scope.MachineScope, ec2svc services.EC2MachineInterface, elbScope scope.ELBScope, i *infrav1.Instance) (bool, error)
+		expect       func(ec2 *mock_services.MockEC2MachineInterfaceMockRecorder, elb *mock_services.MockELBInterfaceMockRecorder)
+		expectError  bool
+	}{
+		{
+			name:         "control plane instance stopped on request is deregistered first",
+			controlPlane: true,
+			setup:        stopOnRequest,
+			reconcile:    reconcilePowerState,
+			expect: func(ec2 *mock_services.MockEC2MachineInterfaceMockRecorder, elb *mock_services.MockELBInterfaceMockRecorder) {
+				gomock.InOrder(
+					elb.InstanceIsRegisteredWithAPIServerELB(gomock.Any()).Return(true, nil),
+					elb.DeregisterInstanceFromAPIServerELB(gomock.Any()).Return(nil),
+					ec2.StopInstance("i-1234").Return(nil),
+				)
+			},
+		},
+		{
+			name:         "control plane instance stopped to change its instance type is deregistered first",
+			controlPlane: true,
+			setup:        changeInstanceType,
+			reconcile:    reconcileInstanceType,
+			expect: func(ec2 *mock_services.MockEC2MachineInterfaceMockRecorder, elb *mock_services.MockELBInterfaceMockRecorder) {
+				gomock.InOrder(
+					elb.InstanceIsRegisteredWithAPIServerELB(gomock.Any()).Return(true, nil),
+					elb.DeregisterInstanceFromAPIServerELB(gomock.Any()).Return(nil),
+					ec2.StopInstance("i-1234").Return(nil),
+				)
+			},
+		},
+		{
+			name:         "control plane instance is not stopped when it cannot be deregistered",
+			controlPlane: true,
+			setup:        stopOnRequest,
+			reconcile:    reconcilePowerState,
+			expect: func(ec2 *mock_services.MockEC2MachineInterfaceMockRecorder, elb *mock_services.MockELBInterfaceMockRecorder) {
+				elb.InstanceIsRegisteredWithAPIServerELB(gomock.Any()).Return(true, nil)
+				elb.DeregisterInstanceFromAPIServerELB(gomock.Any()).Return(errors.New("throttled"))
+			},
+			expectError: true,
+		},
+		{
+			name:      "worker instance is stopped without touching the load balancer",
+			setup:     changeInstanceType,
+			reconcile: reconcileInstanceType,
+			expect: func(ec2 *mock_services.MockEC2MachineInterfaceMockRecorder, elb *mock_services.MockELBInterfaceMockRecorder) {
+				ec2.StopInstance("i-1234").Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_services.NewMockEC2MachineInterface(mockCtrl)
+			elbMock := mock_services.NewMockELBInterface(mockCtrl)
+
+			awsMachine := &infrav1.AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+			tc.setup(awsMachine)
+			machine := &clusterv1.Machine{}
+			if tc.controlPlane {
+				machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabelName: ""}
+			}
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			g.Expect(err).To(BeNil())
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       fake.NewFakeClient(),
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+				Machine:      machine,
+				InfraCluster: clusterScope,
+				AWSMachine:   awsMachine,
+			})
+			g.Expect(err).To(BeNil())
+
+			tc.expect(ec2Mock.EXPECT(), elbMock.EXPECT())
+
+			r := &AWSMachineReconciler{
+				Recorder: record.NewFakeRecorder(10),
+				elbServiceFactory: func(scope.ELBScope) services.ELBInterface {
+					return elbMock
+				},
+			}
+			instance := &infrav1.Instance{ID: "i-1234", State: infrav1.InstanceStateRunning, Type: "m5.large"}
+			_, err = tc.reconcile(r, machineScope, ec2Mock, clusterScope, instance)
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				g.Expect(instance.State).To(Equal(infrav1.InstanceStateRunning))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(instance.State).To(Equal(infrav1.InstanceStateStopping))
+		})
+	}
+}
@@ -235,6 +235,15 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 		})
 	}
 
+	// Instances of the highly available bastion host are replaced by its Auto Scaling group, so the control
+	// plane is resynced for the Elastic IP address of the bastion host to follow them.
+	if awsManagedControlPlane.Spec.Bastion.HighAvailability && !awsManagedControlPlane.Spec.Bastion.IsSSM() {
+		if !conditions.IsTrue(awsManagedControlPlane, infrav1.BastionHostReadyCondition) {
+			return reconcile.Result{RequeueAfter: 15 * time.Second}, nil
+		}
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -256,6 +265,14 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(_ context.Context, ma
 		return reconcile.Result{}, fmt.Errorf("error deleting bastion for AWSManagedControlPlane %s/%s: %w", controlPlane.Namespace, controlPlane.Name, err)
 	}
 
+	if err := ec2svc.DeletePlacementGroups(); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error deleting placement groups for AWSManagedControlPlane %s/%s: %w", controlPlane.Namespace, controlPlane.Name, err)
+	}
+
+	if err := ec2svc.DeleteDedicatedHosts(); err != nil {
+		return reconcile.Result{}, fmt.Errorf("error deleting dedicated hosts for AWSManagedControlPlane %s/%s: %w", controlPlane.Namespace, controlPlane.Name, err)
+	}
+
 	if err := sgService.DeleteSecurityGroups(); err != nil {
 		return reconcile.Result{}, fmt.Errorf("error deleting general security groups for AWSManagedControlPlane %s/%s: %w", controlPlane.Namespace, controlPlane.Name, err) //nolint:goerr113
 	}
@@ -76,6 +76,32 @@ type AWSMachinePoolSpec struct {
 	// Enable or disable the capacity rebalance autoscaling group feature
 	// +optional
 	CapacityRebalance bool `json:"capacityRebalance,omitempty"`
+
+	// PlacementGroup is the placement group instances of the pool are launched into.
+	// +optional
+	PlacementGroup *infrav1.PlacementGroup `json:"placementGroup,omitempty"`
+
+	// CloudInit defines options related to the bootstrapping systems where
+	// CloudInit is used. The secrets holding the userdata are shared by the
+	// instances of the pool, and replaced whenever a new launch template
+	// version is created.
+	// +optional
+	CloudInit infrav1.CloudInit `json:"cloudInit,omitempty"`
+}
+
+// ReplacedBootstrapSecrets is a set of secrets holding the bootstrap data of a previous launch template version.
+type ReplacedBootstrapSecrets struct {
+	// SecureSecretsBackend is the backend the secrets are stored in.
+	SecureSecretsBackend infrav1.SecretBackend `json:"secureSecretsBackend"`
+
+	// SecretPrefix is the prefix of the secrets.
+	SecretPrefix string `json:"secretPrefix"`
+
+	// SecretCount is the number of secrets.
+	SecretCount int32 `json:"secretCount"`
+
+	// ReplacedAt is the time a new launch template version replaced the secrets.
+	ReplacedAt metav1.Time `json:"replacedAt"`
 }
 
 // AWSMachinePoolStatus defines the observed state of AWSMachinePool
@@ -95,6 +121,18 @@ type AWSMachinePoolStatus struct {
 	// The ID of the launch template
 	LaunchTemplateID string `json:"launchTemplateID,omitempty"`
 
+	// Image is the AMI resolved for the launch template. It is reused while it is
+	// resolved from the same source, so that instances launched by scaling up run
+	// the same image until the launch template or Kubernetes version changes.
+	// +optional
+	Image *infrav1.ResolvedImage `json:"image,omitempty"`
+
+	// ReplacedSecrets are the secrets holding the bootstrap data of previous launch template
+	// versions. They are kept for a grace period after being replaced, as instances launched
+	// from these versions may still be booting and fetching them.
+	// +optional
+	ReplacedSecrets []ReplacedBootstrapSecrets `json:"replacedSecrets,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
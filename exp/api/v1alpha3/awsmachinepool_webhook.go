@@ -26,6 +26,8 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 )
 
 var log = logf.Log.WithName("awsmachinepool-resource")
@@ -64,6 +66,13 @@ func (r *AWSMachinePool) ValidateCreate() error {
 		allErrs = append(allErrs, errs...)
 	}
 
+	allErrs = append(allErrs, r.Spec.PlacementGroup.Validate(field.NewPath("spec", "placementGroup"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.CapacityReservation.Validate(field.NewPath("spec", "awsLaunchTemplate", "capacityReservation"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.AMI.ValidateAMI(field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.Platform.Validate(field.NewPath("spec", "awsLaunchTemplate", "platform"), r.Spec.AWSLaunchTemplate.ImageLookupArchitecture)...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.UserDataFormat.Validate(field.NewPath("spec", "awsLaunchTemplate", "userDataFormat"), r.Spec.AWSLaunchTemplate.Platform)...)
+	allErrs = append(allErrs, r.Spec.CloudInit.Validate(field.NewPath("spec", "cloudInit"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -82,6 +91,13 @@ func (r *AWSMachinePool) ValidateUpdate(old runtime.Object) error {
 		allErrs = append(allErrs, errs...)
 	}
 
+	allErrs = append(allErrs, r.Spec.PlacementGroup.Validate(field.NewPath("spec", "placementGroup"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.CapacityReservation.Validate(field.NewPath("spec", "awsLaunchTemplate", "capacityReservation"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.AMI.ValidateAMI(field.NewPath("spec", "awsLaunchTemplate", "ami"))...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.Platform.Validate(field.NewPath("spec", "awsLaunchTemplate", "platform"), r.Spec.AWSLaunchTemplate.ImageLookupArchitecture)...)
+	allErrs = append(allErrs, r.Spec.AWSLaunchTemplate.UserDataFormat.Validate(field.NewPath("spec", "awsLaunchTemplate", "userDataFormat"), r.Spec.AWSLaunchTemplate.Platform)...)
+	allErrs = append(allErrs, r.Spec.CloudInit.Validate(field.NewPath("spec", "cloudInit"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -104,4 +120,7 @@ func (r *AWSMachinePool) Default() {
 		log.Info("DefaultCoolDown is zero, setting 300 seconds as default")
 		r.Spec.DefaultCoolDown.Duration = 300 * time.Second
 	}
+	if !r.Spec.CloudInit.InsecureSkipSecretsManager && r.Spec.CloudInit.SecureSecretsBackend == "" {
+		r.Spec.CloudInit.SecureSecretsBackend = infrav1.SecretBackendSecretsManager
+	}
 }
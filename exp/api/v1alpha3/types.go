@@ -67,13 +67,14 @@ type AWSLaunchTemplate struct {
 	IamInstanceProfile string `json:"iamInstanceProfile,omitempty"`
 
 	// AMI is the reference to the AMI from which to create the machine instance.
+	// AMIs referenced by SSM parameter or looked up are pinned in status.image.
 	// +optional
 	AMI infrav1.AWSResourceReference `json:"ami,omitempty"`
 
 	// ImageLookupFormat is the AMI naming format to look up the image for this
 	// machine It will be ignored if an explicit AMI is set. Supports
-	// substitutions for {{.BaseOS}} and {{.K8sVersion}} with the base OS and
-	// kubernetes version, respectively. The BaseOS will be the value in
+	// substitutions for {{.BaseOS}}, {{.K8sVersion}} and {{.Arch}} with the base OS,
+	// kubernetes version and architecture, respectively. The BaseOS will be the value in
 	// ImageLookupBaseOS or ubuntu (the default), and the kubernetes version as
 	// defined by the packages produced by kubernetes/release without v as a
 	// prefix: 1.13.0, 1.12.5-mybuild.1, or 1.17.3. For example, the default
@@ -91,6 +92,30 @@ type AWSLaunchTemplate struct {
 	// image lookup the AMI is not set.
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
+	// ImageLookupArchitecture is the CPU architecture of the image to look up if AMI is not set.
+	// Defaults to the architecture of the instance type.
+	// +kubebuilder:validation:Enum=x86_64;arm64
+	// +optional
+	ImageLookupArchitecture string `json:"imageLookupArchitecture,omitempty"`
+
+	// Platform is the operating system platform of the instance, either linux or windows. Windows
+	// instances use Windows AMIs and are bootstrapped with PowerShell userdata. Defaults to linux.
+	// +kubebuilder:validation:Enum=linux;windows
+	// +optional
+	Platform infrav1.Platform `json:"platform,omitempty"`
+
+	// UserDataFormat is the format of the bootstrap data, either cloud-config or ignition.
+	// Ignition configs are stored in the cluster's S3 bucket and referenced from the userdata.
+	// Defaults to cloud-config.
+	// +kubebuilder:validation:Enum=cloud-config;ignition
+	// +optional
+	UserDataFormat infrav1.UserDataFormat `json:"userDataFormat,omitempty"`
+
+	// Ignition defines options related to the bootstrapping systems where
+	// Ignition is used.
+	// +optional
+	Ignition infrav1.Ignition `json:"ignition,omitempty"`
+
 	// InstanceType is the type of instance to create. Example: m4.xlarge
 	InstanceType string `json:"instanceType,omitempty"`
 
@@ -115,6 +140,10 @@ type AWSLaunchTemplate struct {
 	// at the cluster level or in the actuator.
 	// +optional
 	AdditionalSecurityGroups []infrav1.AWSResourceReference `json:"additionalSecurityGroups,omitempty"`
+
+	// CapacityReservation sets the On-Demand Capacity Reservation preference of the instances.
+	// +optional
+	CapacityReservation *infrav1.CapacityReservationSpec `json:"capacityReservation,omitempty"`
 }
 
 // Overrides are used to override the instance type specified by the launch template with multiple
@@ -31,10 +31,11 @@ import (
 func (in *AWSLaunchTemplate) DeepCopyInto(out *AWSLaunchTemplate) {
 	*out = *in
 	in.AMI.DeepCopyInto(&out.AMI)
+	out.Ignition = in.Ignition
 	if in.RootVolume != nil {
 		in, out := &in.RootVolume, &out.RootVolume
 		*out = new(apiv1alpha3.Volume)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.SSHKeyName != nil {
 		in, out := &in.SSHKeyName, &out.SSHKeyName
@@ -53,6 +54,11 @@ func (in *AWSLaunchTemplate) DeepCopyInto(out *AWSLaunchTemplate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CapacityReservation != nil {
+		in, out := &in.CapacityReservation, &out.CapacityReservation
+		*out = new(apiv1alpha3.CapacityReservationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLaunchTemplate.
@@ -158,6 +164,12 @@ func (in *AWSMachinePoolSpec) DeepCopyInto(out *AWSMachinePoolSpec) {
 		copy(*out, *in)
 	}
 	out.DefaultCoolDown = in.DefaultCoolDown
+	if in.PlacementGroup != nil {
+		in, out := &in.PlacementGroup, &out.PlacementGroup
+		*out = new(apiv1alpha3.PlacementGroup)
+		(*in).DeepCopyInto(*out)
+	}
+	out.CloudInit = in.CloudInit
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSMachinePoolSpec.
@@ -180,6 +192,18 @@ func (in *AWSMachinePoolStatus) DeepCopyInto(out *AWSMachinePoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(apiv1alpha3.ResolvedImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplacedSecrets != nil {
+		in, out := &in.ReplacedSecrets, &out.ReplacedSecrets
+		*out = make([]ReplacedBootstrapSecrets, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -655,6 +679,22 @@ func (in *Overrides) DeepCopy() *Overrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacedBootstrapSecrets) DeepCopyInto(out *ReplacedBootstrapSecrets) {
+	*out = *in
+	in.ReplacedAt.DeepCopyInto(&out.ReplacedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacedBootstrapSecrets.
+func (in *ReplacedBootstrapSecrets) DeepCopy() *ReplacedBootstrapSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplacedBootstrapSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Tags) DeepCopyInto(out *Tags) {
 	{
@@ -19,7 +19,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"path"
 	"reflect"
+	"time"
 
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -49,15 +51,28 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
 	asg "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/autoscaling"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/s3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/secretsmanager"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ssm"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/userdata"
 )
 
+// replacedBootstrapSecretsGracePeriod is how long the secrets of a previous launch template version are kept,
+// so that the instances launched from it before it was replaced have booted and fetched them.
+const replacedBootstrapSecretsGracePeriod = 30 * time.Minute
+
 // AWSMachinePoolReconciler reconciles a AWSMachinePool object
 type AWSMachinePoolReconciler struct {
 	client.Client
-	Log               logr.Logger
-	Recorder          record.EventRecorder
-	asgServiceFactory func(cloud.ClusterScoper) services.ASGInterface
-	ec2ServiceFactory func(scope.EC2Scope) services.EC2MachineInterface
+	Log                          logr.Logger
+	Recorder                     record.EventRecorder
+	asgServiceFactory            func(cloud.ClusterScoper) services.ASGInterface
+	ec2ServiceFactory            func(scope.EC2Scope) services.EC2MachineInterface
+	objectStoreServiceFactory    func(scope.S3Scope) services.ObjectStoreInterface
+	secretsManagerServiceFactory func(cloud.ClusterScoper) services.SecretInterface
+	ssmServiceFactory            func(cloud.ClusterScoper) services.SecretInterface
+	s3ServiceFactory             func(scope.S3Scope) services.SecretInterface
+	Endpoints                    []scope.ServiceEndpoint
 }
 
 func (r *AWSMachinePoolReconciler) getASGService(scope cloud.ClusterScoper) services.ASGInterface {
@@ -75,6 +90,37 @@ func (r *AWSMachinePoolReconciler) getEC2Service(scope scope.EC2Scope) services.
 	return ec2.NewService(scope)
 }
 
+func (r *AWSMachinePoolReconciler) getObjectStoreService(scope scope.S3Scope) services.ObjectStoreInterface {
+	if scope.Bucket() == nil {
+		return nil
+	}
+	if r.objectStoreServiceFactory != nil {
+		return r.objectStoreServiceFactory(scope)
+	}
+	return s3.NewService(scope)
+}
+
+func (r *AWSMachinePoolReconciler) getSecretService(backend infrav1.SecretBackend, ec2Scope scope.EC2Scope) (services.SecretInterface, error) {
+	switch backend {
+	case infrav1.SecretBackendSSMParameterStore:
+		if r.ssmServiceFactory != nil {
+			return r.ssmServiceFactory(ec2Scope), nil
+		}
+		return ssm.NewService(ec2Scope), nil
+	case infrav1.SecretBackendSecretsManager:
+		if r.secretsManagerServiceFactory != nil {
+			return r.secretsManagerServiceFactory(ec2Scope), nil
+		}
+		return secretsmanager.NewService(ec2Scope), nil
+	case infrav1.SecretBackendS3:
+		if r.s3ServiceFactory != nil {
+			return r.s3ServiceFactory(ec2Scope), nil
+		}
+		return s3.NewService(ec2Scope), nil
+	}
+	return nil, errors.New("invalid secret backend")
+}
+
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachinepools/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=exp.cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch;patch
@@ -231,6 +277,9 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(_ context.Context, machinePoo
 	// set the LaunchTemplateReady condition
 	conditions.MarkTrue(machinePoolScope.AWSMachinePool, infrav1exp.LaunchTemplateReadyCondition)
 
+	// Failures are retried on the next reconciliation, as the launch template is up to date already.
+	requeueAfter, _ := r.deleteReplacedBootstrapSecrets(machinePoolScope, ec2Scope, false)
+
 	// Initialize ASG client
 	asgsvc := r.getASGService(clusterScope)
 
@@ -247,7 +296,7 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(_ context.Context, machinePoo
 			conditions.MarkFalse(machinePoolScope.AWSMachinePool, infrav1exp.ASGReadyCondition, infrav1exp.ASGProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	if machinePoolScope.MachinePool.Spec.ExternallyManagedReplicaCount {
@@ -288,7 +337,7 @@ func (r *AWSMachinePoolReconciler) reconcileNormal(_ context.Context, machinePoo
 	machinePoolScope.AWSMachinePool.Status.Ready = true
 	conditions.MarkTrue(machinePoolScope.AWSMachinePool, infrav1exp.ASGReadyCondition)
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) (ctrl.Result, error) {
@@ -323,6 +372,21 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 		}
 	}
 
+	if machinePoolScope.GetSecretPrefix() != "" {
+		secretSvc, err := r.getSecretService(machinePoolScope.SecureSecretsBackend(), ec2Scope)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := secretSvc.Delete(machinePoolScope); err != nil {
+			r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDeleteAWSSecrets", "Failed to delete secrets %q: %v", machinePoolScope.GetSecretPrefix(), err)
+			return ctrl.Result{}, errors.Wrap(err, "failed to delete AWS Secret entries")
+		}
+	}
+
+	if _, err := r.deleteReplacedBootstrapSecrets(machinePoolScope, ec2Scope, true); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	launchTemplate, err := ec2Svc.GetLaunchTemplate(machinePoolScope.AWSMachinePool.Status.LaunchTemplateID)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -335,6 +399,15 @@ func (r *AWSMachinePoolReconciler) reconcileDelete(machinePoolScope *scope.Machi
 		return ctrl.Result{}, nil
 	}
 
+	if machinePoolScope.UseIgnition() {
+		if objectStoreSvc := r.getObjectStoreService(ec2Scope); objectStoreSvc != nil {
+			if err := objectStoreSvc.DeleteObject(bootstrapDataObjectKey(machinePoolScope)); err != nil {
+				r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete Ignition config: %v", err)
+				return ctrl.Result{}, errors.Wrap(err, "failed to delete Ignition config")
+			}
+		}
+	}
+
 	machinePoolScope.Info("deleting launch template", "name", launchTemplate.Name)
 	if err := ec2Svc.DeleteLaunchTemplate(launchTemplate.ID); err != nil {
 		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDelete", "Failed to delete launch template %q: %v", launchTemplate.Name, err)
@@ -410,11 +483,19 @@ func (r *AWSMachinePoolReconciler) reconcileLaunchTemplate(machinePoolScope *sco
 
 	if launchTemplate == nil {
 		machinePoolScope.Info("no existing launch template found, creating")
+		previousSecrets := currentBootstrapSecrets(machinePoolScope)
+		userData, err = r.launchTemplateUserData(machinePoolScope, ec2Scope, userData)
+		if err != nil {
+			conditions.MarkFalse(machinePoolScope.AWSMachinePool, infrav1exp.LaunchTemplateReadyCondition, infrav1exp.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return err
+		}
 		launchTemplateID, err := ec2svc.CreateLaunchTemplate(machinePoolScope, imageID, userData)
 		if err != nil {
+			r.restoreBootstrapSecrets(machinePoolScope, ec2Scope, previousSecrets)
 			conditions.MarkFalse(machinePoolScope.AWSMachinePool, infrav1exp.LaunchTemplateReadyCondition, infrav1exp.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			return err
 		}
+		r.replaceBootstrapSecrets(machinePoolScope, previousSecrets)
 		machinePoolScope.AWSMachinePool.Status.LaunchTemplateID = launchTemplateID
 		if err := machinePoolScope.PatchObject(); err != nil {
 			return err
@@ -444,13 +525,228 @@ func (r *AWSMachinePoolReconciler) reconcileLaunchTemplate(machinePoolScope *sco
 	// OR we've discovered a new AMI ID
 	if needsUpdate || tagsChanged || *imageID != *launchTemplate.AMI.ID {
 		machinePoolScope.Info("creating new version for launch template", "existing", launchTemplate, "incoming", machinePoolScope.AWSMachinePool.Spec.AWSLaunchTemplate)
+		previousSecrets := currentBootstrapSecrets(machinePoolScope)
+		userData, err = r.launchTemplateUserData(machinePoolScope, ec2Scope, userData)
+		if err != nil {
+			return err
+		}
 		if err := ec2svc.CreateLaunchTemplateVersion(machinePoolScope, imageID, userData); err != nil {
+			r.restoreBootstrapSecrets(machinePoolScope, ec2Scope, previousSecrets)
 			return err
 		}
+		r.replaceBootstrapSecrets(machinePoolScope, previousSecrets)
 	}
 	return nil
 }
 
+// launchTemplateUserData returns the userdata of the launch template of a machine pool. Ignition configs
+// are stored in the cluster's S3 bucket, as Ignition cannot fetch them from Secrets Manager, or passed as
+// plaintext userdata if the pool opted into it.
+// Otherwise the bootstrap data is stored in a new set of secrets, unless the pool opted out of it.
+func (r *AWSMachinePoolReconciler) launchTemplateUserData(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope, userData []byte) ([]byte, error) {
+	if machinePoolScope.UseSecretsManager() && !machinePoolScope.UseIgnition() {
+		return r.secretsUserData(machinePoolScope, ec2Scope, userData)
+	}
+	if !machinePoolScope.UseIgnition() {
+		return userData, nil
+	}
+
+	objectStoreSvc := r.getObjectStoreService(ec2Scope)
+	if objectStoreSvc == nil {
+		if !machinePoolScope.InlineIgnitionUserData() {
+			return nil, errors.New("Ignition config cannot be stored without an S3 bucket on the cluster: set one, or set awsLaunchTemplate.ignition.insecureInlineUserData to pass it as plaintext userdata")
+		}
+		if len(userData) > userdata.MaxUserDataSize {
+			return nil, errors.Errorf("Ignition config is %d bytes, more than the %d bytes allowed in userdata: set an S3 bucket on the cluster to store it", len(userData), userdata.MaxUserDataSize)
+		}
+		return userData, nil
+	}
+
+	source, err := objectStoreSvc.CreateObject(bootstrapDataObjectKey(machinePoolScope), userData)
+	if err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedCreateBootstrapDataObject", err.Error())
+		return nil, err
+	}
+
+	return userdata.IgnitionReplace(userData, source)
+}
+
+// secretsUserData stores the bootstrap data of a machine pool in a new set of secrets, and returns the
+// userdata fetching it. All the instances started from a launch template version share its secrets, so
+// these are kept on boot, and only deleted once a new version has replaced them for a grace period.
+func (r *AWSMachinePoolReconciler) secretsUserData(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope, userData []byte) ([]byte, error) {
+	secretSvc, err := r.getSecretService(machinePoolScope.SecureSecretsBackend(), ec2Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	compressedUserData, err := userdata.GzipBytes(userData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always create a new set of secrets, as instances started from the current launch template
+	// version may still be fetching the previous ones.
+	machinePoolScope.SetSecretPrefix("")
+	machinePoolScope.SetSecretCount(0)
+	prefix, chunks, serviceErr := secretSvc.Create(machinePoolScope, compressedUserData)
+	if chunks > 0 {
+		machinePoolScope.SetSecretPrefix(prefix)
+		machinePoolScope.SetSecretCount(chunks)
+	}
+	// Register the secrets immediately to avoid orphaning whatever AWS resources have been created
+	if err := machinePoolScope.PatchObject(); err != nil {
+		return nil, err
+	}
+	if serviceErr != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedCreateAWSSecrets", serviceErr.Error())
+		machinePoolScope.Error(serviceErr, "Failed to create AWS Secret entry", "secretPrefix", prefix)
+		return nil, serviceErr
+	}
+
+	generateUserData := secretSvc.UserData
+	if machinePoolScope.IsWindows() {
+		generateUserData = secretSvc.WindowsUserData
+	}
+	encryptedCloudInit, err := generateUserData(machinePoolScope.GetSecretPrefix(), machinePoolScope.GetSecretCount(), true, ec2Scope.Region(), r.Endpoints)
+	if err != nil {
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedGenerateAWSSecretsCloudInit", err.Error())
+		return nil, err
+	}
+
+	return encryptedCloudInit, nil
+}
+
+// bootstrapSecrets identifies a set of secrets holding the bootstrap data of a machine pool,
+// which need not be the set the pool currently refers to.
+type bootstrapSecrets struct {
+	scope.SecretScope
+	backend infrav1.SecretBackend
+	prefix  string
+	count   int32
+}
+
+// GetSecretPrefix returns the prefix of the set of secrets.
+func (s bootstrapSecrets) GetSecretPrefix() string {
+	return s.prefix
+}
+
+// GetSecretCount returns the number of secrets in the set.
+func (s bootstrapSecrets) GetSecretCount() int32 {
+	return s.count
+}
+
+// currentBootstrapSecrets returns the set of secrets the machine pool currently refers to.
+func currentBootstrapSecrets(machinePoolScope *scope.MachinePoolScope) bootstrapSecrets {
+	return bootstrapSecrets{
+		SecretScope: machinePoolScope,
+		backend:     machinePoolScope.SecureSecretsBackend(),
+		prefix:      machinePoolScope.GetSecretPrefix(),
+		count:       machinePoolScope.GetSecretCount(),
+	}
+}
+
+// restoreBootstrapSecrets deletes the secrets created for a launch template version which failed to be
+// created, and points the machine pool back to the previous ones.
+func (r *AWSMachinePoolReconciler) restoreBootstrapSecrets(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope, previous bootstrapSecrets) {
+	current := currentBootstrapSecrets(machinePoolScope)
+	if current.prefix == previous.prefix {
+		return
+	}
+	machinePoolScope.SetSecretPrefix(previous.prefix)
+	machinePoolScope.SetSecretCount(previous.count)
+	if err := machinePoolScope.PatchObject(); err != nil {
+		machinePoolScope.Error(err, "Failed to restore the AWS Secret entries of the previous launch template version")
+		return
+	}
+	// The secrets left behind are deleted by the bootstrap secrets sweeper otherwise.
+	r.deleteBootstrapSecrets(machinePoolScope, ec2Scope, current)
+}
+
+// replaceBootstrapSecrets records the secrets of the launch template version a new version replaced, so
+// that they are deleted once the instances launched from it have had time to fetch them.
+func (r *AWSMachinePoolReconciler) replaceBootstrapSecrets(machinePoolScope *scope.MachinePoolScope, previous bootstrapSecrets) {
+	if previous.prefix == "" || previous.prefix == machinePoolScope.GetSecretPrefix() {
+		return
+	}
+	status := &machinePoolScope.AWSMachinePool.Status
+	status.ReplacedSecrets = append(status.ReplacedSecrets, infrav1exp.ReplacedBootstrapSecrets{
+		SecureSecretsBackend: previous.backend,
+		SecretPrefix:         previous.prefix,
+		SecretCount:          previous.count,
+		ReplacedAt:           metav1.Now(),
+	})
+	// Register the replaced secrets immediately to avoid orphaning them
+	if err := machinePoolScope.PatchObject(); err != nil {
+		machinePoolScope.Error(err, "Failed to record the AWS Secret entries of the previous launch template version", "secretPrefix", previous.prefix)
+	}
+}
+
+// deleteReplacedBootstrapSecrets deletes the secrets of previous launch template versions once their grace
+// period has passed, or all of them when the machine pool is deleted. Returns how long until the grace period
+// of the remaining secrets has passed.
+func (r *AWSMachinePoolReconciler) deleteReplacedBootstrapSecrets(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope, all bool) (time.Duration, error) {
+	status := &machinePoolScope.AWSMachinePool.Status
+	if len(status.ReplacedSecrets) == 0 {
+		return 0, nil
+	}
+
+	var requeueAfter time.Duration
+	var remaining []infrav1exp.ReplacedBootstrapSecrets
+	var failed []string
+	for _, replaced := range status.ReplacedSecrets {
+		if wait := replacedBootstrapSecretsGracePeriod - time.Since(replaced.ReplacedAt.Time); !all && wait > 0 {
+			if requeueAfter == 0 || wait < requeueAfter {
+				requeueAfter = wait
+			}
+			remaining = append(remaining, replaced)
+			continue
+		}
+		secrets := bootstrapSecrets{
+			SecretScope: machinePoolScope,
+			backend:     replaced.SecureSecretsBackend,
+			prefix:      replaced.SecretPrefix,
+			count:       replaced.SecretCount,
+		}
+		if !r.deleteBootstrapSecrets(machinePoolScope, ec2Scope, secrets) {
+			remaining = append(remaining, replaced)
+			failed = append(failed, replaced.SecretPrefix)
+		}
+	}
+	status.ReplacedSecrets = remaining
+
+	if len(failed) > 0 {
+		return requeueAfter, errors.Errorf("failed to delete the AWS Secret entries %q of previous launch template versions", failed)
+	}
+	return requeueAfter, nil
+}
+
+// deleteBootstrapSecrets deletes a set of secrets once no launch template version refers to it anymore,
+// and returns whether it succeeded. Failures are reported in an event.
+func (r *AWSMachinePoolReconciler) deleteBootstrapSecrets(machinePoolScope *scope.MachinePoolScope, ec2Scope scope.EC2Scope, secrets bootstrapSecrets) bool {
+	if secrets.prefix == "" || secrets.prefix == machinePoolScope.GetSecretPrefix() {
+		return true
+	}
+
+	secretSvc, err := r.getSecretService(secrets.backend, ec2Scope)
+	if err == nil {
+		err = secretSvc.Delete(secrets)
+	}
+	if err != nil {
+		machinePoolScope.Error(err, "Failed to delete AWS Secret entries", "secretPrefix", secrets.prefix)
+		r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeWarning, "FailedDeleteAWSSecrets", "Failed to delete secrets %q: %v", secrets.prefix, err)
+		return false
+	}
+	r.Recorder.Eventf(machinePoolScope.AWSMachinePool, corev1.EventTypeNormal, "SuccessfulDeleteAWSSecrets", "Deleted secrets %q", secrets.prefix)
+	return true
+}
+
+// bootstrapDataObjectKey returns the key of the object holding the Ignition config of a machine pool.
+// Instances of the pool fetch it whenever they boot, so it is kept until the pool is deleted.
+func bootstrapDataObjectKey(machinePoolScope *scope.MachinePoolScope) string {
+	return s3.ObjectKey(machinePoolScope.Namespace(), machinePoolScope.Cluster.Name, "node", path.Join("pools", machinePoolScope.Name()))
+}
+
 func (r *AWSMachinePoolReconciler) reconcileTags(machinePoolScope *scope.MachinePoolScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope) error {
 	ec2Svc := r.getEC2Service(ec2Scope)
 	asgSvc := r.getASGService(clusterScope)
@@ -574,6 +870,7 @@ func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log logr
 			Cluster:        cluster,
 			ControlPlane:   controlPlane,
 			ControllerName: "awsManagedControlPlane",
+			Endpoints:      r.Endpoints,
 		})
 		if err != nil {
 			return nil, err
@@ -601,6 +898,7 @@ func (r *AWSMachinePoolReconciler) getInfraCluster(ctx context.Context, log logr
 		Cluster:        cluster,
 		AWSCluster:     awsCluster,
 		ControllerName: "awsmachine",
+		Endpoints:      r.Endpoints,
 	})
 	if err != nil {
 		return nil, err
@@ -21,6 +21,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -55,6 +56,7 @@ var _ = Describe("AWSMachinePoolReconciler", func() {
 		mockCtrl   *gomock.Controller
 		ec2Svc     *mock_services.MockEC2MachineInterface
 		asgSvc     *mock_services.MockASGInterface
+		secretSvc  *mock_services.MockSecretInterface
 		recorder   *record.FakeRecorder
 	)
 
@@ -122,6 +124,7 @@ var _ = Describe("AWSMachinePoolReconciler", func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		ec2Svc = mock_services.NewMockEC2MachineInterface(mockCtrl)
 		asgSvc = mock_services.NewMockASGInterface(mockCtrl)
+		secretSvc = mock_services.NewMockSecretInterface(mockCtrl)
 
 		// If the test hangs for 9 minutes, increase the value here to the number of events during a reconciliation loop
 		recorder = record.NewFakeRecorder(2)
@@ -133,6 +136,9 @@ var _ = Describe("AWSMachinePoolReconciler", func() {
 			asgServiceFactory: func(cloud.ClusterScoper) services.ASGInterface {
 				return asgSvc
 			},
+			secretsManagerServiceFactory: func(cloud.ClusterScoper) services.SecretInterface {
+				return secretSvc
+			},
 			Recorder: recorder,
 		}
 	})
@@ -214,10 +220,91 @@ var _ = Describe("AWSMachinePoolReconciler", func() {
 				asgSvc.EXPECT().ASGIfExists(gomock.Any()).Return(nil, nil).AnyTimes()
 				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(nil, nil)
 				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(nil, nil)
+				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil)
+				secretSvc.EXPECT().UserData("test", int32(1), true, gomock.Any(), gomock.Any()).Return(nil, nil)
 				ec2Svc.EXPECT().CreateLaunchTemplate(gomock.Any(), gomock.Any(), gomock.Any()).Return("", expectedErr).AnyTimes()
+				secretSvc.EXPECT().Delete(gomock.Any()).Return(nil)
 
 				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs)
 				Expect(errors.Cause(err)).To(MatchError(expectedErr))
+				Expect(ms.GetSecretPrefix()).To(BeEmpty())
+			})
+		})
+
+		When("the launch template needs a new version", func() {
+			var launchTemplate *expinfrav1.AWSLaunchTemplate
+
+			BeforeEach(func() {
+				ms.SetSecretPrefix("previous")
+				ms.SetSecretCount(1)
+				launchTemplate = &expinfrav1.AWSLaunchTemplate{
+					ID:  "lt-1",
+					AMI: infrav1.AWSResourceReference{ID: pointer.StringPtr("ami-1")},
+				}
+				ec2Svc.EXPECT().GetLaunchTemplate(gomock.Any()).Return(launchTemplate, nil)
+				ec2Svc.EXPECT().DiscoverLaunchTemplateAMI(gomock.Any()).Return(pointer.StringPtr("ami-2"), nil)
+				ec2Svc.EXPECT().LaunchTemplateNeedsUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("next", int32(1), nil)
+				secretSvc.EXPECT().UserData("next", int32(1), true, gomock.Any(), gomock.Any()).Return([]byte("userdata"), nil)
+			})
+
+			It("should replace the secrets holding the bootstrap data, and keep the previous ones", func() {
+				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), []byte("userdata")).Return(nil)
+				secretSvc.EXPECT().Delete(gomock.Any()).Times(0)
+
+				Expect(reconciler.reconcileLaunchTemplate(ms, cs)).To(Succeed())
+				Expect(ms.GetSecretPrefix()).To(Equal("next"))
+				Expect(ms.AWSMachinePool.Status.ReplacedSecrets).To(HaveLen(1))
+				Expect(ms.AWSMachinePool.Status.ReplacedSecrets[0].SecretPrefix).To(Equal("previous"))
+			})
+
+			It("should keep the previous secrets if the version can't be created", func() {
+				expectedErr := errors.New("Invalid launch template")
+				ec2Svc.EXPECT().CreateLaunchTemplateVersion(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedErr)
+				secretSvc.EXPECT().Delete(gomock.Any()).DoAndReturn(func(s scope.SecretScope) error {
+					Expect(s.GetSecretPrefix()).To(Equal("next"))
+					return nil
+				})
+
+				Expect(reconciler.reconcileLaunchTemplate(ms, cs)).To(MatchError(expectedErr))
+				Expect(ms.GetSecretPrefix()).To(Equal("previous"))
+			})
+		})
+
+		When("the secrets of a previous launch template version were replaced", func() {
+			BeforeEach(func() {
+				ms.SetSecretPrefix("next")
+				ms.SetSecretCount(1)
+				ms.AWSMachinePool.Status.ReplacedSecrets = []expinfrav1.ReplacedBootstrapSecrets{
+					{
+						SecureSecretsBackend: infrav1.SecretBackendSecretsManager,
+						SecretPrefix:         "previous",
+						SecretCount:          1,
+						ReplacedAt:           metav1.NewTime(time.Now().Add(-time.Minute)),
+					},
+				}
+			})
+
+			It("should keep them during the grace period", func() {
+				secretSvc.EXPECT().Delete(gomock.Any()).Times(0)
+
+				requeueAfter, err := reconciler.deleteReplacedBootstrapSecrets(ms, cs, false)
+				Expect(err).To(BeNil())
+				Expect(requeueAfter).To(BeNumerically("~", replacedBootstrapSecretsGracePeriod-time.Minute, time.Minute))
+				Expect(ms.AWSMachinePool.Status.ReplacedSecrets).To(HaveLen(1))
+			})
+
+			It("should delete them once the grace period has passed", func() {
+				ms.AWSMachinePool.Status.ReplacedSecrets[0].ReplacedAt = metav1.NewTime(time.Now().Add(-replacedBootstrapSecretsGracePeriod))
+				secretSvc.EXPECT().Delete(gomock.Any()).DoAndReturn(func(s scope.SecretScope) error {
+					Expect(s.GetSecretPrefix()).To(Equal("previous"))
+					return nil
+				})
+
+				requeueAfter, err := reconciler.deleteReplacedBootstrapSecrets(ms, cs, false)
+				Expect(err).To(BeNil())
+				Expect(requeueAfter).To(BeZero())
+				Expect(ms.AWSMachinePool.Status.ReplacedSecrets).To(BeEmpty())
 			})
 		})
 
@@ -36,6 +36,7 @@ import (
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1alpha3"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	infrav1alpha2 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha2"
@@ -46,6 +47,7 @@ import (
 	controllersexp "sigs.k8s.io/cluster-api-provider-aws/exp/controllers"
 	"sigs.k8s.io/cluster-api-provider-aws/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/endpoints"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
 	"sigs.k8s.io/cluster-api-provider-aws/version"
 	// +kubebuilder:scaffold:imports
@@ -67,6 +69,10 @@ func init() {
 	// +kubebuilder:scaffold:scheme
 }
 
+// instanceStateEventsBufferSize is the number of instance state changes that can be
+// waiting for the AWSMachine controller before further ones are dropped.
+const instanceStateEventsBufferSize = 1000
+
 var (
 	metricsAddr             string
 	enableLeaderElection    bool
@@ -79,6 +85,9 @@ var (
 	webhookPort             int
 	healthAddr              string
 	serviceEndpoints        string
+	instanceEventsPoll      time.Duration
+	bootstrapSecretsSweep   time.Duration
+	lookupCacheTTL          time.Duration
 	errEKSInvalidFlags      = errors.New("invalid EKS flag combination")
 )
 
@@ -96,6 +105,8 @@ func main() {
 		setupLog.Info("Watching cluster-api objects only in namespace for reconciliation", "namespace", watchNamespace)
 	}
 
+	ec2.SetLookupCacheTTL(lookupCacheTTL)
+
 	if profilerAddress != "" {
 		setupLog.Info("Profiler listening for requests", "profiler-address", profilerAddress)
 		go func() {
@@ -139,11 +150,19 @@ func main() {
 	}
 
 	if webhookPort == 0 {
+		// Instance state changes consumed from the instance events queue trigger
+		// the reconciliation of the AWSMachines they refer to.
+		var instanceStateEvents chan event.GenericEvent
+		if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+			instanceStateEvents = make(chan event.GenericEvent, instanceStateEventsBufferSize)
+		}
+
 		if err = (&controllers.AWSMachineReconciler{
-			Client:    mgr.GetClient(),
-			Log:       ctrl.Log.WithName("controllers").WithName("AWSMachine"),
-			Recorder:  mgr.GetEventRecorderFor("awsmachine-controller"),
-			Endpoints: AWSServiceEndpoints,
+			Client:              mgr.GetClient(),
+			Log:                 ctrl.Log.WithName("controllers").WithName("AWSMachine"),
+			Recorder:            mgr.GetEventRecorderFor("awsmachine-controller"),
+			Endpoints:           AWSServiceEndpoints,
+			InstanceStateEvents: instanceStateEvents,
 		}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: awsMachineConcurrency}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSMachine")
 			os.Exit(1)
@@ -158,6 +177,33 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&controllers.AWSBootstrapSecretsReconciler{
+			Client:        mgr.GetClient(),
+			Log:           ctrl.Log.WithName("controllers").WithName("AWSBootstrapSecrets"),
+			Recorder:      mgr.GetEventRecorderFor("awsbootstrapsecrets-controller"),
+			Endpoints:     AWSServiceEndpoints,
+			SweepInterval: bootstrapSecretsSweep,
+			MachinePools:  feature.Gates.Enabled(feature.MachinePool),
+		}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AWSBootstrapSecrets")
+			os.Exit(1)
+		}
+
+		if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+			setupLog.Info("enabling instance state controller")
+			if err = (&controllers.AWSInstanceStateReconciler{
+				Client:              mgr.GetClient(),
+				Log:                 ctrl.Log.WithName("controllers").WithName("AWSInstanceState"),
+				Recorder:            mgr.GetEventRecorderFor("awsinstancestate-controller"),
+				Endpoints:           AWSServiceEndpoints,
+				PollInterval:        instanceEventsPoll,
+				InstanceStateEvents: instanceStateEvents,
+			}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency}); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "AWSInstanceState")
+				os.Exit(1)
+			}
+		}
+
 		if feature.Gates.Enabled(feature.EKS) {
 			setupLog.Info("enabling EKS controllers")
 
@@ -189,9 +235,10 @@ func main() {
 		}
 		if feature.Gates.Enabled(feature.MachinePool) {
 			if err = (&controllersexp.AWSMachinePoolReconciler{
-				Client:   mgr.GetClient(),
-				Log:      ctrl.Log.WithName("controllers").WithName("AWSMachinePool"),
-				Recorder: mgr.GetEventRecorderFor("awsmachinepool-controller"),
+				Client:    mgr.GetClient(),
+				Log:       ctrl.Log.WithName("controllers").WithName("AWSMachinePool"),
+				Recorder:  mgr.GetEventRecorderFor("awsmachinepool-controller"),
+				Endpoints: AWSServiceEndpoints,
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "AWSMachinePool")
 				os.Exit(1)
@@ -329,5 +376,23 @@ func initFlags(fs *pflag.FlagSet) {
 		"Set custom AWS service endpoins in semi-colon separated format: ${SigningRegion1}:${ServiceID1}=${URL},${ServiceID2}=${URL};${SigningRegion2}...",
 	)
 
+	fs.DurationVar(&instanceEventsPoll,
+		"instance-events-poll-interval",
+		controllers.DefaultInstanceEventsPollInterval,
+		"The interval at which the instance events queue of each cluster is polled, when the EventBridgeInstanceState feature is enabled",
+	)
+
+	fs.DurationVar(&bootstrapSecretsSweep,
+		"bootstrap-secrets-sweep-interval",
+		controllers.DefaultBootstrapSecretsSweepInterval,
+		"The interval at which the Secrets Manager secrets and SSM parameters holding bootstrap data are swept, deleting those leaked by the machines of each cluster",
+	)
+
+	fs.DurationVar(&lookupCacheTTL,
+		"aws-lookup-cache-ttl",
+		ec2.DefaultLookupCacheTTL,
+		"The time for which image and instance type lookups are cached and shared between reconciles. Set to 0 to disable caching",
+	)
+
 	feature.MutableGates.AddFlag(fs)
 }
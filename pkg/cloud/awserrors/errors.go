@@ -42,6 +42,10 @@ const (
 	InvalidInstanceID       = "InvalidInstanceID.NotFound"
 	ResourceExists          = "ResourceExistsException"
 	NoCredentialProviders   = "NoCredentialProviders"
+	ReservationCapacity     = "ReservationCapacityExceeded"
+	AccessDenied            = "AccessDeniedException"
+
+	LaunchTemplateNameNotFound = "InvalidLaunchTemplateName.NotFoundException"
 )
 
 var _ error = &EC2Error{}
@@ -123,6 +127,24 @@ func IsConflict(err error) bool {
 	return ReasonForError(err) == http.StatusConflict
 }
 
+// IsReservationCapacityExceeded returns true if the error indicates that a targeted
+// capacity reservation has no available capacity left.
+func IsReservationCapacityExceeded(err error) bool {
+	if code, ok := Code(err); ok {
+		return code == ReservationCapacity
+	}
+	return false
+}
+
+// IsAccessDenied returns true if the error indicates that the request was denied by the IAM policies
+// of the caller.
+func IsAccessDenied(err error) bool {
+	if code, ok := Code(err); ok {
+		return code == AccessDenied
+	}
+	return false
+}
+
 // IsSDKError returns true if the error is of type awserr.Error.
 func IsSDKError(err error) (ok bool) {
 	_, ok = err.(awserr.Error)
@@ -137,6 +159,8 @@ func IsInvalidNotFoundError(err error) bool {
 			return true
 		case InvalidInstanceID:
 			return true
+		case LaunchTemplateNameNotFound:
+			return true
 		case ssm.ErrCodeParameterNotFound:
 			return true
 		}
@@ -21,6 +21,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
@@ -123,6 +125,49 @@ func MapToSSMTags(src infrav1.Tags) []*ssm.Tag {
 	return tags
 }
 
+// MapToEventBridgeTags converts a infrav1.Tags to a []*eventbridge.Tag
+func MapToEventBridgeTags(src infrav1.Tags) []*eventbridge.Tag {
+	tags := make([]*eventbridge.Tag, 0, len(src))
+
+	for k, v := range src {
+		tag := &eventbridge.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// MapToS3Tags converts a infrav1.Tags to a []*s3.Tag
+func MapToS3Tags(src infrav1.Tags) []*s3.Tag {
+	tags := make([]*s3.Tag, 0, len(src))
+
+	for k, v := range src {
+		tag := &s3.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// S3TagsToMap converts a []*s3.Tag into a infrav1.Tags.
+func S3TagsToMap(src []*s3.Tag) infrav1.Tags {
+	tags := make(infrav1.Tags, len(src))
+
+	for _, t := range src {
+		tags[*t.Key] = *t.Value
+	}
+
+	return tags
+}
+
 // ASGTagsToMap converts a []*autoscaling.TagDescription into a infrav1.Tags.
 func ASGTagsToMap(src []*autoscaling.TagDescription) infrav1.Tags {
 	tags := make(infrav1.Tags, len(src))
@@ -53,6 +53,14 @@ func (ec2Filters) Name(name string) *ec2.Filter {
 	}
 }
 
+// AutoScalingGroup returns a filter based on the Auto Scaling group which launched the instance.
+func (ec2Filters) AutoScalingGroup(name string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("tag:aws:autoscaling:groupName"),
+		Values: aws.StringSlice([]string{name}),
+	}
+}
+
 // ClusterOwned returns a filter using the Cluster API per-cluster tag where
 // the resource is owned
 func (ec2Filters) ClusterOwned(clusterName string) *ec2.Filter {
@@ -34,12 +34,19 @@ const (
 	metricRequestCountKey    = "api_requests_total"
 	metricRequestDurationKey = "api_request_duration_seconds"
 	metricAPICallRetries     = "api_call_retries"
+	metricLookupCacheKey     = "lookup_cache_requests_total"
+	metricSweptSecretsKey    = "bootstrap_secrets_swept_total"
 	metricServiceLabel       = "service"
 	metricRegionLabel        = "region"
 	metricOperationLabel     = "operation"
 	metricControllerLabel    = "controller"
 	metricStatusCodeLabel    = "status_code"
 	metricErrorCodeLabel     = "error_code"
+	metricCacheLabel         = "cache"
+	metricResultLabel        = "result"
+	metricBackendLabel       = "backend"
+	metricNamespaceLabel     = "namespace"
+	metricClusterLabel       = "cluster"
 )
 
 var (
@@ -59,12 +66,38 @@ var (
 		Help:      "Number of retries made against an AWS API",
 		Buckets:   []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
 	}, []string{metricControllerLabel, metricServiceLabel, metricRegionLabel, metricOperationLabel})
+	awsLookupCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricLookupCacheKey,
+		Help:      "Total number of requests to the caches of AWS lookups, by result (hit or miss)",
+	}, []string{metricCacheLabel, metricRegionLabel, metricResultLabel})
+	awsSweptSecrets = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricAWSSubsystem,
+		Name:      metricSweptSecretsKey,
+		Help:      "Total number of orphaned bootstrap secrets and SSM parameters deleted, by backend",
+	}, []string{metricNamespaceLabel, metricClusterLabel, metricBackendLabel})
 )
 
 func init() {
 	metrics.Registry.MustRegister(awsRequestCount)
 	metrics.Registry.MustRegister(awsRequestDurationSeconds)
 	metrics.Registry.MustRegister(awsCallRetries)
+	metrics.Registry.MustRegister(awsLookupCacheRequests)
+	metrics.Registry.MustRegister(awsSweptSecrets)
+}
+
+// RecordSweptSecrets records orphaned bootstrap secrets deleted from the backend of a cluster.
+func RecordSweptSecrets(namespace, cluster, backend string, count int) {
+	awsSweptSecrets.WithLabelValues(namespace, cluster, backend).Add(float64(count))
+}
+
+// RecordLookupCacheRequest records a hit or a miss in a cache of AWS lookups.
+func RecordLookupCacheRequest(cache, region string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	awsLookupCacheRequests.WithLabelValues(cache, region, result).Inc()
 }
 
 func CaptureRequestMetrics(controller string) func(r *request.Request) {
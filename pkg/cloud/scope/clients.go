@@ -27,12 +27,18 @@ import (
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts"
@@ -136,6 +142,36 @@ func NewSSMClient(scopeUser cloud.ScopeUsage, session cloud.Session, target runt
 	return ssmClient
 }
 
+// NewS3Client creates a new S3 API client for a given session
+func NewS3Client(scopeUser cloud.ScopeUsage, session cloud.Session, target runtime.Object) s3iface.S3API {
+	s3Client := s3.New(session.Session())
+	s3Client.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	s3Client.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	s3Client.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+
+	return s3Client
+}
+
+// NewSQSClient creates a new SQS API client for a given session
+func NewSQSClient(scopeUser cloud.ScopeUsage, session cloud.Session, target runtime.Object) sqsiface.SQSAPI {
+	sqsClient := sqs.New(session.Session())
+	sqsClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	sqsClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	sqsClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+
+	return sqsClient
+}
+
+// NewEventBridgeClient creates a new EventBridge API client for a given session
+func NewEventBridgeClient(scopeUser cloud.ScopeUsage, session cloud.Session, target runtime.Object) eventbridgeiface.EventBridgeAPI {
+	eventBridgeClient := eventbridge.New(session.Session())
+	eventBridgeClient.Handlers.Build.PushFrontNamed(getUserAgentHandler())
+	eventBridgeClient.Handlers.CompleteAttempt.PushFront(awsmetrics.CaptureRequestMetrics(scopeUser.ControllerName()))
+	eventBridgeClient.Handlers.Complete.PushBack(recordAWSPermissionsIssue(target))
+
+	return eventBridgeClient
+}
+
 func recordAWSPermissionsIssue(target runtime.Object) func(r *request.Request) {
 	return func(r *request.Request) {
 		if awsErr, ok := r.Error.(awserr.Error); ok {
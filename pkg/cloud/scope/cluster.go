@@ -113,11 +113,24 @@ func (s *ClusterScope) SetSubnets(subnets infrav1.Subnets) {
 // CNIIngressRules returns the CNI spec ingress rules.
 func (s *ClusterScope) CNIIngressRules() infrav1.CNIIngressRules {
 	if s.AWSCluster.Spec.NetworkSpec.CNI != nil {
-		return s.AWSCluster.Spec.NetworkSpec.CNI.CNIIngressRules
+		return s.AWSCluster.Spec.NetworkSpec.CNI.IngressRules()
 	}
 	return infrav1.CNIIngressRules{}
 }
 
+// AdoptedIngressRules returns the existing ingress rules of the given security group when it has been adopted.
+func (s *ClusterScope) AdoptedIngressRules(groupID string) infrav1.IngressRules {
+	if s.AWSCluster.Status.Adoption == nil || !s.AWSCluster.Status.Adoption.Adopted {
+		return nil
+	}
+	for _, resource := range s.AWSCluster.Status.Adoption.Resources {
+		if resource.Kind == "SecurityGroup" && resource.ID == groupID {
+			return resource.IngressRules
+		}
+	}
+	return nil
+}
+
 // SecurityGroups returns the cluster security groups as a map, it creates the map if empty.
 func (s *ClusterScope) SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup {
 	return s.AWSCluster.Status.Network.SecurityGroups
@@ -192,6 +205,10 @@ func (s *ClusterScope) PatchObject() error {
 		}
 	}
 
+	if s.AWSCluster.Spec.Adoption != nil {
+		applicableConditions = append(applicableConditions, infrav1.InfrastructureAdoptedCondition)
+	}
+
 	conditions.SetSummary(s.AWSCluster,
 		conditions.WithConditions(applicableConditions...),
 		conditions.WithStepCounterIf(s.AWSCluster.ObjectMeta.DeletionTimestamp.IsZero()),
@@ -211,6 +228,7 @@ func (s *ClusterScope) PatchObject() error {
 			infrav1.ClusterSecurityGroupsReadyCondition,
 			infrav1.BastionHostReadyCondition,
 			infrav1.LoadBalancerReadyCondition,
+			infrav1.InfrastructureAdoptedCondition,
 		}})
 }
 
@@ -259,11 +277,21 @@ func (s *ClusterScope) Bastion() *infrav1.Bastion {
 	return &s.AWSCluster.Spec.Bastion
 }
 
+// BastionInstance returns the bastion instance in the status of the cluster.
+func (s *ClusterScope) BastionInstance() *infrav1.Instance {
+	return s.AWSCluster.Status.Bastion
+}
+
 // SetBastionInstance sets the bastion instance in the status of the cluster.
 func (s *ClusterScope) SetBastionInstance(instance *infrav1.Instance) {
 	s.AWSCluster.Status.Bastion = instance
 }
 
+// SetSessionManagerTargets sets the instances reachable through Session Manager in the status of the cluster.
+func (s *ClusterScope) SetSessionManagerTargets(targets []infrav1.SessionManagerTarget) {
+	s.AWSCluster.Status.SessionManagerTargets = targets
+}
+
 // SSHKeyName returns the SSH key name to use for instances.
 func (s *ClusterScope) SSHKeyName() *string {
 	return s.AWSCluster.Spec.SSHKeyName
@@ -289,3 +317,23 @@ func (s *ClusterScope) ImageLookupOrg() string {
 func (s *ClusterScope) ImageLookupBaseOS() string {
 	return s.AWSCluster.Spec.ImageLookupBaseOS
 }
+
+// Bucket returns the S3 bucket used to pass bootstrap data to instances, if any.
+func (s *ClusterScope) Bucket() *infrav1.S3Bucket {
+	return s.AWSCluster.Spec.S3Bucket
+}
+
+// InstanceEvents returns the instance event processing configuration of the cluster.
+func (s *ClusterScope) InstanceEvents() *infrav1.InstanceEventsSpec {
+	return s.AWSCluster.Spec.InstanceEvents
+}
+
+// InstanceEventsQueueURL returns the URL of the queue instance events are consumed from.
+func (s *ClusterScope) InstanceEventsQueueURL() string {
+	return s.AWSCluster.Status.InstanceEventsQueueURL
+}
+
+// SetInstanceEventsQueueURL sets the URL of the queue instance events are consumed from.
+func (s *ClusterScope) SetInstanceEventsQueueURL(url string) {
+	s.AWSCluster.Status.InstanceEventsQueueURL = url
+}
@@ -40,9 +40,15 @@ type EC2Scope interface {
 	// Bastion returns the bastion details for the cluster.
 	Bastion() *infrav1.Bastion
 
+	// BastionInstance returns the bastion instance in the status of the cluster.
+	BastionInstance() *infrav1.Instance
+
 	// SetBastionInstance sets the bastion instance in the status of the cluster.
 	SetBastionInstance(instance *infrav1.Instance)
 
+	// SetSessionManagerTargets sets the instances reachable through Session Manager in the status of the cluster.
+	SetSessionManagerTargets(targets []infrav1.SessionManagerTarget)
+
 	// SSHKeyName returns the SSH key name to use for instances.
 	SSHKeyName() *string
 
@@ -54,4 +60,7 @@ type EC2Scope interface {
 
 	// ImageLookupBaseOS returns the base operating system name to use when looking up AMIs
 	ImageLookupBaseOS() string
+
+	// Bucket returns the S3 bucket used to pass bootstrap data to instances, if any.
+	Bucket() *infrav1.S3Bucket
 }
@@ -20,10 +20,12 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/klogr"
 	"k8s.io/utils/pointer"
@@ -193,12 +195,43 @@ func (m *MachineScope) SecureSecretsBackend() infrav1.SecretBackend {
 	return m.AWSMachine.Spec.CloudInit.SecureSecretsBackend
 }
 
+// SecretsKMSKeyID returns the customer managed KMS key with which the secrets holding the userdata
+// are encrypted, if any.
+func (m *MachineScope) SecretsKMSKeyID() string {
+	return m.AWSMachine.Spec.CloudInit.KMSKeyID
+}
+
 // UserDataIsUncompressed returns the computed value of whether or not
 // userdata should be compressed using gzip.
 func (m *MachineScope) UserDataIsUncompressed() bool {
 	return m.AWSMachine.Spec.UncompressedUserData != nil && *m.AWSMachine.Spec.UncompressedUserData
 }
 
+// UseIgnition returns true if the bootstrap data is an Ignition config.
+func (m *MachineScope) UseIgnition() bool {
+	return m.AWSMachine.Spec.UserDataFormat.IsIgnition()
+}
+
+// InlineIgnitionUserData returns true if the Ignition config may be passed as plaintext userdata.
+func (m *MachineScope) InlineIgnitionUserData() bool {
+	return m.AWSMachine.Spec.Ignition.InsecureInlineUserData
+}
+
+// GetBootstrapDataObject returns the key of the object holding the Ignition config of the AWSMachine.
+func (m *MachineScope) GetBootstrapDataObject() string {
+	return m.AWSMachine.Status.BootstrapDataObject
+}
+
+// SetBootstrapDataObject sets the key of the object holding the Ignition config of the AWSMachine.
+func (m *MachineScope) SetBootstrapDataObject(key string) {
+	m.AWSMachine.Status.BootstrapDataObject = key
+}
+
+// IsWindows returns true if the AWSMachine runs a Windows instance.
+func (m *MachineScope) IsWindows() bool {
+	return m.AWSMachine.Spec.Platform.IsWindows()
+}
+
 // GetSecretPrefix returns the prefix for the secrets belonging
 // to the AWSMachine in AWS Secrets Manager
 func (m *MachineScope) GetSecretPrefix() string {
@@ -234,6 +267,105 @@ func (m *MachineScope) SetAddresses(addrs []clusterv1.MachineAddress) {
 	m.AWSMachine.Status.Addresses = addrs
 }
 
+// SetNetworkInterfaces sets the AWSMachine secondary network interfaces status.
+func (m *MachineScope) SetNetworkInterfaces(interfaces []infrav1.NetworkInterfaceStatus) {
+	m.AWSMachine.Status.NetworkInterfaces = interfaces
+}
+
+// SetScheduledEvents sets the events scheduled for the AWSMachine's instance.
+func (m *MachineScope) SetScheduledEvents(events []infrav1.InstanceScheduledEvent) {
+	m.AWSMachine.Status.ScheduledEvents = events
+}
+
+// SetImage sets the AMI resolved for the AWSMachine's instance.
+func (m *MachineScope) SetImage(image *infrav1.ResolvedImage) {
+	m.AWSMachine.Status.Image = image
+}
+
+// InstanceIDIndex is the name of the field index of AWSMachines by the ID of their instance.
+const InstanceIDIndex = "spec.providerID.instanceID"
+
+// InstanceID indexes AWSMachines by the ID of the instance in their provider ID, for InstanceIDIndex.
+func InstanceID(o runtime.Object) []string {
+	machine, ok := o.(*infrav1.AWSMachine)
+	if !ok || machine.Spec.ProviderID == nil {
+		return nil
+	}
+	providerID, err := noderefutil.NewProviderID(*machine.Spec.ProviderID)
+	if err != nil {
+		return nil
+	}
+	return []string{providerID.ID()}
+}
+
+// TemplateClonedFromIndex is the name of the field index of AWSMachines by the template they were cloned from.
+const TemplateClonedFromIndex = "metadata.annotations.templateClonedFrom"
+
+// TemplateClonedFrom indexes AWSMachines by the template they were cloned from, for TemplateClonedFromIndex.
+func TemplateClonedFrom(o runtime.Object) []string {
+	machine, ok := o.(*infrav1.AWSMachine)
+	if !ok {
+		return nil
+	}
+	templateName, ok := machine.Annotations[clusterv1.TemplateClonedFromNameAnnotation]
+	if !ok {
+		return nil
+	}
+	return []string{templateClonedFromKey(templateName, machine.Annotations[clusterv1.TemplateClonedFromGroupKindAnnotation])}
+}
+
+func templateClonedFromKey(templateName, templateGroupKind string) string {
+	return templateGroupKind + "/" + templateName
+}
+
+// TemplateImages returns the AMIs resolved for the other AWSMachines cloned from the same template as the AWSMachine,
+// the most recently resolved first.
+func (m *MachineScope) TemplateImages() ([]*infrav1.ResolvedImage, error) {
+	templateName, ok := m.AWSMachine.Annotations[clusterv1.TemplateClonedFromNameAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	templateGroupKind := m.AWSMachine.Annotations[clusterv1.TemplateClonedFromGroupKindAnnotation]
+
+	machines := &infrav1.AWSMachineList{}
+	if err := m.client.List(context.TODO(), machines, client.InNamespace(m.Namespace()),
+		client.MatchingLabels{clusterv1.ClusterLabelName: m.Cluster.Name},
+		client.MatchingFields{TemplateClonedFromIndex: templateClonedFromKey(templateName, templateGroupKind)}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list AWSMachines cloned from %s %s/%s", templateGroupKind, m.Namespace(), templateName)
+	}
+
+	siblings := make([]*infrav1.AWSMachine, 0, len(machines.Items))
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if machine.Name == m.Name() || machine.Status.Image == nil {
+			continue
+		}
+		if machine.Annotations[clusterv1.TemplateClonedFromNameAnnotation] != templateName ||
+			machine.Annotations[clusterv1.TemplateClonedFromGroupKindAnnotation] != templateGroupKind {
+			continue
+		}
+		siblings = append(siblings, machine)
+	}
+
+	// Images resolved before their resolution time was recorded come last, ordered by machine name.
+	sort.Slice(siblings, func(i, j int) bool {
+		a, b := siblings[i].Status.Image.ResolvedAt, siblings[j].Status.Image.ResolvedAt
+		switch {
+		case a != nil && b != nil && !a.Equal(b):
+			return b.Before(a)
+		case (a == nil) != (b == nil):
+			return a != nil
+		}
+		return siblings[i].Name < siblings[j].Name
+	})
+
+	images := make([]*infrav1.ResolvedImage, 0, len(siblings))
+	for _, machine := range siblings {
+		images = append(images, machine.Status.Image)
+	}
+	return images, nil
+}
+
 // GetBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName as base64.
 func (m *MachineScope) GetBootstrapData() (string, error) {
 	value, err := m.GetRawBootstrapData()
@@ -276,6 +408,10 @@ func (m *MachineScope) PatchObject() error {
 		applicableConditions = append(applicableConditions, infrav1.ELBAttachedCondition)
 	}
 
+	if len(m.AWSMachine.Spec.SecondaryNetworkInterfaces) > 0 {
+		applicableConditions = append(applicableConditions, infrav1.NetworkInterfacesReadyCondition)
+	}
+
 	conditions.SetSummary(m.AWSMachine,
 		conditions.WithConditions(applicableConditions...),
 		conditions.WithStepCounterIf(m.AWSMachine.ObjectMeta.DeletionTimestamp.IsZero()),
@@ -290,6 +426,13 @@ func (m *MachineScope) PatchObject() error {
 			infrav1.InstanceReadyCondition,
 			infrav1.SecurityGroupsReadyCondition,
 			infrav1.ELBAttachedCondition,
+			infrav1.NetworkInterfacesReadyCondition,
+			infrav1.VolumesReadyCondition,
+			infrav1.InstanceTypeReadyCondition,
+			infrav1.SystemStatusCheckPassedCondition,
+			infrav1.InstanceStatusCheckPassedCondition,
+			infrav1.EBSStatusCheckPassedCondition,
+			infrav1.NoScheduledEventsCondition,
 		}})
 }
 
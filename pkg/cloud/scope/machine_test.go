@@ -224,3 +224,33 @@ func TestSetProviderID(t *testing.T) {
 		t.Fatalf("Expected providerID %s, got %s", expectedProviderID, providerID)
 	}
 }
+
+func TestTemplateClonedFrom(t *testing.T) {
+	machine := newAWSMachine("my-cluster", "my-machine-0")
+	if keys := TemplateClonedFrom(machine); len(keys) != 0 {
+		t.Fatalf("Expected no index keys for a machine which wasn't cloned, got %v", keys)
+	}
+
+	machine.Annotations = map[string]string{
+		clusterv1.TemplateClonedFromNameAnnotation:      "workers-v1",
+		clusterv1.TemplateClonedFromGroupKindAnnotation: "AWSMachineTemplate.infrastructure.cluster.x-k8s.io",
+	}
+	keys := TemplateClonedFrom(machine)
+	expectedKey := "AWSMachineTemplate.infrastructure.cluster.x-k8s.io/workers-v1"
+	if len(keys) != 1 || keys[0] != expectedKey {
+		t.Fatalf("Expected index key %s, got %v", expectedKey, keys)
+	}
+}
+
+func TestInstanceID(t *testing.T) {
+	machine := newAWSMachine("my-cluster", "my-machine-0")
+	if keys := InstanceID(machine); len(keys) != 0 {
+		t.Fatalf("Expected no index keys for a machine without a provider ID, got %v", keys)
+	}
+
+	machine.Spec.ProviderID = pointer.StringPtr("aws:///us-east-1a/i-1234567890abcdef0")
+	keys := InstanceID(machine)
+	if len(keys) != 1 || keys[0] != "i-1234567890abcdef0" {
+		t.Fatalf("Expected index key i-1234567890abcdef0, got %v", keys)
+	}
+}
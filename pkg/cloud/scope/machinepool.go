@@ -115,6 +115,11 @@ func (m *MachinePoolScope) Namespace() string {
 	return m.AWSMachinePool.Namespace
 }
 
+// Role returns the machine role. Machine pools only run worker nodes.
+func (m *MachinePoolScope) Role() string {
+	return "node"
+}
+
 // GetRawBootstrapData returns the bootstrap data from the secret in the Machine's bootstrap.dataSecretName.
 // todo(rudoi): stolen from MachinePool - any way to reuse?
 func (m *MachinePoolScope) GetRawBootstrapData() ([]byte, error) {
@@ -184,6 +189,11 @@ func (m *MachinePoolScope) SetFailureReason(v capierrors.MachineStatusError) {
 	m.AWSMachinePool.Status.FailureReason = &v
 }
 
+// SetImage sets the AMI resolved for the AWSMachinePool's launch template.
+func (m *MachinePoolScope) SetImage(image *infrav1.ResolvedImage) {
+	m.AWSMachinePool.Status.Image = image
+}
+
 // HasFailed returns true when the AWSMachinePool's Failure reason or Failure message is populated
 func (m *MachinePoolScope) HasFailed() bool {
 	return m.AWSMachinePool.Status.FailureReason != nil || m.AWSMachinePool.Status.FailureMessage != nil
@@ -207,3 +217,61 @@ func (m *MachinePoolScope) SetASGStatus(v expinfrav1.ASGStatus) {
 func (m *MachinePoolScope) IsEKSManaged() bool {
 	return m.InfraCluster.InfraCluster().GetObjectKind().GroupVersionKind().Kind == "AWSManagedControlPlane"
 }
+
+// UseIgnition returns true if the bootstrap data is an Ignition config.
+func (m *MachinePoolScope) UseIgnition() bool {
+	return m.AWSMachinePool.Spec.AWSLaunchTemplate.UserDataFormat.IsIgnition()
+}
+
+// InlineIgnitionUserData returns true if the Ignition config may be passed as plaintext userdata.
+func (m *MachinePoolScope) InlineIgnitionUserData() bool {
+	return m.AWSMachinePool.Spec.AWSLaunchTemplate.Ignition.InsecureInlineUserData
+}
+
+// IsWindows returns true if the AWSMachinePool runs Windows instances.
+func (m *MachinePoolScope) IsWindows() bool {
+	return m.AWSMachinePool.Spec.AWSLaunchTemplate.Platform.IsWindows()
+}
+
+// UseSecretsManager returns the computed value of whether or not
+// userdata should be stored using AWS Secrets Manager.
+func (m *MachinePoolScope) UseSecretsManager() bool {
+	return !m.AWSMachinePool.Spec.CloudInit.InsecureSkipSecretsManager
+}
+
+// SecureSecretsBackend returns the chosen secret backend. Machine pools created before
+// it could be chosen default to AWS Secrets Manager.
+func (m *MachinePoolScope) SecureSecretsBackend() infrav1.SecretBackend {
+	if m.AWSMachinePool.Spec.CloudInit.SecureSecretsBackend == "" {
+		return infrav1.SecretBackendSecretsManager
+	}
+	return m.AWSMachinePool.Spec.CloudInit.SecureSecretsBackend
+}
+
+// SecretsKMSKeyID returns the customer managed KMS key with which the secrets holding the userdata
+// are encrypted, if any.
+func (m *MachinePoolScope) SecretsKMSKeyID() string {
+	return m.AWSMachinePool.Spec.CloudInit.KMSKeyID
+}
+
+// GetSecretPrefix returns the prefix for the secrets holding the userdata
+// of the AWSMachinePool's launch template.
+func (m *MachinePoolScope) GetSecretPrefix() string {
+	return m.AWSMachinePool.Spec.CloudInit.SecretPrefix
+}
+
+// SetSecretPrefix sets the prefix for the secrets holding the userdata
+// of the AWSMachinePool's launch template.
+func (m *MachinePoolScope) SetSecretPrefix(value string) {
+	m.AWSMachinePool.Spec.CloudInit.SecretPrefix = value
+}
+
+// GetSecretCount returns the number of secrets making up the complete userdata.
+func (m *MachinePoolScope) GetSecretCount() int32 {
+	return m.AWSMachinePool.Spec.CloudInit.SecretCount
+}
+
+// SetSecretCount sets the number of secrets making up the complete userdata.
+func (m *MachinePoolScope) SetSecretCount(i int32) {
+	m.AWSMachinePool.Spec.CloudInit.SecretCount = i
+}
@@ -122,11 +122,16 @@ func (s *ManagedControlPlaneScope) SetSubnets(subnets infrav1.Subnets) {
 // CNIIngressRules returns the CNI spec ingress rules.
 func (s *ManagedControlPlaneScope) CNIIngressRules() infrav1.CNIIngressRules {
 	if s.ControlPlane.Spec.NetworkSpec.CNI != nil {
-		return s.ControlPlane.Spec.NetworkSpec.CNI.CNIIngressRules
+		return s.ControlPlane.Spec.NetworkSpec.CNI.IngressRules()
 	}
 	return infrav1.CNIIngressRules{}
 }
 
+// AdoptedIngressRules returns nil, as the security groups of a managed control plane can't be adopted.
+func (s *ManagedControlPlaneScope) AdoptedIngressRules(groupID string) infrav1.IngressRules {
+	return nil
+}
+
 // SecurityGroups returns the control plane security groups as a map, it creates the map if empty.
 func (s *ManagedControlPlaneScope) SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.SecurityGroup {
 	return s.ControlPlane.Status.Network.SecurityGroups
@@ -213,11 +218,19 @@ func (s *ManagedControlPlaneScope) Bastion() *infrav1.Bastion {
 	return &s.ControlPlane.Spec.Bastion
 }
 
+// BastionInstance returns the bastion instance in the status of the cluster.
+func (s *ManagedControlPlaneScope) BastionInstance() *infrav1.Instance {
+	return s.ControlPlane.Status.Bastion
+}
+
 // SetBastionInstance sets the bastion instance in the status of the cluster.
 func (s *ManagedControlPlaneScope) SetBastionInstance(instance *infrav1.Instance) {
 	s.ControlPlane.Status.Bastion = instance
 }
 
+// SetSessionManagerTargets does nothing, as managed control planes have no instances to reach.
+func (s *ManagedControlPlaneScope) SetSessionManagerTargets(targets []infrav1.SessionManagerTarget) {}
+
 // SSHKeyName returns the SSH key name to use for instances.
 func (s *ManagedControlPlaneScope) SSHKeyName() *string {
 	return s.ControlPlane.Spec.SSHKeyName
@@ -269,6 +282,11 @@ func (s *ManagedControlPlaneScope) ImageLookupBaseOS() string {
 	return s.ControlPlane.Spec.ImageLookupBaseOS
 }
 
+// Bucket returns the S3 bucket used to pass bootstrap data to instances. EKS clusters don't have one.
+func (s *ManagedControlPlaneScope) Bucket() *infrav1.S3Bucket {
+	return nil
+}
+
 // IAMAuthConfig returns the IAM authenticator config. The returned value will never be nil.
 func (s *ManagedControlPlaneScope) IAMAuthConfig() *ekscontrolplanev1.IAMAuthenticatorConfig {
 	if s.ControlPlane.Spec.IAMAuthenticatorConfig == nil {
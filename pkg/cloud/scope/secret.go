@@ -0,0 +1,38 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+// SecretScope is the interface for the scope of the machines or machine pools whose userdata
+// is stored in a secure secrets backend.
+type SecretScope interface {
+	// Name returns the name of the machine or machine pool.
+	Name() string
+	// Role returns the role of the instances, either control-plane or node.
+	Role() string
+	// AdditionalTags returns the tags to apply to the secrets.
+	AdditionalTags() infrav1.Tags
+	// GetSecretPrefix returns the prefix of the secrets holding the userdata.
+	GetSecretPrefix() string
+	// GetSecretCount returns the number of secrets holding the userdata.
+	GetSecretCount() int32
+	// SecretsKMSKeyID returns the customer managed KMS key with which the secrets are encrypted, if any.
+	SecretsKMSKeyID() string
+}
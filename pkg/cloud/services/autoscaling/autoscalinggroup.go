@@ -108,6 +108,8 @@ func (s *Service) ASGIfExists(name *string) (*expinfrav1.AutoScalingGroup, error
 	case err != nil:
 		record.Eventf(s.scope.InfraCluster(), "FailedDescribeAutoScalingGroups", "failed to describe ASG %q: %v", *name, err)
 		return nil, errors.Wrapf(err, "failed to describe AutoScaling Group: %q", *name)
+	case len(out.AutoScalingGroups) == 0:
+		return nil, nil
 	}
 	//TODO: double check if you're handling nil vals
 	return s.SDKToAutoScalingGroup(out.AutoScalingGroups[0])
@@ -266,6 +268,50 @@ func (s *Service) DeleteASG(name string) error {
 	return nil
 }
 
+// CreateSingleInstanceASG runs an autoscaling group keeping one instance of the latest version of a
+// launch template running in any of the subnets.
+func (s *Service) CreateSingleInstanceASG(name, launchTemplateID string, subnetIDs []string, tags infrav1.Tags) error {
+	input := &expinfrav1.AutoScalingGroup{
+		Name:            name,
+		MaxSize:         1,
+		MinSize:         1,
+		DesiredCapacity: aws.Int32(1),
+		Subnets:         subnetIDs,
+		Tags:            tags,
+	}
+
+	if err := s.runPool(input, launchTemplateID); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateAutoScalingGroup", "Failed to create ASG %q: %v", name, err)
+		return err
+	}
+
+	s.scope.V(2).Info("Created ASG", "name", name)
+	return nil
+}
+
+// StartInstanceRefresh replaces the instances of an autoscaling group by instances of the latest version of
+// its launch template. Instances are terminated before being replaced, as the group may have a single one.
+func (s *Service) StartInstanceRefresh(name string) error {
+	input := &autoscaling.StartInstanceRefreshInput{
+		AutoScalingGroupName: aws.String(name),
+		Preferences: &autoscaling.RefreshPreferences{
+			MinHealthyPercentage: aws.Int64(0),
+		},
+	}
+
+	if _, err := s.ASGClient.StartInstanceRefresh(input); err != nil {
+		if code, _ := awserrors.Code(err); code == autoscaling.ErrCodeInstanceRefreshInProgressFault {
+			s.scope.V(4).Info("Instance refresh already in progress", "name", name)
+			return nil
+		}
+		record.Warnf(s.scope.InfraCluster(), "FailedStartInstanceRefresh", "Failed to start instance refresh of ASG %q: %v", name, err)
+		return errors.Wrapf(err, "failed to start instance refresh of ASG %q", name)
+	}
+
+	s.scope.V(2).Info("Started instance refresh", "name", name)
+	return nil
+}
+
 func (s *Service) UpdateASG(scope *scope.MachinePoolScope) error {
 	subnetIDs := make([]string, len(scope.AWSMachinePool.Spec.Subnets))
 	for i, v := range scope.AWSMachinePool.Spec.Subnets {
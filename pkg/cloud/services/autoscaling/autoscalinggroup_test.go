@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/golang/mock/gomock"
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
@@ -208,3 +209,72 @@ func TestService_SDKToAutoScalingGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ASGIfExists(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster:    &clusterv1.Cluster{},
+		AWSCluster: &infrav1.AWSCluster{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	// Groups which don't exist are not reported as errors.
+	asgMock.EXPECT().DescribeAutoScalingGroups(gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: aws.StringSlice([]string{"missing"}),
+	})).Return(&autoscaling.DescribeAutoScalingGroupsOutput{}, nil)
+
+	s := NewService(cs)
+	s.ASGClient = asgMock
+
+	group, err := s.ASGIfExists(aws.String("missing"))
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if group != nil {
+		t.Fatalf("did not expect anything but got something: %+v", group)
+	}
+}
+
+func TestService_StartInstanceRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster:    &clusterv1.Cluster{},
+		AWSCluster: &infrav1.AWSCluster{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	input := &autoscaling.StartInstanceRefreshInput{
+		AutoScalingGroupName: aws.String("bastion"),
+		Preferences: &autoscaling.RefreshPreferences{
+			MinHealthyPercentage: aws.Int64(0),
+		},
+	}
+	asgMock.EXPECT().StartInstanceRefresh(gomock.Eq(input)).Return(&autoscaling.StartInstanceRefreshOutput{}, nil)
+	asgMock.EXPECT().StartInstanceRefresh(gomock.Eq(input)).
+		Return(nil, awserr.New(autoscaling.ErrCodeInstanceRefreshInProgressFault, "in progress", nil))
+	asgMock.EXPECT().StartInstanceRefresh(gomock.Eq(input)).
+		Return(nil, awserr.New(autoscaling.ErrCodeResourceContentionFault, "contention", nil))
+
+	s := NewService(cs)
+	s.ASGClient = asgMock
+
+	if err := s.StartInstanceRefresh("bastion"); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if err := s.StartInstanceRefresh("bastion"); err != nil {
+		t.Fatalf("did not expect error when a refresh is in progress: %v", err)
+	}
+	if err := s.StartInstanceRefresh("bastion"); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+)
+
+// AdoptInstance takes ownership of an existing instance which wasn't created by this provider.
+// The instance must run in a subnet of the cluster, with the core and additional security groups of
+// the machine attached and no others, and must not belong to another cluster or machine. It is then tagged the same way as
+// the instances created by CreateInstance, so that it is found and managed like any other instance.
+func (s *Service) AdoptInstance(scope *scope.MachineScope, instanceID string) (*infrav1.Instance, error) {
+	s.scope.V(2).Info("Adopting an existing instance for a machine", "instance-id", instanceID)
+
+	instance, err := s.InstanceIfExists(aws.String(instanceID))
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, errors.Errorf("instance %q does not exist", instanceID)
+	}
+
+	if err := s.validateAdoptedInstance(scope, instance); err != nil {
+		return nil, errors.Wrapf(err, "cannot adopt instance %q", instanceID)
+	}
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(scope.Name()),
+		Role:        aws.String(scope.Role()),
+		Additional:  scope.AdditionalTags(),
+	}.WithCloudProvider(s.scope.Name()).WithMachineName(scope.Machine))
+
+	if err := s.UpdateResourceTags(aws.String(instanceID), tags, nil); err != nil {
+		return nil, errors.Wrapf(err, "failed to tag adopted instance %q", instanceID)
+	}
+
+	if instance.Tags == nil {
+		instance.Tags = make(map[string]string, len(tags))
+	}
+	for key, value := range tags {
+		instance.Tags[key] = value
+	}
+
+	s.scope.V(2).Info("Adopted instance", "instance-id", instanceID)
+	return instance, nil
+}
+
+// validateAdoptedInstance checks the instance can be managed as the instance of the machine.
+func (s *Service) validateAdoptedInstance(scope *scope.MachineScope, instance *infrav1.Instance) error {
+	switch instance.State {
+	case infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated:
+		return errors.Errorf("instance is %s", instance.State)
+	}
+
+	if s.scope.Subnets().FindByID(instance.SubnetID) == nil {
+		return errors.Errorf("subnet %q is not a subnet of the cluster in VPC %q", instance.SubnetID, s.scope.VPC().ID)
+	}
+	if subnet := scope.AWSMachine.Spec.Subnet; subnet != nil && subnet.ID != nil && *subnet.ID != instance.SubnetID {
+		return errors.Errorf("instance runs in subnet %q rather than subnet %q of the machine", instance.SubnetID, *subnet.ID)
+	}
+
+	coreSecurityGroups, err := s.GetCoreSecurityGroups(scope)
+	if err != nil {
+		return err
+	}
+	attached := make(map[string]bool, len(instance.SecurityGroupIDs))
+	for _, id := range instance.SecurityGroupIDs {
+		attached[id] = true
+	}
+	var missing []string
+	for _, id := range coreSecurityGroups {
+		if !attached[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("security groups %s of the cluster are not attached", strings.Join(missing, ", "))
+	}
+
+	// Security groups which are neither core nor additional would be detached once the instance is managed.
+	expected := sets.NewString(coreSecurityGroups...)
+	for _, ref := range scope.AWSMachine.Spec.AdditionalSecurityGroups {
+		if ref.ID != nil {
+			expected.Insert(*ref.ID)
+		}
+	}
+	if extra := sets.NewString(instance.SecurityGroupIDs...).Difference(expected); extra.Len() > 0 {
+		return errors.Errorf("security groups %s are attached but not listed in the additional security groups of the machine", strings.Join(extra.List(), ", "))
+	}
+
+	for key := range instance.Tags {
+		if cluster := strings.TrimPrefix(key, infrav1.NameAWSProviderOwned); cluster != key && cluster != s.scope.Name() {
+			return errors.Errorf("instance belongs to cluster %q", cluster)
+		}
+	}
+	machineName := types.NamespacedName{Namespace: scope.Machine.Namespace, Name: scope.Machine.Name}.String()
+	if owner, ok := instance.Tags[infrav1.MachineNameTagKey]; ok && owner != machineName {
+		return errors.Errorf("instance belongs to machine %q", owner)
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+)
+
+func TestAdoptInstance(t *testing.T) {
+	existingInstance := func() *ec2.Instance {
+		return &ec2.Instance{
+			InstanceId:   aws.String("i-0123456789abcdef0"),
+			InstanceType: aws.String("m5.large"),
+			SubnetId:     aws.String("subnet-1"),
+			ImageId:      aws.String("ami-1"),
+			State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+			Placement:    &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+			SecurityGroups: []*ec2.GroupIdentifier{
+				{GroupId: aws.String("sg-node")},
+				{GroupId: aws.String("sg-lb")},
+				{GroupId: aws.String("sg-kops")},
+			},
+			Tags: []*ec2.Tag{
+				{Key: aws.String("Name"), Value: aws.String("nodes.kops.example.com")},
+				{Key: aws.String("kubernetes.io/cluster/kops.example.com"), Value: aws.String("owned")},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                     string
+		instance                 func(i *ec2.Instance)
+		subnetID                 *string
+		additionalSecurityGroups []string
+		expectTags               bool
+		expectError              bool
+	}{
+		{
+			name:                     "adopts and tags an instance of the cluster",
+			instance:                 func(i *ec2.Instance) {},
+			additionalSecurityGroups: []string{"sg-kops"},
+			expectTags:               true,
+		},
+		{
+			name:                     "adopts an instance in the subnet of the machine",
+			instance:                 func(i *ec2.Instance) {},
+			subnetID:                 aws.String("subnet-1"),
+			additionalSecurityGroups: []string{"sg-kops"},
+			expectTags:               true,
+		},
+		{
+			name: "adopts an instance with only the core security groups",
+			instance: func(i *ec2.Instance) {
+				i.SecurityGroups = i.SecurityGroups[:2]
+			},
+			expectTags: true,
+		},
+		{
+			name:        "rejects an instance with security groups which aren't additional security groups of the machine",
+			instance:    func(i *ec2.Instance) {},
+			expectError: true,
+		},
+		{
+			name:        "rejects an instance in another subnet than the one of the machine",
+			instance:    func(i *ec2.Instance) {},
+			subnetID:    aws.String("subnet-2"),
+			expectError: true,
+		},
+		{
+			name: "rejects an instance outside of the cluster subnets",
+			instance: func(i *ec2.Instance) {
+				i.SubnetId = aws.String("subnet-other-vpc")
+			},
+			expectError: true,
+		},
+		{
+			name: "rejects an instance without the core security groups",
+			instance: func(i *ec2.Instance) {
+				i.SecurityGroups = []*ec2.GroupIdentifier{{GroupId: aws.String("sg-kops")}}
+			},
+			expectError: true,
+		},
+		{
+			name: "rejects a terminated instance",
+			instance: func(i *ec2.Instance) {
+				i.State.Name = aws.String(ec2.InstanceStateNameTerminated)
+			},
+			expectError: true,
+		},
+		{
+			name: "rejects an instance of another cluster",
+			instance: func(i *ec2.Instance) {
+				i.Tags = append(i.Tags, &ec2.Tag{Key: aws.String(infrav1.ClusterTagKey("other-cluster")), Value: aws.String("owned")})
+			},
+			expectError: true,
+		},
+		{
+			name: "rejects an instance of another machine",
+			instance: func(i *ec2.Instance) {
+				i.Tags = append(i.Tags, &ec2.Tag{Key: aws.String(infrav1.MachineNameTagKey), Value: aws.String("default/other")})
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s, machineScope := newMachineTestService(g, ec2Mock)
+			awsCluster := machineScope.InfraCluster.(*scope.ClusterScope).AWSCluster
+			awsCluster.Spec.NetworkSpec.VPC.ID = "vpc-1"
+			awsCluster.Spec.NetworkSpec.Subnets = infrav1.Subnets{{ID: "subnet-1"}, {ID: "subnet-2"}}
+			awsCluster.Status.Network.SecurityGroups = map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+				infrav1.SecurityGroupNode: {ID: "sg-node"},
+				infrav1.SecurityGroupLB:   {ID: "sg-lb"},
+			}
+			if tc.subnetID != nil {
+				machineScope.AWSMachine.Spec.Subnet = &infrav1.AWSResourceReference{ID: tc.subnetID}
+			}
+			for _, id := range tc.additionalSecurityGroups {
+				machineScope.AWSMachine.Spec.AdditionalSecurityGroups = append(machineScope.AWSMachine.Spec.AdditionalSecurityGroups, infrav1.AWSResourceReference{ID: aws.String(id)})
+			}
+
+			instance := existingInstance()
+			tc.instance(instance)
+			ec2Mock.EXPECT().DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{"i-0123456789abcdef0"})}).
+				Return(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{instance}}}}, nil)
+			if tc.expectTags {
+				ec2Mock.EXPECT().CreateTags(gomock.Any()).
+					DoAndReturn(func(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+						tags := map[string]string{}
+						for _, tag := range input.Tags {
+							tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+						}
+						g.Expect(aws.StringValueSlice(input.Resources)).To(Equal([]string{"i-0123456789abcdef0"}))
+						g.Expect(tags).To(HaveKeyWithValue(infrav1.ClusterTagKey("test-cluster"), "owned"))
+						g.Expect(tags).To(HaveKeyWithValue(infrav1.NameAWSClusterAPIRole, "node"))
+						g.Expect(tags).To(HaveKeyWithValue("Name", "aws-test"))
+						g.Expect(tags).To(HaveKeyWithValue(infrav1.MachineNameTagKey, "default/test"))
+						return &ec2.CreateTagsOutput{}, nil
+					})
+			}
+
+			adopted, err := s.AdoptInstance(machineScope, "i-0123456789abcdef0")
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(adopted.ID).To(Equal("i-0123456789abcdef0"))
+			g.Expect(adopted.AvailabilityZone).To(Equal("us-east-1a"))
+			g.Expect(adopted.Tags).To(HaveKeyWithValue(infrav1.ClusterTagKey("test-cluster"), "owned"))
+		})
+	}
+
+	t.Run("fails for an instance which doesn't exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+		s, machineScope := newMachineTestService(g, ec2Mock)
+		ec2Mock.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{}, nil)
+
+		_, err := s.AdoptInstance(machineScope, "i-0123456789abcdef0")
+		g.Expect(err).NotTo(BeNil())
+	})
+}
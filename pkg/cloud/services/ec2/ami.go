@@ -29,6 +29,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
 )
 
@@ -41,6 +43,10 @@ const (
 	// when looking up machine AMIs
 	defaultMachineAMILookupBaseOS = "ubuntu-18.04"
 
+	// defaultWindowsMachineAMILookupBaseOS is the default base operating system to use
+	// when looking up Windows machine AMIs
+	defaultWindowsMachineAMILookupBaseOS = "windows-2019"
+
 	// defaultAmiNameFormat is defined in the build/ directory of this project.
 	// The pattern is:
 	// 1. the string value `capa-ami-`
@@ -54,16 +60,85 @@ const (
 
 	// EKS AMI ID SSM Parameter name
 	eksAmiSSMParameterFormat = "/aws/service/eks/optimized-ami/%s/amazon-linux-2/recommended/image_id"
+
+	// EKS arm64 AMI ID SSM Parameter name
+	eksARM64AmiSSMParameterFormat = "/aws/service/eks/optimized-ami/%s/amazon-linux-2-arm64/recommended/image_id"
+
+	// EKS Windows AMI ID SSM Parameter name
+	eksWindowsAmiSSMParameterFormat = "/aws/service/ami-windows-latest/Windows_Server-2019-English-Core-EKS_Optimized-%s/image_id"
+
+	// Ubuntu arm64 AMI ID SSM Parameter name, used for arm64 bastion hosts
+	ubuntuARM64AmiSSMParameterName = "/aws/service/canonical/ubuntu/server/18.04/stable/current/arm64/hvm/ebs-gp2/ami-id"
 )
 
 // AMILookup contains the parameters used to template AMI names used for lookup.
 type AMILookup struct {
 	BaseOS     string
 	K8sVersion string
+	Arch       string
+}
+
+// imageLookup contains the parameters used to resolve an AMI which isn't referenced by ID.
+type imageLookup struct {
+	SSMParameter      *string
+	EKS               bool
+	Format            string
+	Org               string
+	BaseOS            string
+	Architecture      string
+	Platform          infrav1.Platform
+	KubernetesVersion string
+}
+
+// source describes what the AMI is resolved from. AMIs resolved from the same source are reused.
+func (l imageLookup) source() string {
+	var source string
+	switch {
+	case l.SSMParameter != nil:
+		return fmt.Sprintf("ssm:%s", *l.SSMParameter)
+	case l.EKS:
+		source = fmt.Sprintf("eks:version=%s,arch=%s", l.KubernetesVersion, l.Architecture)
+	default:
+		source = fmt.Sprintf("lookup:format=%s,org=%s,baseOS=%s,arch=%s,version=%s", l.Format, l.Org, l.BaseOS, l.Architecture, l.KubernetesVersion)
+	}
+	// The platform is only part of the source of Windows images, so that Linux images pinned
+	// before platforms were introduced are still reused.
+	if l.Platform.IsWindows() {
+		source += fmt.Sprintf(",platform=%s", l.Platform)
+	}
+	return source
+}
+
+// resolveImage resolves the AMI for lookup. The first pinned AMI resolved from the same source is
+// returned instead, so that machines keep using the same image when newer ones are published.
+func (s *Service) resolveImage(lookup imageLookup, pinned ...*infrav1.ResolvedImage) (*infrav1.ResolvedImage, error) {
+	source := lookup.source()
+	for _, image := range pinned {
+		if image != nil && image.Source == source {
+			return image, nil
+		}
+	}
+
+	var id string
+	var err error
+	switch {
+	case lookup.SSMParameter != nil:
+		id, err = s.ssmAMILookup(*lookup.SSMParameter)
+	case lookup.EKS:
+		id, err = s.eksAMILookup(lookup.KubernetesVersion, lookup.Architecture, lookup.Platform)
+	default:
+		id, err = s.defaultAMILookup(lookup.Format, lookup.Org, lookup.BaseOS, lookup.Architecture, lookup.Platform, lookup.KubernetesVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.scope.V(2).Info("Resolved AMI", "ami-id", id, "source", source)
+	return &infrav1.ResolvedImage{ID: id, Source: source, ResolvedAt: &metav1.Time{Time: time.Now()}}, nil
 }
 
-func amiName(amiNameFormat, baseOS, kubernetesVersion string) (string, error) {
-	amiNameParameters := AMILookup{baseOS, strings.TrimPrefix(kubernetesVersion, "v")}
+func amiName(amiNameFormat, baseOS, architecture, kubernetesVersion string) (string, error) {
+	amiNameParameters := AMILookup{baseOS, strings.TrimPrefix(kubernetesVersion, "v"), architecture}
 	// revert to default if not specified
 	if amiNameFormat == "" {
 		amiNameFormat = defaultAmiNameFormat
@@ -81,7 +156,7 @@ func amiName(amiNameFormat, baseOS, kubernetesVersion string) (string, error) {
 }
 
 // defaultAMILookup returns the default AMI based on region
-func (s *Service) defaultAMILookup(amiNameFormat, ownerID, baseOS, kubernetesVersion string) (string, error) {
+func (s *Service) defaultAMILookup(amiNameFormat, ownerID, baseOS, architecture string, platform infrav1.Platform, kubernetesVersion string) (string, error) {
 	if amiNameFormat == "" {
 		amiNameFormat = defaultAmiNameFormat
 	}
@@ -90,11 +165,20 @@ func (s *Service) defaultAMILookup(amiNameFormat, ownerID, baseOS, kubernetesVer
 	}
 	if baseOS == "" {
 		baseOS = defaultMachineAMILookupBaseOS
+		if platform.IsWindows() {
+			baseOS = defaultWindowsMachineAMILookupBaseOS
+		}
 	}
-	amiName, err := amiName(amiNameFormat, baseOS, kubernetesVersion)
+	if architecture == "" {
+		architecture = ec2.ArchitectureValuesX8664
+	}
+	amiName, err := amiName(amiNameFormat, baseOS, architecture, kubernetesVersion)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to process ami format: %q", amiNameFormat)
 	}
+	if id, ok := imageLookupCache.get(s.scope.Region(), s.lookupCacheIdentity(), ownerID, amiName, architecture, string(platform)); ok {
+		return id.(string), nil
+	}
 	describeImageInput := &ec2.DescribeImagesInput{
 		Filters: []*ec2.Filter{
 			{
@@ -107,7 +191,7 @@ func (s *Service) defaultAMILookup(amiNameFormat, ownerID, baseOS, kubernetesVer
 			},
 			{
 				Name:   aws.String("architecture"),
-				Values: []*string{aws.String("x86_64")},
+				Values: []*string{aws.String(architecture)},
 			},
 			{
 				Name:   aws.String("state"),
@@ -119,6 +203,12 @@ func (s *Service) defaultAMILookup(amiNameFormat, ownerID, baseOS, kubernetesVer
 			},
 		},
 	}
+	if platform.IsWindows() {
+		describeImageInput.Filters = append(describeImageInput.Filters, &ec2.Filter{
+			Name:   aws.String("platform"),
+			Values: []*string{aws.String(string(platform))},
+		})
+	}
 
 	out, err := s.EC2Client.DescribeImages(describeImageInput)
 	if err != nil {
@@ -134,6 +224,7 @@ func (s *Service) defaultAMILookup(amiNameFormat, ownerID, baseOS, kubernetesVer
 		return "", err
 	}
 	s.scope.V(2).Info("Found and using an existing AMI", "ami-id", aws.StringValue(latestImage.ImageId))
+	imageLookupCache.set(aws.StringValue(latestImage.ImageId), s.scope.Region(), s.lookupCacheIdentity(), ownerID, amiName, architecture, string(platform))
 	return aws.StringValue(latestImage.ImageId), nil
 }
 
@@ -170,7 +261,14 @@ func getLatestImage(imgs []*ec2.Image) (*ec2.Image, error) {
 	return imgs[len(imgs)-1], nil
 }
 
-func (s *Service) defaultBastionAMILookup(region string) string {
+func (s *Service) defaultBastionAMILookup(region, architecture string) (string, error) {
+	if architecture == ec2.ArchitectureValuesArm64 {
+		return s.ssmAMILookup(ubuntuARM64AmiSSMParameterName)
+	}
+	return defaultBastionX8664AMI(region), nil
+}
+
+func defaultBastionX8664AMI(region string) string {
 	switch region {
 	case "ap-northeast-1":
 		return "ami-d39a02b5"
@@ -209,15 +307,32 @@ func (s *Service) defaultBastionAMILookup(region string) string {
 	}
 }
 
-func (s *Service) eksAMILookup(kubernetesVersion string) (string, error) {
+func (s *Service) eksAMILookup(kubernetesVersion, architecture string, platform infrav1.Platform) (string, error) {
 	// format ssm parameter path properly
 	formattedVersion, err := formatVersionForEKS(kubernetesVersion)
 	if err != nil {
 		return "", err
 	}
 
-	paramName := fmt.Sprintf(eksAmiSSMParameterFormat, formattedVersion)
+	paramFormat := eksAmiSSMParameterFormat
+	switch {
+	case platform.IsWindows():
+		paramFormat = eksWindowsAmiSSMParameterFormat
+	case architecture == ec2.ArchitectureValuesArm64:
+		paramFormat = eksARM64AmiSSMParameterFormat
+	}
 
+	id, err := s.ssmAMILookup(fmt.Sprintf(paramFormat, formattedVersion))
+	if err != nil {
+		return "", err
+	}
+	s.scope.Info("found AMI", "id", id, "version", formattedVersion, "architecture", architecture, "platform", platform)
+
+	return id, nil
+}
+
+// ssmAMILookup returns the AMI ID stored in an SSM parameter.
+func (s *Service) ssmAMILookup(paramName string) (string, error) {
 	input := &ssm.GetParameterInput{
 		Name: aws.String(paramName),
 	}
@@ -233,10 +348,7 @@ func (s *Service) eksAMILookup(kubernetesVersion string) (string, error) {
 		return "", errors.Errorf("SSM parameter returned with nil value: %q", paramName)
 	}
 
-	id := aws.StringValue(out.Parameter.Value)
-	s.scope.Info("found AMI", "id", id, "version", formattedVersion)
-
-	return id, nil
+	return aws.StringValue(out.Parameter.Value), nil
 }
 
 func formatVersionForEKS(version string) (string, error) {
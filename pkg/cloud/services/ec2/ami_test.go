@@ -18,15 +18,24 @@ package ec2
 
 import (
 	"testing"
+	"time"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/exp/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ssm/mock_ssmiface"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1alpha3"
 )
 
 func TestAMIs(t *testing.T) {
@@ -78,7 +87,7 @@ func TestAMIs(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			id, err := s.defaultAMILookup("", "", "base os-baseos version", "1.11.1")
+			id, err := s.defaultAMILookup("", "", "base os-baseos version", "", "", "1.11.1")
 			if err != nil {
 				t.Fatalf("did not expect error calling a mock: %v", err)
 			}
@@ -138,10 +147,350 @@ func TestAMIsWithInvalidCreationDate(t *testing.T) {
 			s := NewService(scope)
 			s.EC2Client = ec2Mock
 
-			_, err = s.defaultAMILookup("", "", "base os-baseos version", "1.11.1")
+			_, err = s.defaultAMILookup("", "", "base os-baseos version", "", "", "1.11.1")
 			if err == nil {
 				t.Fatalf("expected an error but did not get one")
 			}
 		})
 	}
 }
+
+func TestAMINameArchitecture(t *testing.T) {
+	g := NewWithT(t)
+
+	name, err := amiName("capa-ami-{{.BaseOS}}-{{.Arch}}-?{{.K8sVersion}}-*", "ubuntu-20.04", "arm64", "v1.18.2")
+	g.Expect(err).To(BeNil())
+	g.Expect(name).To(Equal("capa-ami-ubuntu-20.04-arm64-?1.18.2-*"))
+}
+
+func TestWindowsAMILookup(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	s := newClusterTestService(g, ec2Mock)
+
+	ec2Mock.EXPECT().DescribeImages(gomock.AssignableToTypeOf(&ec2.DescribeImagesInput{})).
+		DoAndReturn(func(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+			filters := map[string]string{}
+			for _, filter := range input.Filters {
+				filters[aws.StringValue(filter.Name)] = aws.StringValue(filter.Values[0])
+			}
+			g.Expect(filters).To(HaveKeyWithValue("name", "capa-ami-windows-2019-?1.18.2-*"))
+			g.Expect(filters).To(HaveKeyWithValue("platform", "windows"))
+			return &ec2.DescribeImagesOutput{
+				Images: []*ec2.Image{{ImageId: aws.String("ami-windows"), CreationDate: aws.String("2020-02-08T17:02:31.000Z")}},
+			}, nil
+		})
+
+	image, err := s.resolveImage(imageLookup{Architecture: "x86_64", Platform: infrav1.PlatformWindows, KubernetesVersion: "v1.18.2"})
+	g.Expect(err).To(BeNil())
+	g.Expect(image.ID).To(Equal("ami-windows"))
+	g.Expect(image.Source).To(HaveSuffix(",platform=windows"))
+	g.Expect(imageLookup{Architecture: "x86_64", Platform: infrav1.PlatformLinux}.source()).NotTo(ContainSubstring("platform"))
+}
+
+func TestImageArchitecture(t *testing.T) {
+	defer enableLookupCaches()()
+
+	describeInstanceTypes := func(architectures ...string) *ec2.DescribeInstanceTypesOutput {
+		return &ec2.DescribeInstanceTypesOutput{
+			InstanceTypes: []*ec2.InstanceTypeInfo{
+				{ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice(architectures)}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		architecture       string
+		instanceType       string
+		expect             func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectArchitecture string
+		expectError        bool
+	}{
+		{
+			name:               "explicit architecture",
+			architecture:       "arm64",
+			instanceType:       "m5.large",
+			expect:             func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expectArchitecture: "arm64",
+		},
+		{
+			name:               "no instance type",
+			expect:             func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expectArchitecture: "x86_64",
+		},
+		{
+			name:         "graviton instance type",
+			instanceType: "test-arch.m6g.large",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.Eq(&ec2.DescribeInstanceTypesInput{
+					InstanceTypes: aws.StringSlice([]string{"test-arch.m6g.large"}),
+				})).
+					Return(describeInstanceTypes("arm64"), nil).
+					Times(1)
+			},
+			expectArchitecture: "arm64",
+		},
+		{
+			name:         "instance type supporting several architectures",
+			instanceType: "test-arch.t2.micro",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.Any()).
+					Return(describeInstanceTypes("i386", "x86_64"), nil)
+			},
+			expectArchitecture: "x86_64",
+		},
+		{
+			name:         "describe fails",
+			instanceType: "test-arch.unknown",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceTypes(gomock.Any()).
+					Return(nil, errors.New("InvalidInstanceType"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s := newClusterTestService(g, ec2Mock)
+			tc.expect(ec2Mock.EXPECT())
+
+			// Look up the architecture twice, to ensure it is cached.
+			for i := 0; i < 2; i++ {
+				architecture, err := s.imageArchitecture(tc.architecture, tc.instanceType)
+				if tc.expectError {
+					g.Expect(err).NotTo(BeNil())
+					return
+				}
+				g.Expect(err).To(BeNil())
+				g.Expect(architecture).To(Equal(tc.expectArchitecture))
+			}
+		})
+	}
+}
+
+func TestSSMAMILookup(t *testing.T) {
+	tests := []struct {
+		name        string
+		lookup      func(s *Service) (string, error)
+		expectParam string
+	}{
+		{
+			name:        "eks x86_64",
+			lookup:      func(s *Service) (string, error) { return s.eksAMILookup("v1.18.9", "x86_64", "") },
+			expectParam: "/aws/service/eks/optimized-ami/1.18/amazon-linux-2/recommended/image_id",
+		},
+		{
+			name:        "eks arm64",
+			lookup:      func(s *Service) (string, error) { return s.eksAMILookup("v1.18.9", "arm64", "") },
+			expectParam: "/aws/service/eks/optimized-ami/1.18/amazon-linux-2-arm64/recommended/image_id",
+		},
+		{
+			name:        "eks windows",
+			lookup:      func(s *Service) (string, error) { return s.eksAMILookup("v1.18.9", "x86_64", infrav1.PlatformWindows) },
+			expectParam: "/aws/service/ami-windows-latest/Windows_Server-2019-English-Core-EKS_Optimized-1.18/image_id",
+		},
+		{
+			name:        "arm64 bastion",
+			lookup:      func(s *Service) (string, error) { return s.defaultBastionAMILookup("us-east-1", "arm64") },
+			expectParam: "/aws/service/canonical/ubuntu/server/18.04/stable/current/arm64/hvm/ebs-gp2/ami-id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+
+			s := newClusterTestService(g, mock_ec2iface.NewMockEC2API(mockCtrl))
+			s.SSMClient = ssmMock
+
+			ssmMock.EXPECT().GetParameter(gomock.Eq(&ssm.GetParameterInput{Name: aws.String(tc.expectParam)})).
+				Return(&ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String("ami-1")}}, nil)
+
+			id, err := tc.lookup(s)
+			g.Expect(err).To(BeNil())
+			g.Expect(id).To(Equal("ami-1"))
+		})
+	}
+}
+
+func TestDefaultBastionAMILookupX8664(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	s := newClusterTestService(g, mock_ec2iface.NewMockEC2API(mockCtrl))
+
+	id, err := s.defaultBastionAMILookup("us-east-1", "x86_64")
+	g.Expect(err).To(BeNil())
+	g.Expect(id).To(Equal("ami-41e0b93b"))
+}
+
+func TestResolveMachineImage(t *testing.T) {
+	const ssmParameter = "/golden-images/ubuntu/ami-id"
+
+	templateMachine := func(name, template string, image *infrav1.ResolvedImage) *infrav1.AWSMachine {
+		return &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+				Annotations: map[string]string{
+					clusterv1.TemplateClonedFromNameAnnotation:      template,
+					clusterv1.TemplateClonedFromGroupKindAnnotation: "AWSMachineTemplate.infrastructure.cluster.x-k8s.io",
+				},
+			},
+			Status: infrav1.AWSMachineStatus{Image: image},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		image        *infrav1.ResolvedImage
+		objs         []runtime.Object
+		expect       func(m *mock_ssmiface.MockSSMAPIMockRecorder)
+		expectedID   string
+		expectSource string
+	}{
+		{
+			name: "resolves the ssm parameter",
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Eq(&ssm.GetParameterInput{Name: aws.String(ssmParameter)})).
+					Return(&ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String("ami-new")}}, nil)
+			},
+			expectedID: "ami-new",
+		},
+		{
+			name:       "keeps the image resolved for the machine",
+			image:      &infrav1.ResolvedImage{ID: "ami-pinned", Source: "ssm:" + ssmParameter},
+			expect:     func(m *mock_ssmiface.MockSSMAPIMockRecorder) {},
+			expectedID: "ami-pinned",
+		},
+		{
+			name: "reuses the image of machines cloned from the same template",
+			objs: []runtime.Object{
+				templateMachine("other-template", "workers-v2", &infrav1.ResolvedImage{ID: "ami-other", Source: "ssm:" + ssmParameter}),
+				templateMachine("same-template", "workers-v1", &infrav1.ResolvedImage{ID: "ami-pinned", Source: "ssm:" + ssmParameter}),
+			},
+			expect:     func(m *mock_ssmiface.MockSSMAPIMockRecorder) {},
+			expectedID: "ami-pinned",
+		},
+		{
+			name: "reuses the most recently resolved image of the template",
+			objs: []runtime.Object{
+				templateMachine("a-unknown", "workers-v1", &infrav1.ResolvedImage{ID: "ami-unknown", Source: "ssm:" + ssmParameter}),
+				templateMachine("b-older", "workers-v1", &infrav1.ResolvedImage{
+					ID: "ami-older", Source: "ssm:" + ssmParameter, ResolvedAt: &metav1.Time{Time: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+				}),
+				templateMachine("c-newer", "workers-v1", &infrav1.ResolvedImage{
+					ID: "ami-newer", Source: "ssm:" + ssmParameter, ResolvedAt: &metav1.Time{Time: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)},
+				}),
+			},
+			expect:     func(m *mock_ssmiface.MockSSMAPIMockRecorder) {},
+			expectedID: "ami-newer",
+		},
+		{
+			name: "resolves the image again when its source changed",
+			objs: []runtime.Object{
+				templateMachine("same-template", "workers-v1", &infrav1.ResolvedImage{ID: "ami-old", Source: "ssm:/golden-images/old/ami-id"}),
+			},
+			expect: func(m *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.GetParameter(gomock.Any()).
+					Return(&ssm.GetParameterOutput{Parameter: &ssm.Parameter{Value: aws.String("ami-new")}}, nil)
+			},
+			expectedID: "ami-new",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+
+			s, machineScope := newMachineTestService(g, mock_ec2iface.NewMockEC2API(mockCtrl), tc.objs...)
+			s.SSMClient = ssmMock
+			machineScope.AWSMachine = templateMachine("aws-test", "workers-v1", tc.image)
+			machineScope.AWSMachine.Spec.AMI.SSMParameter = aws.String(ssmParameter)
+
+			tc.expect(ssmMock.EXPECT())
+
+			image, err := s.resolveMachineImage(machineScope)
+			g.Expect(err).To(BeNil())
+			g.Expect(image.ID).To(Equal(tc.expectedID))
+			g.Expect(image.Source).To(Equal("ssm:" + ssmParameter))
+		})
+	}
+}
+
+func TestDiscoverLaunchTemplateAMIPinning(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	s := newClusterTestService(g, ec2Mock)
+	machinePoolScope := &scope.MachinePoolScope{
+		InfraCluster: s.scope,
+		MachinePool: &expclusterv1.MachinePool{
+			Spec: expclusterv1.MachinePoolSpec{
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{Version: aws.String("v1.18.2")},
+				},
+			},
+		},
+		AWSMachinePool: &expinfrav1.AWSMachinePool{
+			Spec: expinfrav1.AWSMachinePoolSpec{
+				AWSLaunchTemplate: expinfrav1.AWSLaunchTemplate{ImageLookupArchitecture: "x86_64"},
+			},
+		},
+	}
+
+	image := func(id, creationDate string) *ec2.Image {
+		return &ec2.Image{ImageId: aws.String(id), CreationDate: aws.String(creationDate)}
+	}
+
+	ec2Mock.EXPECT().DescribeImages(gomock.Any()).
+		Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{image("ami-1", "2020-01-01T00:00:00.000Z")}}, nil)
+
+	id, err := s.DiscoverLaunchTemplateAMI(machinePoolScope)
+	g.Expect(err).To(BeNil())
+	g.Expect(*id).To(Equal("ami-1"))
+	g.Expect(machinePoolScope.AWSMachinePool.Status.Image.ID).To(Equal("ami-1"))
+
+	// The pinned image is reused without looking it up again until the version changes.
+	id, err = s.DiscoverLaunchTemplateAMI(machinePoolScope)
+	g.Expect(err).To(BeNil())
+	g.Expect(*id).To(Equal("ami-1"))
+
+	machinePoolScope.MachinePool.Spec.Template.Spec.Version = aws.String("v1.18.3")
+	ec2Mock.EXPECT().DescribeImages(gomock.Any()).
+		Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{
+			image("ami-1", "2020-01-01T00:00:00.000Z"),
+			image("ami-2", "2020-02-01T00:00:00.000Z"),
+		}}, nil)
+
+	id, err = s.DiscoverLaunchTemplateAMI(machinePoolScope)
+	g.Expect(err).To(BeNil())
+	g.Expect(*id).To(Equal("ami-2"))
+	g.Expect(machinePoolScope.AWSMachinePool.Status.Image.ID).To(Equal("ami-2"))
+}
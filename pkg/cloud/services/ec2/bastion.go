@@ -19,6 +19,7 @@ package ec2
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strings"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
@@ -45,16 +46,14 @@ var (
 
 // ReconcileBastion ensures a bastion is created for the cluster
 func (s *Service) ReconcileBastion() error {
+	if s.scope.Bastion().IsSSM() {
+		return s.reconcileSessionManagerTargets()
+	}
+	s.scope.SetSessionManagerTargets(nil)
+
 	if !s.scope.Bastion().Enabled {
 		s.scope.V(4).Info("Skipping bastion reconcile")
-		_, err := s.describeBastionInstance()
-		if err != nil {
-			if awserrors.IsNotFound(err) {
-				return nil
-			}
-			return err
-		}
-		return s.DeleteBastion()
+		return s.deleteUnusedBastion()
 	}
 
 	s.scope.V(2).Info("Reconciling bastion host")
@@ -67,6 +66,15 @@ func (s *Service) ReconcileBastion() error {
 		return errors.New("failed to reconcile bastion host, no public subnets are available")
 	}
 
+	if s.scope.Bastion().HighAvailability {
+		return s.reconcileBastionGroup()
+	}
+
+	// The highly available bastion host is replaced by a single instance when high availability is disabled.
+	if err := s.deleteUnusedBastionGroup(); err != nil {
+		return err
+	}
+
 	// Describe bastion instance, if any.
 	instance, err := s.describeBastionInstance()
 	if awserrors.IsNotFound(err) { // nolint:nestif
@@ -76,7 +84,13 @@ func (s *Service) ReconcileBastion() error {
 				return errors.Wrap(err, "failed to patch conditions")
 			}
 		}
-		instance, err = s.runInstance("bastion", s.getDefaultBastion(s.scope.Bastion().InstanceType, s.scope.Bastion().AMI))
+		defaultBastion, err := s.getDefaultBastion(s.scope.Bastion().InstanceType, s.scope.Bastion().AMI)
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedCreateBastion", "Failed to create bastion instance: %v", err)
+			return err
+		}
+
+		instance, err = s.runInstance("bastion", defaultBastion)
 		if err != nil {
 			record.Warnf(s.scope.InfraCluster(), "FailedCreateBastion", "Failed to create bastion instance: %v", err)
 			return err
@@ -89,7 +103,10 @@ func (s *Service) ReconcileBastion() error {
 		return err
 	}
 
-	// TODO(vincepri): check for possible changes between the default spec and the instance.
+	instance, err = s.replaceDriftedBastionInstance(instance)
+	if err != nil {
+		return err
+	}
 
 	s.scope.SetBastionInstance(instance.DeepCopy())
 	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition)
@@ -98,8 +115,119 @@ func (s *Service) ReconcileBastion() error {
 	return nil
 }
 
+// replaceDriftedBastionInstance replaces the bastion instance when its instance type, AMI or SSH key
+// no longer match the spec, and returns the instance to use. Security group rules are reconciled in
+// place by the security group service, so changes to the allowed CIDR blocks don't replace the instance.
+func (s *Service) replaceDriftedBastionInstance(instance *infrav1.Instance) (*infrav1.Instance, error) {
+	desired, err := s.desiredBastion(instance.Type, instance.ImageID)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := bastionDrift(instance, desired)
+	if len(drift) == 0 {
+		return instance, nil
+	}
+
+	message := fmt.Sprintf("Replacing bastion instance %q: %s", instance.ID, strings.Join(drift, ", "))
+	s.scope.V(2).Info("Bastion host does not match its spec, replacing it", "instance", instance.ID, "drift", drift)
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, infrav1.BastionReplacementPendingReason, clusterv1.ConditionSeverityInfo, message)
+	if err := s.scope.PatchObject(); err != nil {
+		return nil, errors.Wrap(err, "failed to patch conditions")
+	}
+	record.Eventf(s.scope.InfraCluster(), "BastionReplacementPending", message)
+
+	// The running bastion host is only terminated once its replacement is known to be able to launch.
+	if err := s.validateBastion(desired); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateBastion", "Failed to create bastion instance: %v", err)
+		return nil, err
+	}
+
+	if err := s.TerminateInstanceAndWait(instance.ID); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedTerminateBastion", "Failed to terminate bastion instance %q: %v", instance.ID, err)
+		return nil, errors.Wrap(err, "unable to delete bastion instance")
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulTerminateBastion", "Terminated bastion instance %q", instance.ID)
+
+	replacement, err := s.runInstance("bastion", desired)
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateBastion", "Failed to create bastion instance: %v", err)
+		return nil, err
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateBastion", "Created bastion instance %q", replacement.ID)
+
+	return replacement, nil
+}
+
+// desiredBastion returns the bastion instance described by the spec. The given instance type and AMI are
+// used when the spec leaves them empty, so that running bastion hosts aren't replaced whenever a newer
+// default AMI is published. The given AMI is only kept for another instance type when it supports its
+// architecture, otherwise the default AMI for the new instance type is looked up.
+func (s *Service) desiredBastion(currentInstanceType, currentAMI string) (*infrav1.Instance, error) {
+	instanceType, ami := s.scope.Bastion().InstanceType, s.scope.Bastion().AMI
+	if instanceType == "" {
+		instanceType = currentInstanceType
+	}
+	if ami == "" && currentAMI != "" {
+		supported := true
+		if instanceType != currentInstanceType {
+			var err error
+			if supported, err = s.imageSupportsInstanceType(currentAMI, instanceType); err != nil {
+				return nil, err
+			}
+		}
+		if supported {
+			ami = currentAMI
+		}
+	}
+	return s.getDefaultBastion(instanceType, ami)
+}
+
+// validateBastion checks that the AMI of a bastion instance exists and supports its instance type.
+func (s *Service) validateBastion(i *infrav1.Instance) error {
+	supported, err := s.imageSupportsInstanceType(i.ImageID, i.Type)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return errors.Errorf("AMI %q does not support the architecture of instance type %q", i.ImageID, i.Type)
+	}
+	return nil
+}
+
+// bastionDrift describes the differences between a bastion instance and the desired one.
+func bastionDrift(instance, desired *infrav1.Instance) []string {
+	drift := []string{}
+	if instance.Type != desired.Type {
+		drift = append(drift, fmt.Sprintf("instance type changed from %q to %q", instance.Type, desired.Type))
+	}
+	if instance.ImageID != desired.ImageID {
+		drift = append(drift, fmt.Sprintf("AMI changed from %q to %q", instance.ImageID, desired.ImageID))
+	}
+	if aws.StringValue(instance.SSHKeyName) != aws.StringValue(desired.SSHKeyName) {
+		drift = append(drift, fmt.Sprintf("SSH key changed from %q to %q", aws.StringValue(instance.SSHKeyName), aws.StringValue(desired.SSHKeyName)))
+	}
+	return drift
+}
+
 // DeleteBastion deletes the Bastion instance
 func (s *Service) DeleteBastion() error {
+	if err := s.deleteBastionGroup(); err != nil {
+		return err
+	}
+	return s.deleteBastionInstance()
+}
+
+// deleteUnusedBastion deletes the bastion host while it is disabled. Unlike DeleteBastion, it only looks
+// up the highly available bastion host when the status of the cluster shows it may exist.
+func (s *Service) deleteUnusedBastion() error {
+	if err := s.deleteUnusedBastionGroup(); err != nil {
+		return err
+	}
+	return s.deleteBastionInstance()
+}
+
+func (s *Service) deleteBastionInstance() error {
 	instance, err := s.describeBastionInstance()
 	if err != nil {
 		if awserrors.IsNotFound(err) {
@@ -125,6 +253,56 @@ func (s *Service) DeleteBastion() error {
 	return nil
 }
 
+// reconcileSessionManagerTargets lists the control plane instances reachable through Session Manager
+// in the status of the cluster, in place of the bastion host.
+func (s *Service) reconcileSessionManagerTargets() error {
+	s.scope.V(2).Info("Reconciling Session Manager targets")
+
+	// The bastion host created before switching to ssm mode is not needed anymore.
+	if err := s.deleteUnusedBastion(); err != nil {
+		return err
+	}
+	s.scope.SetBastionInstance(nil)
+
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderRole("control-plane"),
+			filter.EC2.Cluster(s.scope.Name()),
+			filter.EC2.InstanceStates(ec2.InstanceStateNameRunning),
+		},
+	}
+
+	out, err := s.EC2Client.DescribeInstances(input)
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeSessionManagerTargets", "Failed to describe control plane instances: %v", err)
+		return errors.Wrap(err, "failed to describe control plane instances")
+	}
+
+	targets := []infrav1.SessionManagerTarget{}
+	for _, res := range out.Reservations {
+		for _, instance := range res.Instances {
+			instanceID := aws.StringValue(instance.InstanceId)
+			target := infrav1.SessionManagerTarget{
+				InstanceID: instanceID,
+				Command:    fmt.Sprintf("aws ssm start-session --region %s --target %s", s.scope.Region(), instanceID),
+			}
+			if instance.Placement != nil {
+				target.AvailabilityZone = aws.StringValue(instance.Placement.AvailabilityZone)
+			}
+			targets = append(targets, target)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].InstanceID < targets[j].InstanceID
+	})
+
+	s.scope.SetSessionManagerTargets(targets)
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition)
+	s.scope.V(2).Info("Reconcile Session Manager targets completed successfully", "targets", len(targets))
+
+	return nil
+}
+
 func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 	input := &ec2.DescribeInstancesInput{
 		Filters: []*ec2.Filter{
@@ -149,6 +327,10 @@ func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 	// the first non-terminated.
 	for _, res := range out.Reservations {
 		for _, instance := range res.Instances {
+			// Instances of the highly available bastion host are managed by their Auto Scaling group.
+			if isAutoScalingGroupInstance(instance) {
+				continue
+			}
 			if aws.StringValue(instance.State.Name) != ec2.InstanceStateNameTerminated {
 				return s.SDKToInstance(instance)
 			}
@@ -158,7 +340,7 @@ func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 	return nil, awserrors.NewNotFound("bastion host not found")
 }
 
-func (s *Service) getDefaultBastion(instanceType, ami string) *infrav1.Instance {
+func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance, error) {
 	name := fmt.Sprintf("%s-bastion", s.scope.Name())
 	userData, _ := userdata.NewBastion(&userdata.BastionInput{})
 
@@ -179,7 +361,15 @@ func (s *Service) getDefaultBastion(instanceType, ami string) *infrav1.Instance
 	}
 
 	if ami == "" {
-		ami = s.defaultBastionAMILookup(s.scope.Region())
+		architecture, err := s.instanceTypeArchitecture(instanceType)
+		if err != nil {
+			return nil, err
+		}
+
+		ami, err = s.defaultBastionAMILookup(s.scope.Region(), architecture)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	i := &infrav1.Instance{
@@ -200,5 +390,14 @@ func (s *Service) getDefaultBastion(instanceType, ami string) *infrav1.Instance
 		}),
 	}
 
-	return i
+	return i, nil
+}
+
+func isAutoScalingGroupInstance(instance *ec2.Instance) bool {
+	for _, tag := range instance.Tags {
+		if aws.StringValue(tag.Key) == autoScalingGroupNameTagKey {
+			return true
+		}
+	}
+	return false
 }
@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
@@ -30,8 +32,10 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/autoscaling/mock_autoscalingiface"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -204,6 +208,10 @@ func TestDeleteBastion(t *testing.T) {
 					}
 				}
 
+				// There is no highly available bastion host.
+				ec2Mock.EXPECT().
+					DescribeAddresses(gomock.Any()).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
 				tc.expect(ec2Mock.EXPECT())
 				s := NewService(scope)
 				s.EC2Client = ec2Mock
@@ -219,3 +227,571 @@ func TestDeleteBastion(t *testing.T) {
 		}
 	}
 }
+
+func TestReconcileBastionSessionManager(t *testing.T) {
+	g := NewWithT(t)
+
+	mockControl := gomock.NewController(t)
+	defer mockControl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockControl)
+
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+
+	clusterName := "cluster"
+	awsCluster := &infrav1.AWSCluster{
+		Spec: infrav1.AWSClusterSpec{
+			Region: "us-east-1",
+			Bastion: infrav1.Bastion{
+				Enabled: true,
+				Mode:    infrav1.BastionModeSSM,
+			},
+		},
+		Status: infrav1.AWSClusterStatus{
+			Bastion: &infrav1.Instance{ID: "id123"},
+		},
+	}
+
+	client := fake.NewFakeClientWithScheme(scheme)
+	client.Create(context.TODO(), awsCluster)
+
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      clusterName,
+			},
+		},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+	g.Expect(err).To(BeNil())
+
+	// The bastion host left from ssh mode is deleted. Its status shows it isn't highly available, so
+	// no Elastic IP address is looked up.
+	ec2Mock.EXPECT().DescribeInstances(gomock.Eq(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
+			filter.EC2.Cluster(clusterName),
+			filter.EC2.InstanceStates(
+				ec2.InstanceStateNamePending,
+				ec2.InstanceStateNameRunning,
+				ec2.InstanceStateNameStopping,
+				ec2.InstanceStateNameStopped,
+			),
+		},
+	})).Return(&ec2.DescribeInstancesOutput{}, nil)
+	ec2Mock.EXPECT().DescribeInstances(gomock.Eq(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderRole("control-plane"),
+			filter.EC2.Cluster(clusterName),
+			filter.EC2.InstanceStates(ec2.InstanceStateNameRunning),
+		},
+	})).Return(&ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{
+			{
+				Instances: []*ec2.Instance{
+					{
+						InstanceId: aws.String("i-b"),
+						Placement:  &ec2.Placement{AvailabilityZone: aws.String("us-east-1b")},
+					},
+					{
+						InstanceId: aws.String("i-a"),
+						Placement:  &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.ReconcileBastion()).To(Succeed())
+	g.Expect(awsCluster.Status.Bastion).To(BeNil())
+	g.Expect(awsCluster.Status.SessionManagerTargets).To(Equal([]infrav1.SessionManagerTarget{
+		{InstanceID: "i-a", AvailabilityZone: "us-east-1a", Command: "aws ssm start-session --region us-east-1 --target i-a"},
+		{InstanceID: "i-b", AvailabilityZone: "us-east-1b", Command: "aws ssm start-session --region us-east-1 --target i-b"},
+	}))
+}
+
+func TestReconcileBastionHighAvailability(t *testing.T) {
+	g := NewWithT(t)
+
+	mockControl := gomock.NewController(t)
+	defer mockControl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockControl)
+	asgMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockControl)
+
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+
+	clusterName := "cluster"
+	awsCluster := &infrav1.AWSCluster{
+		Spec: infrav1.AWSClusterSpec{
+			Region: "us-east-1",
+			Bastion: infrav1.Bastion{
+				Enabled:          true,
+				HighAvailability: true,
+				InstanceType:     "t3.small",
+				AMI:              "ami-bastion",
+			},
+			NetworkSpec: infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{ID: "vpc-1"},
+				Subnets: infrav1.Subnets{
+					{ID: "subnet-private", IsPublic: false, AvailabilityZone: "us-east-1a"},
+					{ID: "subnet-public-a", IsPublic: true, AvailabilityZone: "us-east-1a"},
+					{ID: "subnet-public-b", IsPublic: true, AvailabilityZone: "us-east-1b"},
+				},
+			},
+		},
+		Status: infrav1.AWSClusterStatus{
+			Network: infrav1.Network{
+				SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+					infrav1.SecurityGroupBastion: {ID: "sg-bastion"},
+				},
+			},
+		},
+	}
+
+	client := fake.NewFakeClientWithScheme(scheme)
+	client.Create(context.TODO(), awsCluster)
+
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      clusterName,
+			},
+		},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+	g.Expect(err).To(BeNil())
+
+	groupInstance := func(id string, launchTime time.Time) *ec2.Instance {
+		return &ec2.Instance{
+			InstanceId:   aws.String(id),
+			InstanceType: aws.String("t3.small"),
+			ImageId:      aws.String("ami-bastion"),
+			KeyName:      aws.String(defaultSSHKeyName),
+			LaunchTime:   aws.Time(launchTime),
+			State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+			Placement:    &ec2.Placement{AvailabilityZone: aws.String("us-east-1b")},
+			Tags: []*ec2.Tag{
+				{Key: aws.String(autoScalingGroupNameTagKey), Value: aws.String("cluster-bastion")},
+			},
+		}
+	}
+	now := time.Now()
+
+	// Instances of the group are not mistaken for a single bastion instance.
+	ec2Mock.EXPECT().DescribeInstances(gomock.Eq(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
+			filter.EC2.Cluster(clusterName),
+			filter.EC2.InstanceStates(
+				ec2.InstanceStateNamePending,
+				ec2.InstanceStateNameRunning,
+				ec2.InstanceStateNameStopping,
+				ec2.InstanceStateNameStopped,
+			),
+		},
+	})).Return(&ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{groupInstance("i-old", now.Add(-time.Hour))}}},
+	}, nil)
+	ec2Mock.EXPECT().DescribeAddresses(gomock.Eq(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.Cluster(clusterName),
+			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
+		},
+	})).Return(&ec2.DescribeAddressesOutput{
+		Addresses: []*ec2.Address{
+			{
+				AllocationId: aws.String("eipalloc-bastion"),
+				PublicIp:     aws.String("203.0.113.10"),
+				InstanceId:   aws.String("i-old"),
+			},
+		},
+	}, nil)
+	ec2Mock.EXPECT().DescribeLaunchTemplateVersions(gomock.Any()).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+			{
+				LaunchTemplateId:   aws.String("lt-bastion"),
+				LaunchTemplateName: aws.String("cluster-bastion"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					InstanceType: aws.String("t3.small"),
+					ImageId:      aws.String("ami-bastion"),
+					KeyName:      aws.String(defaultSSHKeyName),
+				},
+			},
+		},
+	}, nil)
+	asgMock.EXPECT().DescribeAutoScalingGroups(gomock.Eq(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: aws.StringSlice([]string{"cluster-bastion"}),
+	})).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+		AutoScalingGroups: []*autoscaling.Group{{AutoScalingGroupName: aws.String("cluster-bastion")}},
+	}, nil)
+	ec2Mock.EXPECT().DescribeInstances(gomock.Eq(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.AutoScalingGroup("cluster-bastion"),
+			filter.EC2.Cluster(clusterName),
+			filter.EC2.InstanceStates(ec2.InstanceStateNameRunning),
+		},
+	})).Return(&ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{
+			{Instances: []*ec2.Instance{groupInstance("i-old", now.Add(-time.Hour)), groupInstance("i-new", now)}},
+		},
+	}, nil)
+	// The address follows the replacement instance.
+	ec2Mock.EXPECT().AssociateAddress(gomock.Eq(&ec2.AssociateAddressInput{
+		AllocationId:       aws.String("eipalloc-bastion"),
+		InstanceId:         aws.String("i-new"),
+		AllowReassociation: aws.Bool(true),
+	})).Return(&ec2.AssociateAddressOutput{}, nil)
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+	s.ASGClient = asgMock
+
+	g.Expect(s.ReconcileBastion()).To(Succeed())
+	g.Expect(awsCluster.Status.Bastion).NotTo(BeNil())
+	g.Expect(awsCluster.Status.Bastion.ID).To(Equal("i-new"))
+	g.Expect(aws.StringValue(awsCluster.Status.Bastion.PublicIP)).To(Equal("203.0.113.10"))
+	g.Expect(conditions.IsTrue(awsCluster, infrav1.BastionHostReadyCondition)).To(BeTrue())
+}
+
+func TestReconcileBastionReplacesDriftedInstance(t *testing.T) {
+	describeImage := func(m *mock_ec2iface.MockEC2APIMockRecorder, id, architecture string) *gomock.Call {
+		return m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{id})})).
+			Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{ImageId: aws.String(id), Architecture: aws.String(architecture)}}}, nil)
+	}
+	describeInstanceType := func(m *mock_ec2iface.MockEC2APIMockRecorder, instanceType, architecture string) *gomock.Call {
+		return m.DescribeInstanceTypes(gomock.Eq(&ec2.DescribeInstanceTypesInput{InstanceTypes: aws.StringSlice([]string{instanceType})})).
+			Return(&ec2.DescribeInstanceTypesOutput{
+				InstanceTypes: []*ec2.InstanceTypeInfo{{ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{architecture})}}},
+			}, nil)
+	}
+
+	tests := []struct {
+		name                string
+		ami                 string
+		currentInstanceType string
+		instanceType        string
+		expect              func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectAMI           string
+		expectError         bool
+	}{
+		{
+			name:                "keeps the AMI of the running instance, as the spec leaves it empty",
+			currentInstanceType: "t3.micro",
+			instanceType:        "t3.small",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeImage(m, "ami-bastion", "x86_64").Times(2)
+				describeInstanceType(m, "t3.small", "x86_64").Times(2)
+			},
+			expectAMI: "ami-bastion",
+		},
+		{
+			name:                "looks up the default AMI when the instance type changes architecture",
+			currentInstanceType: "t4g.micro",
+			instanceType:        "t3.small",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeImage(m, "ami-bastion", "arm64")
+				describeInstanceType(m, "t3.small", "x86_64").Times(3)
+				describeImage(m, "ami-1ee65166", "x86_64")
+			},
+			expectAMI: "ami-1ee65166",
+		},
+		{
+			name:                "keeps the running instance when the AMI of the spec doesn't support the instance type",
+			ami:                 "ami-arm64",
+			currentInstanceType: "t3.micro",
+			instanceType:        "t3.small",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeImage(m, "ami-arm64", "arm64")
+				describeInstanceType(m, "t3.small", "x86_64")
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockControl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).To(BeNil())
+
+			clusterName := "cluster"
+			awsCluster := &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					Region: "us-west-2",
+					Bastion: infrav1.Bastion{
+						Enabled:      true,
+						InstanceType: tc.instanceType,
+						AMI:          tc.ami,
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{ID: "vpc-1"},
+						Subnets: infrav1.Subnets{
+							{ID: "subnet-private", IsPublic: false, AvailabilityZone: "us-west-2a"},
+							{ID: "subnet-public", IsPublic: true, AvailabilityZone: "us-west-2a"},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupBastion: {ID: "sg-bastion"},
+						},
+					},
+				},
+			}
+
+			client := fake.NewFakeClientWithScheme(scheme)
+			client.Create(context.TODO(), awsCluster)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).To(BeNil())
+
+			bastionInstance := func(id, instanceType, ami string) *ec2.Instance {
+				return &ec2.Instance{
+					InstanceId:   aws.String(id),
+					InstanceType: aws.String(instanceType),
+					ImageId:      aws.String(ami),
+					KeyName:      aws.String(defaultSSHKeyName),
+					State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+					Placement:    &ec2.Placement{AvailabilityZone: aws.String("us-west-2a")},
+				}
+			}
+
+			ec2Mock.EXPECT().DescribeInstances(gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{bastionInstance("i-old", tc.currentInstanceType, "ami-bastion")}}},
+			}, nil)
+			tc.expect(ec2Mock.EXPECT())
+			if !tc.expectError {
+				ec2Mock.EXPECT().TerminateInstances(gomock.Eq(&ec2.TerminateInstancesInput{
+					InstanceIds: aws.StringSlice([]string{"i-old"}),
+				})).Return(nil, nil)
+				ec2Mock.EXPECT().WaitUntilInstanceTerminated(gomock.Eq(&ec2.DescribeInstancesInput{
+					InstanceIds: aws.StringSlice([]string{"i-old"}),
+				})).Return(nil)
+				ec2Mock.EXPECT().RunInstances(gomock.Any()).DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+					g.Expect(aws.StringValue(input.InstanceType)).To(Equal(tc.instanceType))
+					g.Expect(aws.StringValue(input.ImageId)).To(Equal(tc.expectAMI))
+					return &ec2.Reservation{Instances: []*ec2.Instance{bastionInstance("i-new", tc.instanceType, tc.expectAMI)}}, nil
+				})
+				ec2Mock.EXPECT().WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.ReconcileBastion()
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(awsCluster.Status.Bastion.ID).To(Equal("i-new"))
+			g.Expect(conditions.IsTrue(awsCluster, infrav1.BastionHostReadyCondition)).To(BeTrue())
+		})
+	}
+}
+
+func TestBastionGroupMayExist(t *testing.T) {
+	testCases := []struct {
+		name      string
+		bastion   *infrav1.Instance
+		condition *clusterv1.Condition
+		expect    bool
+	}{
+		{
+			name:   "no bastion host was ever created",
+			expect: false,
+		},
+		{
+			name:    "the bastion instance runs outside of the group",
+			bastion: &infrav1.Instance{ID: "i-1"},
+			expect:  false,
+		},
+		{
+			name:    "the bastion instance runs in the group",
+			bastion: &infrav1.Instance{ID: "i-1", Tags: infrav1.Tags{autoScalingGroupNameTagKey: "cluster-bastion"}},
+			expect:  true,
+		},
+		{
+			name:      "waiting for the bastion instance to run",
+			condition: conditions.FalseCondition(infrav1.BastionHostReadyCondition, infrav1.BastionCreationStartedReason, clusterv1.ConditionSeverityInfo, ""),
+			expect:    true,
+		},
+		{
+			name:      "the bastion host is being deleted",
+			condition: conditions.FalseCondition(infrav1.BastionHostReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, ""),
+			expect:    true,
+		},
+		{
+			name:      "the bastion host was deleted",
+			condition: conditions.FalseCondition(infrav1.BastionHostReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, ""),
+			expect:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme, err := setupScheme()
+			g.Expect(err).To(BeNil())
+
+			awsCluster := &infrav1.AWSCluster{
+				Status: infrav1.AWSClusterStatus{
+					Bastion: tc.bastion,
+				},
+			}
+			if tc.condition != nil {
+				conditions.Set(awsCluster, tc.condition)
+			}
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cluster"},
+				},
+				AWSCluster: awsCluster,
+				Client:     fake.NewFakeClientWithScheme(scheme),
+			})
+			g.Expect(err).To(BeNil())
+
+			s := NewService(scope)
+			g.Expect(s.bastionGroupMayExist()).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestReconcileBastionLaunchTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		ami         string
+		expect      func(g *WithT, m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectAMI   string
+		expectError bool
+	}{
+		{
+			name: "looks up the default AMI when the instance type changes architecture",
+			expect: func(g *WithT, m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-bastion"})})).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{Architecture: aws.String("arm64")}}}, nil)
+				m.DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-41e0b93b"})})).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{Architecture: aws.String("x86_64")}}}, nil)
+				m.DescribeInstanceTypes(gomock.Any()).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{{ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{"x86_64"})}}},
+					}, nil).Times(3)
+				m.CreateLaunchTemplateVersion(gomock.Any()).DoAndReturn(func(input *ec2.CreateLaunchTemplateVersionInput) (*ec2.CreateLaunchTemplateVersionOutput, error) {
+					g.Expect(aws.StringValue(input.LaunchTemplateData.InstanceType)).To(Equal("t3.small"))
+					g.Expect(aws.StringValue(input.LaunchTemplateData.ImageId)).To(Equal("ami-41e0b93b"))
+					return &ec2.CreateLaunchTemplateVersionOutput{}, nil
+				})
+			},
+			expectAMI: "ami-41e0b93b",
+		},
+		{
+			name: "doesn't replace the bastion host when the AMI of the spec doesn't support the instance type",
+			ami:  "ami-arm64",
+			expect: func(g *WithT, m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{Images: []*ec2.Image{{Architecture: aws.String("arm64")}}}, nil)
+				m.DescribeInstanceTypes(gomock.Any()).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{{ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{"x86_64"})}}},
+					}, nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockControl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "cluster",
+					},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						Region: "us-east-1",
+						Bastion: infrav1.Bastion{
+							Enabled:          true,
+							HighAvailability: true,
+							InstanceType:     "t3.small",
+							AMI:              tc.ami,
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{ID: "subnet-public", IsPublic: true, AvailabilityZone: "us-east-1a"},
+							},
+						},
+					},
+					Status: infrav1.AWSClusterStatus{
+						Network: infrav1.Network{
+							SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+								infrav1.SecurityGroupBastion: {ID: "sg-bastion"},
+							},
+						},
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			// The launch template describes a bastion host running on arm64.
+			ec2Mock.EXPECT().DescribeLaunchTemplateVersions(gomock.Any()).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+				LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+					{
+						LaunchTemplateId:   aws.String("lt-bastion"),
+						LaunchTemplateName: aws.String("cluster-bastion"),
+						LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+							InstanceType: aws.String("t4g.small"),
+							ImageId:      aws.String("ami-bastion"),
+							KeyName:      aws.String(defaultSSHKeyName),
+						},
+					},
+				},
+			}, nil)
+			tc.expect(g, ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			id, desired, err := s.reconcileBastionLaunchTemplate("cluster-bastion")
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(id).To(Equal("lt-bastion"))
+			g.Expect(desired.ImageID).To(Equal(tc.expectAMI))
+		})
+	}
+}
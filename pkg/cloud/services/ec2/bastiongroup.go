@@ -0,0 +1,370 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	asg "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/autoscaling"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// autoScalingGroupNameTagKey is the tag Auto Scaling adds to the instances it launches.
+const autoScalingGroupNameTagKey = "aws:autoscaling:groupName"
+
+// bastionGroupName returns the name of the Auto Scaling group and launch template of the highly
+// available bastion host.
+func (s *Service) bastionGroupName() string {
+	return fmt.Sprintf("%s-bastion", s.scope.Name())
+}
+
+func (s *Service) getASGService() *asg.Service {
+	svc := asg.NewService(s.scope)
+	svc.ASGClient = s.ASGClient
+	return svc
+}
+
+// reconcileBastionGroup ensures the bastion host runs in an Auto Scaling group of one instance spanning
+// all public subnets, and that the Elastic IP address of the bastion host is associated with it.
+func (s *Service) reconcileBastionGroup() error {
+	s.scope.V(2).Info("Reconciling highly available bastion host")
+
+	// The bastion instance created before enabling high availability is replaced by the group.
+	if err := s.deleteBastionInstance(); err != nil {
+		return err
+	}
+
+	// The address is allocated first and released last, so that it tells whether there is a group
+	// to delete.
+	address, err := s.getOrAllocateBastionAddress()
+	if err != nil {
+		return err
+	}
+
+	name := s.bastionGroupName()
+	launchTemplateID, desired, err := s.reconcileBastionLaunchTemplate(name)
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateBastion", "Failed to reconcile bastion launch template: %v", err)
+		return err
+	}
+
+	asgSvc := s.getASGService()
+	group, err := asgSvc.ASGIfExists(aws.String(name))
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		subnetIDs := []string{}
+		for _, subnet := range s.scope.Subnets().FilterPublic() {
+			subnetIDs = append(subnetIDs, subnet.ID)
+		}
+		if err := asgSvc.CreateSingleInstanceASG(name, launchTemplateID, subnetIDs, desired.Tags); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedCreateBastion", "Failed to create bastion Auto Scaling group: %v", err)
+			return err
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateBastion", "Created bastion Auto Scaling group %q", name)
+	}
+
+	instance, err := s.describeBastionGroupInstance(name)
+	if awserrors.IsNotFound(err) {
+		s.scope.V(2).Info("Waiting for the bastion host instance to run")
+		s.scope.SetBastionInstance(nil)
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, infrav1.BastionCreationStartedReason, clusterv1.ConditionSeverityInfo, "Waiting for the bastion host instance to run")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Instances of previous launch template versions are rolled forward, and remain the bastion host
+	// until their replacement runs.
+	drift := bastionDrift(instance, desired)
+	if len(drift) > 0 {
+		if err := asgSvc.StartInstanceRefresh(name); err != nil {
+			return err
+		}
+		record.Eventf(s.scope.InfraCluster(), "BastionReplacementPending", "Replacing bastion instance %q: %s", instance.ID, strings.Join(drift, ", "))
+	}
+
+	if aws.StringValue(address.InstanceId) != instance.ID {
+		if _, err := s.EC2Client.AssociateAddress(&ec2.AssociateAddressInput{
+			AllocationId:       address.AllocationId,
+			InstanceId:         aws.String(instance.ID),
+			AllowReassociation: aws.Bool(true),
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedAssociateEIP", "Failed to associate Elastic IP %q with bastion instance %q: %v", aws.StringValue(address.AllocationId), instance.ID, err)
+			return errors.Wrapf(err, "failed to associate Elastic IP %q with bastion instance %q", aws.StringValue(address.AllocationId), instance.ID)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateEIP", "Associated Elastic IP %q with bastion instance %q", aws.StringValue(address.PublicIp), instance.ID)
+	}
+	instance.PublicIP = address.PublicIp
+
+	s.scope.SetBastionInstance(instance.DeepCopy())
+	if len(drift) > 0 {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, infrav1.BastionReplacementPendingReason, clusterv1.ConditionSeverityInfo,
+			"Replacing bastion instance %q: %s", instance.ID, strings.Join(drift, ", "))
+		return nil
+	}
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition)
+	s.scope.V(2).Info("Reconcile highly available bastion completed successfully", "instance", instance.ID)
+
+	return nil
+}
+
+// reconcileBastionLaunchTemplate ensures the latest version of the launch template of the bastion host
+// matches its spec, and returns its ID along with the instance it describes.
+func (s *Service) reconcileBastionLaunchTemplate(name string) (string, *infrav1.Instance, error) {
+	existing, err := s.GetLaunchTemplate(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var current *infrav1.Instance
+	if existing != nil {
+		current = &infrav1.Instance{
+			Type:       existing.InstanceType,
+			ImageID:    aws.StringValue(existing.AMI.ID),
+			SSHKeyName: existing.SSHKeyName,
+		}
+	} else {
+		current = &infrav1.Instance{}
+	}
+
+	desired, err := s.desiredBastion(current.Type, current.ImageID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if existing == nil {
+		out, err := s.EC2Client.CreateLaunchTemplate(&ec2.CreateLaunchTemplateInput{
+			LaunchTemplateName: aws.String(name),
+			LaunchTemplateData: bastionLaunchTemplateData(desired),
+			TagSpecifications: []*ec2.TagSpecification{
+				{
+					ResourceType: aws.String(ec2.ResourceTypeLaunchTemplate),
+					Tags:         converters.MapToTags(desired.Tags),
+				},
+			},
+		})
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "failed to create launch template %q", name)
+		}
+		return aws.StringValue(out.LaunchTemplate.LaunchTemplateId), desired, nil
+	}
+
+	if drift := bastionDrift(current, desired); len(drift) > 0 {
+		// The new version replaces the running bastion host, so it must be able to launch.
+		if err := s.validateBastion(desired); err != nil {
+			return "", nil, err
+		}
+		if _, err := s.EC2Client.CreateLaunchTemplateVersion(&ec2.CreateLaunchTemplateVersionInput{
+			LaunchTemplateId:   aws.String(existing.ID),
+			LaunchTemplateData: bastionLaunchTemplateData(desired),
+		}); err != nil {
+			return "", nil, errors.Wrapf(err, "failed to create version of launch template %q", name)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulUpdateBastion", "Updated bastion launch template %q: %s", name, strings.Join(drift, ", "))
+	}
+
+	return existing.ID, desired, nil
+}
+
+func bastionLaunchTemplateData(i *infrav1.Instance) *ec2.RequestLaunchTemplateData {
+	data := &ec2.RequestLaunchTemplateData{
+		ImageId:          aws.String(i.ImageID),
+		InstanceType:     aws.String(i.Type),
+		UserData:         i.UserData,
+		SecurityGroupIds: aws.StringSlice(i.SecurityGroupIDs),
+		TagSpecifications: []*ec2.LaunchTemplateTagSpecificationRequest{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeInstance),
+				Tags:         converters.MapToTags(i.Tags),
+			},
+		},
+	}
+
+	if aws.StringValue(i.SSHKeyName) != "" {
+		data.KeyName = i.SSHKeyName
+	}
+
+	return data
+}
+
+// describeBastionGroupInstance returns the most recently launched running instance of the Auto Scaling group
+// of the bastion host.
+func (s *Service) describeBastionGroupInstance(name string) (*infrav1.Instance, error) {
+	out, err := s.EC2Client.DescribeInstances(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.AutoScalingGroup(name),
+			filter.EC2.Cluster(s.scope.Name()),
+			filter.EC2.InstanceStates(ec2.InstanceStateNameRunning),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeBastionHost", "Failed to describe bastion host: %v", err)
+		return nil, errors.Wrap(err, "failed to describe bastion host")
+	}
+
+	var latest *ec2.Instance
+	for _, res := range out.Reservations {
+		for _, instance := range res.Instances {
+			if latest == nil || aws.TimeValue(instance.LaunchTime).After(aws.TimeValue(latest.LaunchTime)) {
+				latest = instance
+			}
+		}
+	}
+	if latest == nil {
+		return nil, awserrors.NewNotFound("bastion host not found")
+	}
+
+	return s.SDKToInstance(latest)
+}
+
+// bastionGroupMayExist returns whether the highly available bastion host may exist according to the status
+// of the cluster. Its instance runs in the Auto Scaling group, and without an instance the group can only
+// exist while waiting for its instance to run or while being deleted.
+func (s *Service) bastionGroupMayExist() bool {
+	if instance := s.scope.BastionInstance(); instance != nil {
+		_, ok := instance.Tags[autoScalingGroupNameTagKey]
+		return ok
+	}
+	condition := conditions.Get(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition)
+	return condition != nil && condition.Reason != clusterv1.DeletedReason
+}
+
+// deleteUnusedBastionGroup deletes the highly available bastion host when the status of the cluster shows
+// it may exist, to avoid looking up its Elastic IP address on every reconcile.
+func (s *Service) deleteUnusedBastionGroup() error {
+	if !s.bastionGroupMayExist() {
+		s.scope.V(4).Info("highly available bastion does not exist")
+		return nil
+	}
+	return s.deleteBastionGroup()
+}
+
+// deleteBastionGroup deletes the Auto Scaling group and launch template of the highly available bastion
+// host, then releases its Elastic IP address.
+func (s *Service) deleteBastionGroup() error {
+	address, err := s.describeBastionAddress()
+	if err != nil {
+		return err
+	}
+	if address == nil {
+		s.scope.V(4).Info("highly available bastion does not exist")
+		return nil
+	}
+
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+	if err := s.scope.PatchObject(); err != nil {
+		return err
+	}
+
+	name := s.bastionGroupName()
+	asgSvc := s.getASGService()
+	group, err := asgSvc.ASGIfExists(aws.String(name))
+	if err != nil {
+		return err
+	}
+	if group != nil {
+		if err := asgSvc.DeleteASGAndWait(name); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedTerminateBastion", "Failed to delete bastion Auto Scaling group %q: %v", name, err)
+			return err
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulTerminateBastion", "Deleted bastion Auto Scaling group %q", name)
+	}
+
+	launchTemplate, err := s.GetLaunchTemplate(name)
+	if err != nil {
+		return err
+	}
+	if launchTemplate != nil {
+		if err := s.DeleteLaunchTemplate(launchTemplate.ID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.EC2Client.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: address.AllocationId}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedReleaseEIP", "Failed to release bastion Elastic IP %q: %v", aws.StringValue(address.AllocationId), err)
+		return errors.Wrapf(err, "failed to release bastion Elastic IP %q", aws.StringValue(address.AllocationId))
+	}
+
+	s.scope.SetBastionInstance(nil)
+	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.BastionHostReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+
+	return nil
+}
+
+func (s *Service) describeBastionAddress() (*ec2.Address, error) {
+	out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.Cluster(s.scope.Name()),
+			filter.EC2.ProviderRole(infrav1.BastionRoleTagValue),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeAddresses", "Failed to query addresses for role %q: %v", infrav1.BastionRoleTagValue, err)
+		return nil, errors.Wrap(err, "failed to query bastion addresses")
+	}
+
+	if len(out.Addresses) == 0 {
+		return nil, nil
+	}
+	return out.Addresses[0], nil
+}
+
+func (s *Service) getOrAllocateBastionAddress() (*ec2.Address, error) {
+	address, err := s.describeBastionAddress()
+	if err != nil || address != nil {
+		return address, err
+	}
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(fmt.Sprintf("%s-eip-%s", s.scope.Name(), infrav1.BastionRoleTagValue)),
+		Role:        aws.String(infrav1.BastionRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	})
+	out, err := s.EC2Client.AllocateAddress(&ec2.AllocateAddressInput{
+		Domain: aws.String("vpc"),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeElasticIp),
+				Tags:         converters.MapToTags(tags),
+			},
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAllocateEIP", "Failed to allocate Elastic IP for %q: %v", infrav1.BastionRoleTagValue, err)
+		return nil, errors.Wrap(err, "failed to allocate Elastic IP")
+	}
+
+	return &ec2.Address{
+		AllocationId: out.AllocationId,
+		PublicIp:     out.PublicIp,
+	}, nil
+}
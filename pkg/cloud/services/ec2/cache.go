@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	awsclient "github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	awsmetrics "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/metrics"
+)
+
+// DefaultLookupCacheTTL is the default time for which the results of image and instance type lookups are cached.
+const DefaultLookupCacheTTL = time.Hour
+
+var (
+	lookupCacheTTLMu sync.RWMutex
+	lookupCacheTTL   = DefaultLookupCacheTTL
+
+	// imageLookupCache caches the IDs of the AMIs found by name, owner and architecture.
+	imageLookupCache = newLookupCache("image_lookup")
+
+	// imageCache caches the description of AMIs by ID, used for their root device.
+	imageCache = newLookupCache("image")
+
+	// instanceTypeCache caches the description of instance types.
+	instanceTypeCache = newLookupCache("instance_type")
+)
+
+// SetLookupCacheTTL sets the time for which the results of image and instance type lookups are cached
+// and shared between reconciles. A TTL of 0 disables caching.
+func SetLookupCacheTTL(ttl time.Duration) {
+	lookupCacheTTLMu.Lock()
+	defer lookupCacheTTLMu.Unlock()
+	lookupCacheTTL = ttl
+}
+
+func getLookupCacheTTL() time.Duration {
+	lookupCacheTTLMu.RLock()
+	defer lookupCacheTTLMu.RUnlock()
+	return lookupCacheTTL
+}
+
+// InvalidateLookupCaches removes the cached results of image and instance type lookups in a region, for all identities.
+func InvalidateLookupCaches(region string) {
+	for _, c := range []*lookupCache{imageLookupCache, imageCache, instanceTypeCache} {
+		c.invalidate(region)
+	}
+}
+
+type lookupCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// lookupCache is a TTL cache of AWS lookups shared by all reconciles. Entries are keyed by region and
+// identity, so clusters reconciled with different credentials never see each other's results.
+type lookupCache struct {
+	name string
+
+	mu      sync.Mutex
+	entries map[string]lookupCacheEntry
+}
+
+func newLookupCache(name string) *lookupCache {
+	return &lookupCache{
+		name:    name,
+		entries: map[string]lookupCacheEntry{},
+	}
+}
+
+// lookupCacheIdentity returns the identity the lookups of the service are cached for.
+func (s *Service) lookupCacheIdentity() string {
+	return sessionIdentity(s.scope.Session())
+}
+
+// sessionIdentity returns the identity of the credentials of a session. Sessions are shared between
+// reconciles, so their credentials identify the AWS identity used for the lookups.
+func sessionIdentity(sess awsclient.ConfigProvider) string {
+	if s, ok := sess.(*session.Session); ok && s.Config.Credentials != nil {
+		return fmt.Sprintf("%p", s.Config.Credentials)
+	}
+	return ""
+}
+
+func lookupCacheKey(region, identity string, parts ...string) string {
+	return region + "/" + identity + "/" + strings.Join(parts, "/")
+}
+
+// get returns the value cached for key in region for identity, and records the hit or miss.
+func (c *lookupCache) get(region, identity string, parts ...string) (interface{}, bool) {
+	key := lookupCacheKey(region, identity, parts...)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	awsmetrics.RecordLookupCacheRequest(c.name, region, ok)
+	return entry.value, ok
+}
+
+// set caches value for key in region for identity, unless caching is disabled.
+func (c *lookupCache) set(value interface{}, region, identity string, parts ...string) {
+	ttl := getLookupCacheTTL()
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[lookupCacheKey(region, identity, parts...)] = lookupCacheEntry{value: value, expires: time.Now().Add(ttl)}
+}
+
+// invalidate removes the entries cached for region, for all identities.
+func (c *lookupCache) invalidate(region string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, region+"/") {
+			delete(c.entries, key)
+		}
+	}
+}
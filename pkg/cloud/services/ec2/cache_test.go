@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+)
+
+func init() {
+	// Lookups are cached across services, so caching is only enabled by the tests which cover it.
+	SetLookupCacheTTL(0)
+}
+
+// enableLookupCaches enables caching of lookups, and returns a function disabling it and clearing the caches.
+func enableLookupCaches() func() {
+	SetLookupCacheTTL(DefaultLookupCacheTTL)
+	return func() {
+		SetLookupCacheTTL(0)
+		for _, c := range []*lookupCache{imageLookupCache, imageCache, instanceTypeCache} {
+			c.entries = map[string]lookupCacheEntry{}
+		}
+	}
+}
+
+func TestLookupCache(t *testing.T) {
+	g := NewWithT(t)
+	defer enableLookupCaches()()
+
+	c := newLookupCache("test")
+
+	c.set("ami-1", "us-east-1", "identity-a", "image")
+	c.set("ami-2", "us-west-2", "identity-a", "image")
+	c.set("ami-3", "us-east-1", "identity-b", "image")
+
+	value, ok := c.get("us-east-1", "identity-a", "image")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(value).To(Equal("ami-1"))
+
+	// Identities do not share results.
+	value, ok = c.get("us-east-1", "identity-b", "image")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(value).To(Equal("ami-3"))
+	_, ok = c.get("us-west-2", "identity-b", "image")
+	g.Expect(ok).To(BeFalse())
+
+	_, ok = c.get("eu-west-1", "identity-a", "image")
+	g.Expect(ok).To(BeFalse())
+
+	// Invalidating a region removes the entries of all identities.
+	c.invalidate("us-east-1")
+	_, ok = c.get("us-east-1", "identity-a", "image")
+	g.Expect(ok).To(BeFalse())
+	_, ok = c.get("us-east-1", "identity-b", "image")
+	g.Expect(ok).To(BeFalse())
+	_, ok = c.get("us-west-2", "identity-a", "image")
+	g.Expect(ok).To(BeTrue())
+
+	// Expired entries are removed.
+	c.entries[lookupCacheKey("us-west-2", "identity-a", "image")] = lookupCacheEntry{value: "ami-2", expires: time.Now().Add(-time.Second)}
+	_, ok = c.get("us-west-2", "identity-a", "image")
+	g.Expect(ok).To(BeFalse())
+	g.Expect(c.entries).To(BeEmpty())
+
+	// Nothing is cached when caching is disabled.
+	SetLookupCacheTTL(0)
+	c.set("ami-1", "us-east-1", "identity-a", "image")
+	_, ok = c.get("us-east-1", "identity-a", "image")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestSessionIdentity(t *testing.T) {
+	g := NewWithT(t)
+
+	a := session.Must(session.NewSession(&aws.Config{Credentials: credentials.NewStaticCredentials("a", "secret", "")}))
+	b := session.Must(session.NewSession(&aws.Config{Credentials: credentials.NewStaticCredentials("b", "secret", "")}))
+
+	g.Expect(sessionIdentity(a)).To(Equal(sessionIdentity(a)))
+	g.Expect(sessionIdentity(a)).NotTo(Equal(sessionIdentity(b)))
+}
+
+func TestDescribeImageCache(t *testing.T) {
+	g := NewWithT(t)
+	defer enableLookupCaches()()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	s := newClusterTestService(g, ec2Mock)
+
+	ec2Mock.EXPECT().DescribeImages(gomock.Eq(&ec2.DescribeImagesInput{ImageIds: aws.StringSlice([]string{"ami-1"})})).
+		Return(&ec2.DescribeImagesOutput{
+			Images: []*ec2.Image{
+				{
+					ImageId:        aws.String("ami-1"),
+					RootDeviceName: aws.String("/dev/sda1"),
+					BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+						{DeviceName: aws.String("/dev/sda1"), Ebs: &ec2.EbsBlockDevice{VolumeSize: aws.Int64(8)}},
+					},
+				},
+			},
+		}, nil).
+		Times(1)
+
+	rootDeviceName, err := s.checkRootVolume(&infrav1.Volume{Size: 8}, "ami-1")
+	g.Expect(err).To(BeNil())
+	g.Expect(aws.StringValue(rootDeviceName)).To(Equal("/dev/sda1"))
+
+	rootDeviceName, err = s.checkRootVolume(&infrav1.Volume{Size: 8}, "ami-1")
+	g.Expect(err).To(BeNil())
+	g.Expect(aws.StringValue(rootDeviceName)).To(Equal("/dev/sda1"))
+
+	InvalidateLookupCaches(s.scope.Region())
+	ec2Mock.EXPECT().DescribeImages(gomock.Any()).Return(nil, errors.New("RequestLimitExceeded"))
+	_, err = s.checkRootVolume(&infrav1.Volume{Size: 8}, "ami-1")
+	g.Expect(err).NotTo(BeNil())
+}
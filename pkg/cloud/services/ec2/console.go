@@ -45,3 +45,23 @@ func (s *Service) GetConsoleOutput(instanceID string) (string, error) {
 
 	return string(data), nil
 }
+
+// GetConsoleScreenshot returns a JPG screenshot of the console of an instance
+func (s *Service) GetConsoleScreenshot(instanceID string) ([]byte, error) {
+	input := &ec2.GetConsoleScreenshotInput{
+		InstanceId: aws.String(instanceID),
+		WakeUp:     aws.Bool(true),
+	}
+
+	out, err := s.EC2Client.GetConsoleScreenshot(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get console screenshot for instance %q", instanceID)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(aws.StringValue(out.ImageData))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode console screenshot for instance %q", instanceID)
+	}
+
+	return data, nil
+}
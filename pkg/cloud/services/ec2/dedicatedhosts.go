@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// dedicatedHostReleaseGracePeriod protects freshly allocated hosts from being released
+// before the instance they were allocated for has been launched onto them.
+const dedicatedHostReleaseGracePeriod = 10 * time.Minute
+
+// getOrAllocateDedicatedHost returns the ID of a cluster-owned Dedicated Host in the availability zone
+// with free capacity for the instance type, allocating a new host if none is available.
+func (s *Service) getOrAllocateDedicatedHost(availabilityZone, instanceType string) (string, error) {
+	hosts, err := s.describeOwnedDedicatedHosts(
+		filter.EC2.AvailabilityZone(availabilityZone),
+		&ec2.Filter{
+			Name:   aws.String("state"),
+			Values: aws.StringSlice([]string{ec2.AllocationStateAvailable}),
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	for _, host := range hosts {
+		if host.AvailableCapacity == nil {
+			continue
+		}
+		for _, capacity := range host.AvailableCapacity.AvailableInstanceCapacity {
+			if aws.StringValue(capacity.InstanceType) == instanceType && aws.Int64Value(capacity.AvailableCapacity) > 0 {
+				return aws.StringValue(host.HostId), nil
+			}
+		}
+	}
+
+	out, err := s.EC2Client.AllocateHosts(&ec2.AllocateHostsInput{
+		AutoPlacement:    aws.String(ec2.AutoPlacementOff),
+		AvailabilityZone: aws.String(availabilityZone),
+		InstanceType:     aws.String(instanceType),
+		Quantity:         aws.Int64(1),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeDedicatedHost, infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(fmt.Sprintf("%s-%s", s.scope.Name(), availabilityZone)),
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAllocateDedicatedHost", "Failed to allocate Dedicated Host for instance type %q in %q: %v", instanceType, availabilityZone, err)
+		return "", errors.Wrapf(err, "failed to allocate dedicated host for instance type %q in %q", instanceType, availabilityZone)
+	}
+
+	if len(out.HostIds) == 0 {
+		return "", errors.Errorf("no dedicated host allocated for instance type %q in %q", instanceType, availabilityZone)
+	}
+
+	hostID := aws.StringValue(out.HostIds[0])
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAllocateDedicatedHost", "Allocated Dedicated Host %q for instance type %q in %q", hostID, instanceType, availabilityZone)
+	s.scope.V(2).Info("Allocated dedicated host", "host-id", hostID, "instance-type", instanceType, "availability-zone", availabilityZone)
+
+	return hostID, nil
+}
+
+// ReleaseDedicatedHosts releases cluster-owned Dedicated Hosts that no longer run any instances.
+// Recently allocated hosts are kept, as they may be about to receive an instance.
+func (s *Service) ReleaseDedicatedHosts() error {
+	_, err := s.releaseDedicatedHosts(dedicatedHostReleaseGracePeriod)
+	return err
+}
+
+// DeleteDedicatedHosts releases all cluster-owned Dedicated Hosts that no longer run any instances.
+// It fails while hosts still run instances, e.g. of machines being deleted, so that they are released
+// once their instances are terminated rather than left behind.
+func (s *Service) DeleteDedicatedHosts() error {
+	busy, err := s.releaseDedicatedHosts(0)
+	if err != nil {
+		return err
+	}
+	if len(busy) > 0 {
+		return errors.Errorf("dedicated hosts %s still run instances", strings.Join(busy, ", "))
+	}
+	return nil
+}
+
+// releaseDedicatedHosts releases the available hosts without instances allocated longer than the
+// grace period ago, and returns the IDs of the hosts kept as they still run instances.
+func (s *Service) releaseDedicatedHosts(gracePeriod time.Duration) ([]string, error) {
+	hosts, err := s.describeOwnedDedicatedHosts()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []*string
+	var busy []string
+	for _, host := range hosts {
+		if len(host.Instances) > 0 {
+			busy = append(busy, aws.StringValue(host.HostId))
+			continue
+		}
+		if aws.StringValue(host.State) != ec2.AllocationStateAvailable {
+			continue
+		}
+		if host.AllocationTime != nil && time.Since(*host.AllocationTime) < gracePeriod {
+			continue
+		}
+		ids = append(ids, host.HostId)
+	}
+
+	if len(ids) == 0 {
+		return busy, nil
+	}
+
+	out, err := s.EC2Client.ReleaseHosts(&ec2.ReleaseHostsInput{HostIds: ids})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedReleaseDedicatedHosts", "Failed to release Dedicated Hosts: %v", err)
+		return nil, errors.Wrap(err, "failed to release dedicated hosts")
+	}
+
+	for _, id := range out.Successful {
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulReleaseDedicatedHost", "Released Dedicated Host %q", aws.StringValue(id))
+		s.scope.V(2).Info("Released dedicated host", "host-id", aws.StringValue(id))
+	}
+
+	if len(out.Unsuccessful) > 0 {
+		item := out.Unsuccessful[0]
+		return nil, errors.Errorf("failed to release dedicated host %q: %s", aws.StringValue(item.ResourceId), aws.StringValue(item.Error.Message))
+	}
+
+	return busy, nil
+}
+
+func (s *Service) describeOwnedDedicatedHosts(filters ...*ec2.Filter) ([]*ec2.Host, error) {
+	input := &ec2.DescribeHostsInput{
+		Filter: append([]*ec2.Filter{filter.EC2.ClusterOwned(s.scope.Name())}, filters...),
+	}
+
+	var hosts []*ec2.Host
+	err := s.EC2Client.DescribeHostsPages(input,
+		func(page *ec2.DescribeHostsOutput, lastPage bool) bool {
+			hosts = append(hosts, page.Hosts...)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe dedicated hosts")
+	}
+
+	return hosts, nil
+}
+
+func (s *Service) getSubnetAvailabilityZone(subnetID string) (string, error) {
+	if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil && subnet.AvailabilityZone != "" {
+		return subnet.AvailabilityZone, nil
+	}
+
+	out, err := s.EC2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice([]string{subnetID}),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe subnet %q", subnetID)
+	}
+
+	if len(out.Subnets) == 0 {
+		return "", errors.Errorf("subnet %q not found", subnetID)
+	}
+
+	return aws.StringValue(out.Subnets[0].AvailabilityZone), nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+)
+
+func TestGetOrAllocateDedicatedHost(t *testing.T) {
+	tests := []struct {
+		name         string
+		expect       func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectHostID string
+		expectError  bool
+	}{
+		{
+			name: "reuses a host with free capacity",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeHostsPages(gomock.Any(), gomock.Any()).
+					Do(func(_ *ec2.DescribeHostsInput, fn func(*ec2.DescribeHostsOutput, bool) bool) {
+						fn(&ec2.DescribeHostsOutput{
+							Hosts: []*ec2.Host{
+								{
+									HostId: aws.String("h-full"),
+									AvailableCapacity: &ec2.AvailableCapacity{
+										AvailableInstanceCapacity: []*ec2.InstanceCapacity{
+											{InstanceType: aws.String("m5.large"), AvailableCapacity: aws.Int64(0)},
+										},
+									},
+								},
+								{
+									HostId: aws.String("h-free"),
+									AvailableCapacity: &ec2.AvailableCapacity{
+										AvailableInstanceCapacity: []*ec2.InstanceCapacity{
+											{InstanceType: aws.String("m5.large"), AvailableCapacity: aws.Int64(2)},
+										},
+									},
+								},
+							},
+						}, true)
+					}).
+					Return(nil)
+			},
+			expectHostID: "h-free",
+		},
+		{
+			name: "allocates a host when none has capacity",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeHostsPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.AllocateHosts(gomock.Any()).
+					DoAndReturn(func(input *ec2.AllocateHostsInput) (*ec2.AllocateHostsOutput, error) {
+						if aws.StringValue(input.AvailabilityZone) != "us-east-1a" || aws.StringValue(input.InstanceType) != "m5.large" {
+							t.Fatalf("unexpected allocation request: %v", input)
+						}
+						return &ec2.AllocateHostsOutput{HostIds: aws.StringSlice([]string{"h-new"})}, nil
+					})
+			},
+			expectHostID: "h-new",
+		},
+		{
+			name: "allocation fails",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeHostsPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.AllocateHosts(gomock.Any()).Return(nil, errors.New("InsufficientHostCapacity"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s := newClusterTestService(g, ec2Mock)
+			tc.expect(ec2Mock.EXPECT())
+
+			hostID, err := s.getOrAllocateDedicatedHost("us-east-1a", "m5.large")
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(hostID).To(Equal(tc.expectHostID))
+		})
+	}
+}
+
+func TestReleaseDedicatedHosts(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	recent := time.Now()
+
+	hosts := []*ec2.Host{
+		{
+			HostId:         aws.String("h-empty"),
+			State:          aws.String(ec2.AllocationStateAvailable),
+			AllocationTime: &old,
+		},
+		{
+			HostId:         aws.String("h-busy"),
+			State:          aws.String(ec2.AllocationStateAvailable),
+			AllocationTime: &old,
+			Instances:      []*ec2.HostInstance{{InstanceId: aws.String("i-1")}},
+		},
+		{
+			HostId:         aws.String("h-recent"),
+			State:          aws.String(ec2.AllocationStateAvailable),
+			AllocationTime: &recent,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		hosts       []*ec2.Host
+		release     func(s *Service) error
+		expectIDs   []string
+		releaseErr  error
+		expectError bool
+	}{
+		{
+			name:      "releases empty hosts outside of the grace period",
+			release:   func(s *Service) error { return s.ReleaseDedicatedHosts() },
+			expectIDs: []string{"h-empty"},
+		},
+		{
+			name:        "cluster deletion releases all empty hosts and waits for hosts running instances",
+			release:     func(s *Service) error { return s.DeleteDedicatedHosts() },
+			expectIDs:   []string{"h-empty", "h-recent"},
+			expectError: true,
+		},
+		{
+			name:      "cluster deletion succeeds once no host runs instances",
+			hosts:     hosts[:1],
+			release:   func(s *Service) error { return s.DeleteDedicatedHosts() },
+			expectIDs: []string{"h-empty"},
+		},
+		{
+			name:        "release fails",
+			release:     func(s *Service) error { return s.ReleaseDedicatedHosts() },
+			expectIDs:   []string{"h-empty"},
+			releaseErr:  errors.New("some error"),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s := newClusterTestService(g, ec2Mock)
+			if tc.hosts == nil {
+				tc.hosts = hosts
+			}
+			ec2Mock.EXPECT().DescribeHostsPages(gomock.Any(), gomock.Any()).
+				Do(func(_ *ec2.DescribeHostsInput, fn func(*ec2.DescribeHostsOutput, bool) bool) {
+					fn(&ec2.DescribeHostsOutput{Hosts: tc.hosts}, true)
+				}).
+				Return(nil)
+			ec2Mock.EXPECT().ReleaseHosts(gomock.Eq(&ec2.ReleaseHostsInput{HostIds: aws.StringSlice(tc.expectIDs)})).
+				Return(&ec2.ReleaseHostsOutput{Successful: aws.StringSlice(tc.expectIDs)}, tc.releaseErr)
+
+			err := tc.release(s)
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// volumeStatusWarning is the status of a volume which is degraded or severely degraded.
+	// It is not part of the SummaryStatus enum used for instance status checks.
+	volumeStatusWarning = "warning"
+
+	// timeFormat is the format of the times reported in condition messages and events.
+	timeFormat = time.RFC3339
+
+	// instanceHealthCheckInterval is the minimum interval between two descriptions of the status checks
+	// and scheduled events of an instance, which change far less often than machines are reconciled.
+	instanceHealthCheckInterval = 5 * time.Minute
+)
+
+// ReconcileInstanceHealth surfaces the EC2 status checks of the instance, and of the EBS volumes attached to it,
+// as conditions on the AWSMachine, and records the events AWS has scheduled for the instance in its status.
+// The volumes are the ones attached to the instance, as described by ReconcileVolumes. The health of the
+// instance is described at most once per health check interval.
+func (s *Service) ReconcileInstanceHealth(scope *scope.MachineScope, instance *infrav1.Instance, volumeIDs []string) error {
+	lastCheck := scope.AWSMachine.Status.LastHealthCheck
+	if lastCheck != nil && time.Since(lastCheck.Time) < instanceHealthCheckInterval {
+		return nil
+	}
+
+	out, err := s.EC2Client.DescribeInstanceStatus(&ec2.DescribeInstanceStatusInput{
+		InstanceIds:         aws.StringSlice([]string{instance.ID}),
+		IncludeAllInstances: aws.Bool(true),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe status of instance %q", instance.ID)
+	}
+
+	var status *ec2.InstanceStatus
+	if len(out.InstanceStatuses) > 0 {
+		status = out.InstanceStatuses[0]
+	}
+
+	if status == nil || aws.StringValue(status.InstanceState.Name) != ec2.InstanceStateNameRunning {
+		// Status checks are only run for running instances.
+		conditions.Delete(scope.AWSMachine, infrav1.SystemStatusCheckPassedCondition)
+		conditions.Delete(scope.AWSMachine, infrav1.InstanceStatusCheckPassedCondition)
+		conditions.Delete(scope.AWSMachine, infrav1.EBSStatusCheckPassedCondition)
+	} else {
+		setStatusCheckCondition(scope, infrav1.SystemStatusCheckPassedCondition, status.SystemStatus)
+		setStatusCheckCondition(scope, infrav1.InstanceStatusCheckPassedCondition, status.InstanceStatus)
+
+		volumeStatus, err := s.describeVolumeStatus(instance.ID, volumeIDs)
+		if err != nil {
+			return err
+		}
+		setStatusCheckCondition(scope, infrav1.EBSStatusCheckPassedCondition, volumeStatus)
+	}
+
+	var events []*ec2.InstanceStatusEvent
+	if status != nil {
+		events = status.Events
+	}
+	s.setScheduledEvents(scope, events)
+
+	scope.AWSMachine.Status.LastHealthCheck = &metav1.Time{Time: time.Now()}
+	return nil
+}
+
+// setStatusCheckCondition maps the summary of a status check to a condition. Failing checks are
+// listed in the message of the condition.
+func setStatusCheckCondition(scope *scope.MachineScope, conditionType clusterv1.ConditionType, summary *ec2.InstanceStatusSummary) {
+	if summary == nil {
+		conditions.Delete(scope.AWSMachine, conditionType)
+		return
+	}
+
+	switch aws.StringValue(summary.Status) {
+	case ec2.SummaryStatusOk:
+		conditions.MarkTrue(scope.AWSMachine, conditionType)
+	case ec2.SummaryStatusImpaired:
+		conditions.MarkFalse(scope.AWSMachine, conditionType, infrav1.StatusCheckImpairedReason, clusterv1.ConditionSeverityError, failedStatusChecks(summary))
+	case volumeStatusWarning:
+		conditions.MarkFalse(scope.AWSMachine, conditionType, infrav1.StatusCheckWarningReason, clusterv1.ConditionSeverityWarning, failedStatusChecks(summary))
+	case ec2.SummaryStatusInitializing:
+		conditions.MarkFalse(scope.AWSMachine, conditionType, infrav1.StatusCheckInitializingReason, clusterv1.ConditionSeverityInfo, "")
+	case ec2.SummaryStatusInsufficientData:
+		conditions.MarkFalse(scope.AWSMachine, conditionType, infrav1.StatusCheckInsufficientDataReason, clusterv1.ConditionSeverityInfo, "")
+	default:
+		conditions.Delete(scope.AWSMachine, conditionType)
+	}
+}
+
+func failedStatusChecks(summary *ec2.InstanceStatusSummary) string {
+	var failed []string
+	for _, detail := range summary.Details {
+		if aws.StringValue(detail.Status) == ec2.StatusTypePassed {
+			continue
+		}
+		check := fmt.Sprintf("%s %s", aws.StringValue(detail.Name), aws.StringValue(detail.Status))
+		if detail.ImpairedSince != nil {
+			check += fmt.Sprintf(" since %s", detail.ImpairedSince.UTC().Format(timeFormat))
+		}
+		failed = append(failed, check)
+	}
+	return strings.Join(failed, ", ")
+}
+
+// describeVolumeStatus summarizes the status checks of the volumes attached to an instance,
+// reporting the worst status of all volumes.
+func (s *Service) describeVolumeStatus(instanceID string, volumeIDs []string) (*ec2.InstanceStatusSummary, error) {
+	if len(volumeIDs) == 0 {
+		return nil, nil
+	}
+
+	var statuses []*ec2.VolumeStatusItem
+	err := s.EC2Client.DescribeVolumeStatusPages(&ec2.DescribeVolumeStatusInput{VolumeIds: aws.StringSlice(volumeIDs)},
+		func(page *ec2.DescribeVolumeStatusOutput, lastPage bool) bool {
+			statuses = append(statuses, page.VolumeStatuses...)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe status of volumes of instance %q", instanceID)
+	}
+
+	// Statuses ordered from best to worst.
+	severity := map[string]int{
+		ec2.VolumeStatusInfoStatusOk:               0,
+		ec2.VolumeStatusInfoStatusInsufficientData: 1,
+		volumeStatusWarning:                        2,
+		ec2.VolumeStatusInfoStatusImpaired:         3,
+	}
+
+	summary := &ec2.InstanceStatusSummary{Status: aws.String(ec2.SummaryStatusOk)}
+	for _, item := range statuses {
+		if item.VolumeStatus == nil {
+			continue
+		}
+		status := aws.StringValue(item.VolumeStatus.Status)
+		if severity[status] > severity[aws.StringValue(summary.Status)] {
+			summary.Status = aws.String(status)
+		}
+		for _, detail := range item.VolumeStatus.Details {
+			summary.Details = append(summary.Details, &ec2.InstanceStatusDetails{
+				Name:   aws.String(fmt.Sprintf("%s %s", aws.StringValue(item.VolumeId), aws.StringValue(detail.Name))),
+				Status: detail.Status,
+			})
+		}
+	}
+
+	return summary, nil
+}
+
+// setScheduledEvents records the upcoming events scheduled for the instance in the status of the AWSMachine,
+// and reports newly scheduled events.
+func (s *Service) setScheduledEvents(scope *scope.MachineScope, events []*ec2.InstanceStatusEvent) {
+	known := make(map[string]bool, len(scope.AWSMachine.Status.ScheduledEvents))
+	for _, event := range scope.AWSMachine.Status.ScheduledEvents {
+		known[event.ID] = true
+	}
+
+	var scheduled []infrav1.InstanceScheduledEvent
+	var messages []string
+	for _, event := range events {
+		// Completed and canceled events are kept by AWS for a while, with a description prefixed accordingly.
+		description := aws.StringValue(event.Description)
+		if strings.HasPrefix(description, "[Completed]") || strings.HasPrefix(description, "[Canceled]") {
+			continue
+		}
+
+		e := infrav1.InstanceScheduledEvent{
+			ID:          aws.StringValue(event.InstanceEventId),
+			Code:        aws.StringValue(event.Code),
+			Description: description,
+		}
+		if event.NotBefore != nil {
+			e.NotBefore = &metav1.Time{Time: *event.NotBefore}
+		}
+		if event.NotAfter != nil {
+			e.NotAfter = &metav1.Time{Time: *event.NotAfter}
+		}
+		scheduled = append(scheduled, e)
+
+		message := fmt.Sprintf("%s scheduled", e.Code)
+		if e.NotBefore != nil {
+			message += fmt.Sprintf(" after %s", e.NotBefore.UTC().Format(timeFormat))
+		}
+		messages = append(messages, message)
+
+		if !known[e.ID] {
+			record.Warnf(scope.AWSMachine, "InstanceEventScheduled", "Event %s for instance %q: %s", message, aws.StringValue(scope.GetInstanceID()), e.Description)
+		}
+	}
+
+	scope.SetScheduledEvents(scheduled)
+
+	if len(scheduled) == 0 {
+		conditions.MarkTrue(scope.AWSMachine, infrav1.NoScheduledEventsCondition)
+		return
+	}
+	conditions.MarkFalse(scope.AWSMachine, infrav1.NoScheduledEventsCondition, infrav1.InstanceEventScheduledReason, clusterv1.ConditionSeverityWarning, strings.Join(messages, ", "))
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileInstanceHealth(t *testing.T) {
+	instance := &infrav1.Instance{ID: "i-1"}
+	retirement := time.Date(2026, time.November, 2, 10, 0, 0, 0, time.UTC)
+
+	summary := func(status string, details ...*ec2.InstanceStatusDetails) *ec2.InstanceStatusSummary {
+		return &ec2.InstanceStatusSummary{Status: aws.String(status), Details: details}
+	}
+	instanceStatus := func(state string, system, inst *ec2.InstanceStatusSummary, events ...*ec2.InstanceStatusEvent) *ec2.DescribeInstanceStatusOutput {
+		return &ec2.DescribeInstanceStatusOutput{
+			InstanceStatuses: []*ec2.InstanceStatus{
+				{
+					InstanceId:     aws.String("i-1"),
+					InstanceState:  &ec2.InstanceState{Name: aws.String(state)},
+					SystemStatus:   system,
+					InstanceStatus: inst,
+					Events:         events,
+				},
+			},
+		}
+	}
+	expectVolumeStatus := func(m *mock_ec2iface.MockEC2APIMockRecorder, status string) {
+		m.DescribeVolumeStatusPages(gomock.Eq(&ec2.DescribeVolumeStatusInput{VolumeIds: aws.StringSlice([]string{"vol-1"})}), gomock.Any()).
+			Do(func(_ *ec2.DescribeVolumeStatusInput, fn func(*ec2.DescribeVolumeStatusOutput, bool) bool) {
+				fn(&ec2.DescribeVolumeStatusOutput{
+					VolumeStatuses: []*ec2.VolumeStatusItem{
+						{
+							VolumeId: aws.String("vol-1"),
+							VolumeStatus: &ec2.VolumeStatusInfo{
+								Status: aws.String(status),
+								Details: []*ec2.VolumeStatusDetails{
+									{Name: aws.String("io-enabled"), Status: aws.String(ec2.StatusTypePassed)},
+								},
+							},
+						},
+					},
+				}, true)
+			}).
+			Return(nil)
+	}
+
+	tests := []struct {
+		name        string
+		lastCheck   *metav1.Time
+		expect      func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectError bool
+		check       func(g *WithT, s *scope.MachineScope)
+	}{
+		{
+			name: "healthy running instance",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceStatus(gomock.Eq(&ec2.DescribeInstanceStatusInput{
+					InstanceIds:         aws.StringSlice([]string{"i-1"}),
+					IncludeAllInstances: aws.Bool(true),
+				})).
+					Return(instanceStatus(ec2.InstanceStateNameRunning, summary(ec2.SummaryStatusOk), summary(ec2.SummaryStatusOk)), nil)
+				expectVolumeStatus(m, ec2.VolumeStatusInfoStatusOk)
+			},
+			check: func(g *WithT, s *scope.MachineScope) {
+				g.Expect(conditions.IsTrue(s.AWSMachine, infrav1.SystemStatusCheckPassedCondition)).To(BeTrue())
+				g.Expect(conditions.IsTrue(s.AWSMachine, infrav1.InstanceStatusCheckPassedCondition)).To(BeTrue())
+				g.Expect(conditions.IsTrue(s.AWSMachine, infrav1.EBSStatusCheckPassedCondition)).To(BeTrue())
+				g.Expect(conditions.IsTrue(s.AWSMachine, infrav1.NoScheduledEventsCondition)).To(BeTrue())
+				g.Expect(s.AWSMachine.Status.ScheduledEvents).To(BeEmpty())
+			},
+		},
+		{
+			name: "impaired system and degraded volumes",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceStatus(gomock.Any()).
+					Return(instanceStatus(ec2.InstanceStateNameRunning,
+						summary(ec2.SummaryStatusImpaired, &ec2.InstanceStatusDetails{
+							Name:          aws.String(ec2.StatusNameReachability),
+							Status:        aws.String(ec2.StatusTypeFailed),
+							ImpairedSince: aws.Time(retirement),
+						}),
+						summary(ec2.SummaryStatusInitializing),
+					), nil)
+				expectVolumeStatus(m, "warning")
+			},
+			check: func(g *WithT, s *scope.MachineScope) {
+				g.Expect(conditions.Get(s.AWSMachine, infrav1.SystemStatusCheckPassedCondition).Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(conditions.GetReason(s.AWSMachine, infrav1.SystemStatusCheckPassedCondition)).To(Equal(infrav1.StatusCheckImpairedReason))
+				g.Expect(conditions.GetMessage(s.AWSMachine, infrav1.SystemStatusCheckPassedCondition)).To(Equal("reachability failed since 2026-11-02T10:00:00Z"))
+				g.Expect(conditions.GetReason(s.AWSMachine, infrav1.InstanceStatusCheckPassedCondition)).To(Equal(infrav1.StatusCheckInitializingReason))
+				g.Expect(conditions.GetReason(s.AWSMachine, infrav1.EBSStatusCheckPassedCondition)).To(Equal(infrav1.StatusCheckWarningReason))
+			},
+		},
+		{
+			name: "scheduled events of a stopped instance",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceStatus(gomock.Any()).
+					Return(instanceStatus(ec2.InstanceStateNameStopped,
+						summary("not-applicable"),
+						summary("not-applicable"),
+						&ec2.InstanceStatusEvent{
+							InstanceEventId: aws.String("instance-event-1"),
+							Code:            aws.String(ec2.EventCodeInstanceRetirement),
+							Description:     aws.String("The instance is running on degraded hardware"),
+							NotBefore:       aws.Time(retirement),
+						},
+						&ec2.InstanceStatusEvent{
+							InstanceEventId: aws.String("instance-event-0"),
+							Code:            aws.String(ec2.EventCodeSystemReboot),
+							Description:     aws.String("[Completed] Scheduled reboot"),
+						},
+					), nil)
+			},
+			check: func(g *WithT, s *scope.MachineScope) {
+				g.Expect(conditions.Has(s.AWSMachine, infrav1.SystemStatusCheckPassedCondition)).To(BeFalse())
+				g.Expect(conditions.Has(s.AWSMachine, infrav1.EBSStatusCheckPassedCondition)).To(BeFalse())
+				g.Expect(s.AWSMachine.Status.ScheduledEvents).To(HaveLen(1))
+				g.Expect(s.AWSMachine.Status.ScheduledEvents[0].Code).To(Equal(ec2.EventCodeInstanceRetirement))
+				g.Expect(s.AWSMachine.Status.ScheduledEvents[0].NotBefore.Time).To(Equal(retirement))
+				g.Expect(conditions.GetReason(s.AWSMachine, infrav1.NoScheduledEventsCondition)).To(Equal(infrav1.InstanceEventScheduledReason))
+				g.Expect(conditions.GetMessage(s.AWSMachine, infrav1.NoScheduledEventsCondition)).To(Equal("instance-retirement scheduled after 2026-11-02T10:00:00Z"))
+			},
+		},
+		{
+			name:      "recently checked instance",
+			lastCheck: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			expect:    func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			check: func(g *WithT, s *scope.MachineScope) {
+				g.Expect(conditions.Has(s.AWSMachine, infrav1.SystemStatusCheckPassedCondition)).To(BeFalse())
+				g.Expect(conditions.Has(s.AWSMachine, infrav1.NoScheduledEventsCondition)).To(BeFalse())
+			},
+		},
+		{
+			name:      "instance checked before the health check interval",
+			lastCheck: &metav1.Time{Time: time.Now().Add(-instanceHealthCheckInterval)},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceStatus(gomock.Any()).
+					Return(instanceStatus(ec2.InstanceStateNameRunning, summary(ec2.SummaryStatusOk), summary(ec2.SummaryStatusOk)), nil)
+				expectVolumeStatus(m, ec2.VolumeStatusInfoStatusOk)
+			},
+			check: func(g *WithT, s *scope.MachineScope) {
+				g.Expect(conditions.IsTrue(s.AWSMachine, infrav1.SystemStatusCheckPassedCondition)).To(BeTrue())
+				g.Expect(s.AWSMachine.Status.LastHealthCheck.Time).To(BeTemporally("~", time.Now(), time.Minute))
+			},
+		},
+		{
+			name: "describe fails",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstanceStatus(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s, machineScope := newMachineTestService(g, ec2Mock)
+			machineScope.AWSMachine.Status.LastHealthCheck = tc.lastCheck
+			tc.expect(ec2Mock.EXPECT())
+
+			err := s.ReconcileInstanceHealth(machineScope, instance, []string{"vol-1"})
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			tc.check(g, machineScope)
+		})
+	}
+}
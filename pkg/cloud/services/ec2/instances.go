@@ -129,42 +129,15 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte) (*i
 
 	var err error
 	// Pick image from the machine configuration, or use a default one.
-	if scope.AWSMachine.Spec.AMI.ID != nil { // nolint:nestif
+	if scope.AWSMachine.Spec.AMI.ID != nil {
 		input.ImageID = *scope.AWSMachine.Spec.AMI.ID
 	} else {
-		if scope.Machine.Spec.Version == nil {
-			err := errors.New("Either AWSMachine's spec.ami.id or Machine's spec.version must be defined")
-			scope.SetFailureReason(capierrors.CreateMachineError)
-			scope.SetFailureMessage(err)
+		image, err := s.resolveMachineImage(scope)
+		if err != nil {
 			return nil, err
 		}
-
-		if scope.IsEKSManaged() {
-			input.ImageID, err = s.eksAMILookup(*scope.Machine.Spec.Version)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			imageLookupFormat := scope.AWSMachine.Spec.ImageLookupFormat
-			if imageLookupFormat == "" {
-				imageLookupFormat = scope.InfraCluster.ImageLookupFormat()
-			}
-
-			imageLookupOrg := scope.AWSMachine.Spec.ImageLookupOrg
-			if imageLookupOrg == "" {
-				imageLookupOrg = scope.InfraCluster.ImageLookupOrg()
-			}
-
-			imageLookupBaseOS := scope.AWSMachine.Spec.ImageLookupBaseOS
-			if imageLookupBaseOS == "" {
-				imageLookupBaseOS = scope.InfraCluster.ImageLookupBaseOS()
-			}
-
-			input.ImageID, err = s.defaultAMILookup(imageLookupFormat, imageLookupOrg, imageLookupBaseOS, *scope.Machine.Spec.Version)
-			if err != nil {
-				return nil, err
-			}
-		}
+		input.ImageID = image.ID
+		scope.SetImage(image)
 	}
 
 	subnetID, err := s.findSubnet(scope)
@@ -178,7 +151,13 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte) (*i
 
 		return nil, awserrors.NewFailedDependency("failed to run controlplane, APIServer ELB not available")
 	}
-	if !scope.UserDataIsUncompressed() {
+	switch {
+	case scope.IsWindows():
+		// EC2Launch only runs uncompressed PowerShell userdata.
+		userData = userdata.PowerShell(userData)
+	case scope.UseIgnition():
+		// Ignition does not decompress userdata.
+	case !scope.UserDataIsUncompressed():
 		userData, err = userdata.GzipBytes(userData)
 		if err != nil {
 			return nil, errors.New("failed to gzip userdata")
@@ -222,6 +201,36 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte) (*i
 
 	input.Tenancy = scope.AWSMachine.Spec.Tenancy
 
+	input.CapacityReservation = scope.AWSMachine.Spec.CapacityReservation
+
+	if dh := scope.AWSMachine.Spec.DedicatedHost; dh != nil {
+		input.HostID = dh.ID
+		input.HostResourceGroupARN = dh.ResourceGroupARN
+		if dh.Affinity != "" {
+			input.HostAffinity = aws.String(string(dh.Affinity))
+		}
+
+		if dh.IsManaged() {
+			az, err := s.getSubnetAvailabilityZone(input.SubnetID)
+			if err != nil {
+				return nil, err
+			}
+			hostID, err := s.getOrAllocateDedicatedHost(az, input.Type)
+			if err != nil {
+				return nil, err
+			}
+			input.HostID = aws.String(hostID)
+		}
+	}
+
+	if pg := scope.AWSMachine.Spec.PlacementGroup; pg != nil {
+		if err := s.ReconcilePlacementGroup(pg); err != nil {
+			return nil, err
+		}
+		input.PlacementGroupName = pg.Name
+		input.PlacementGroupPartition = pg.PartitionNumber
+	}
+
 	s.scope.V(2).Info("Running instance", "machine-role", scope.Role())
 	out, err := s.runInstance(scope.Role(), input)
 	if err != nil {
@@ -393,6 +402,71 @@ func (s *Service) TerminateInstance(instanceID string) error {
 	return nil
 }
 
+// StopInstance stops an EC2 instance with the given ID.
+func (s *Service) StopInstance(instanceID string) error {
+	s.scope.V(2).Info("Attempting to stop instance", "instance-id", instanceID)
+
+	input := &ec2.StopInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	}
+
+	if _, err := s.EC2Client.StopInstances(input); err != nil {
+		return errors.Wrapf(err, "failed to stop instance with id %q", instanceID)
+	}
+
+	s.scope.V(2).Info("Stopped instance", "instance-id", instanceID)
+	return nil
+}
+
+// StartInstance starts a stopped EC2 instance with the given ID.
+func (s *Service) StartInstance(instanceID string) error {
+	s.scope.V(2).Info("Attempting to start instance", "instance-id", instanceID)
+
+	input := &ec2.StartInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	}
+
+	if _, err := s.EC2Client.StartInstances(input); err != nil {
+		return errors.Wrapf(err, "failed to start instance with id %q", instanceID)
+	}
+
+	s.scope.V(2).Info("Started instance", "instance-id", instanceID)
+	return nil
+}
+
+// RebootInstance reboots an EC2 instance with the given ID.
+func (s *Service) RebootInstance(instanceID string) error {
+	s.scope.V(2).Info("Attempting to reboot instance", "instance-id", instanceID)
+
+	input := &ec2.RebootInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	}
+
+	if _, err := s.EC2Client.RebootInstances(input); err != nil {
+		return errors.Wrapf(err, "failed to reboot instance with id %q", instanceID)
+	}
+
+	s.scope.V(2).Info("Rebooted instance", "instance-id", instanceID)
+	return nil
+}
+
+// ModifyInstanceType changes the instance type of a stopped EC2 instance with the given ID.
+func (s *Service) ModifyInstanceType(instanceID, instanceType string) error {
+	s.scope.V(2).Info("Attempting to modify instance type", "instance-id", instanceID, "instance-type", instanceType)
+
+	input := &ec2.ModifyInstanceAttributeInput{
+		InstanceId:   aws.String(instanceID),
+		InstanceType: &ec2.AttributeValue{Value: aws.String(instanceType)},
+	}
+
+	if _, err := s.EC2Client.ModifyInstanceAttribute(input); err != nil {
+		return errors.Wrapf(err, "failed to modify instance type of instance with id %q to %q", instanceID, instanceType)
+	}
+
+	s.scope.V(2).Info("Modified instance type", "instance-id", instanceID, "instance-type", instanceType)
+	return nil
+}
+
 // TerminateInstanceAndWait terminates and waits
 // for an EC2 instance to terminate.
 func (s *Service) TerminateInstanceAndWait(instanceID string) error {
@@ -469,6 +543,10 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 			ebsRootDevice.Iops = aws.Int64(i.RootVolume.IOPS)
 		}
 
+		if i.RootVolume.Throughput != nil {
+			ebsRootDevice.Throughput = i.RootVolume.Throughput
+		}
+
 		if i.RootVolume.EncryptionKey != "" {
 			ebsRootDevice.Encrypted = aws.Bool(true)
 			ebsRootDevice.KmsKeyId = aws.String(i.RootVolume.EncryptionKey)
@@ -500,6 +578,10 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 				ebsDevice.Iops = aws.Int64(nonRootVolume.IOPS)
 			}
 
+			if nonRootVolume.Throughput != nil {
+				ebsDevice.Throughput = nonRootVolume.Throughput
+			}
+
 			if nonRootVolume.EncryptionKey != "" {
 				ebsDevice.Encrypted = aws.Bool(true)
 				ebsDevice.KmsKeyId = aws.String(nonRootVolume.EncryptionKey)
@@ -536,14 +618,30 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 
 		input.TagSpecifications = append(input.TagSpecifications, spec)
+
+		// Tag the volumes launched with the instance with the same tags.
+		input.TagSpecifications = append(input.TagSpecifications, &ec2.TagSpecification{
+			ResourceType: aws.String(ec2.ResourceTypeVolume),
+			Tags:         spec.Tags,
+		})
 	}
 
 	input.InstanceMarketOptions = getInstanceMarketOptionsRequest(i.SpotMarketOptions)
 
-	if i.Tenancy != "" {
-		input.Placement = &ec2.Placement{
-			Tenancy: &i.Tenancy,
+	input.CapacityReservationSpecification = getCapacityReservationSpecification(i.CapacityReservation)
+
+	if i.Tenancy != "" || i.PlacementGroupName != "" {
+		input.Placement = &ec2.Placement{}
+		if i.Tenancy != "" {
+			input.Placement.Tenancy = &i.Tenancy
+		}
+		if i.PlacementGroupName != "" {
+			input.Placement.GroupName = aws.String(i.PlacementGroupName)
+			input.Placement.PartitionNumber = i.PlacementGroupPartition
 		}
+		input.Placement.HostId = i.HostID
+		input.Placement.HostResourceGroupArn = i.HostResourceGroupARN
+		input.Placement.Affinity = i.HostAffinity
 	}
 
 	out, err := s.EC2Client.RunInstances(input)
@@ -683,27 +781,42 @@ func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, e
 		return nil, err
 	}
 
-	return output.NetworkInterfaces, nil
+	// Secondary network interfaces managed by the provider have their own security groups.
+	enis := make([]*ec2.NetworkInterface, 0, len(output.NetworkInterfaces))
+	for _, eni := range output.NetworkInterfaces {
+		if converters.TagsToMap(eni.TagSet).GetRole() == infrav1.SecondaryNetworkInterfaceRoleTagValue {
+			continue
+		}
+		enis = append(enis, eni)
+	}
+
+	return enis, nil
 }
 
 func (s *Service) getImageRootDevice(imageID string) (*string, error) {
-	input := &ec2.DescribeImagesInput{
-		ImageIds: []*string{aws.String(imageID)},
-	}
-
-	output, err := s.EC2Client.DescribeImages(input)
+	image, err := s.describeImage(imageID)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(output.Images) == 0 {
-		return nil, errors.Errorf("no images returned when looking up ID %q", imageID)
+	return image.RootDeviceName, nil
+}
+
+func (s *Service) getImageSnapshotSize(imageID string) (*int64, error) {
+	image, err := s.describeImage(imageID)
+	if err != nil {
+		return nil, err
 	}
 
-	return output.Images[0].RootDeviceName, nil
+	return image.BlockDeviceMappings[0].Ebs.VolumeSize, nil
 }
 
-func (s *Service) getImageSnapshotSize(imageID string) (*int64, error) {
+// describeImage returns the description of an AMI, which is cached per region.
+func (s *Service) describeImage(imageID string) (*ec2.Image, error) {
+	if image, ok := imageCache.get(s.scope.Region(), s.lookupCacheIdentity(), imageID); ok {
+		return image.(*ec2.Image), nil
+	}
+
 	input := &ec2.DescribeImagesInput{
 		ImageIds: []*string{aws.String(imageID)},
 	}
@@ -717,7 +830,8 @@ func (s *Service) getImageSnapshotSize(imageID string) (*int64, error) {
 		return nil, errors.Errorf("no images returned when looking up ID %q", imageID)
 	}
 
-	return output.Images[0].BlockDeviceMappings[0].Ebs.VolumeSize, nil
+	imageCache.set(output.Images[0], s.scope.Region(), s.lookupCacheIdentity(), imageID)
+	return output.Images[0], nil
 }
 
 // SDKToInstance converts an AWS EC2 SDK instance to the CAPA instance type.
@@ -726,16 +840,17 @@ func (s *Service) getImageSnapshotSize(imageID string) (*int64, error) {
 // additional call to EC2 is required to get this value.
 func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 	i := &infrav1.Instance{
-		ID:           aws.StringValue(v.InstanceId),
-		State:        infrav1.InstanceState(*v.State.Name),
-		Type:         aws.StringValue(v.InstanceType),
-		SubnetID:     aws.StringValue(v.SubnetId),
-		ImageID:      aws.StringValue(v.ImageId),
-		SSHKeyName:   v.KeyName,
-		PrivateIP:    v.PrivateIpAddress,
-		PublicIP:     v.PublicIpAddress,
-		ENASupport:   v.EnaSupport,
-		EBSOptimized: v.EbsOptimized,
+		ID:             aws.StringValue(v.InstanceId),
+		State:          infrav1.InstanceState(*v.State.Name),
+		Type:           aws.StringValue(v.InstanceType),
+		SubnetID:       aws.StringValue(v.SubnetId),
+		ImageID:        aws.StringValue(v.ImageId),
+		SSHKeyName:     v.KeyName,
+		PrivateIP:      v.PrivateIpAddress,
+		PublicIP:       v.PublicIpAddress,
+		ENASupport:     v.EnaSupport,
+		EBSOptimized:   v.EbsOptimized,
+		RootDeviceName: aws.StringValue(v.RootDeviceName),
 	}
 
 	// Extract IAM Instance Profile name from ARN
@@ -759,6 +874,10 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 	i.Addresses = s.getInstanceAddresses(v)
 
 	i.AvailabilityZone = aws.StringValue(v.Placement.AvailabilityZone)
+	i.PlacementGroupName = aws.StringValue(v.Placement.GroupName)
+	i.PlacementGroupPartition = v.Placement.PartitionNumber
+	i.HostID = v.Placement.HostId
+	i.HostAffinity = v.Placement.Affinity
 
 	return i, nil
 }
@@ -868,6 +987,53 @@ func (s *Service) DetachSecurityGroupsFromNetworkInterface(groups []string, inte
 	return nil
 }
 
+// resolveMachineImage resolves the AMI for a machine without an AMI ID, reusing the AMI
+// resolved before for it or for other machines cloned from the same template.
+func (s *Service) resolveMachineImage(scope *scope.MachineScope) (*infrav1.ResolvedImage, error) {
+	lookup := imageLookup{SSMParameter: scope.AWSMachine.Spec.AMI.SSMParameter}
+	if lookup.SSMParameter == nil {
+		if scope.Machine.Spec.Version == nil {
+			err := errors.New("Either AWSMachine's spec.ami.id, spec.ami.ssmParameter or Machine's spec.version must be defined")
+			scope.SetFailureReason(capierrors.CreateMachineError)
+			scope.SetFailureMessage(err)
+			return nil, err
+		}
+
+		architecture, err := s.imageArchitecture(scope.AWSMachine.Spec.ImageLookupArchitecture, scope.AWSMachine.Spec.InstanceType)
+		if err != nil {
+			return nil, err
+		}
+
+		lookup.EKS = scope.IsEKSManaged()
+		lookup.Architecture = architecture
+		lookup.Platform = scope.AWSMachine.Spec.Platform
+		lookup.KubernetesVersion = *scope.Machine.Spec.Version
+		if !lookup.EKS {
+			lookup.Format = scope.AWSMachine.Spec.ImageLookupFormat
+			if lookup.Format == "" {
+				lookup.Format = scope.InfraCluster.ImageLookupFormat()
+			}
+
+			lookup.Org = scope.AWSMachine.Spec.ImageLookupOrg
+			if lookup.Org == "" {
+				lookup.Org = scope.InfraCluster.ImageLookupOrg()
+			}
+
+			lookup.BaseOS = scope.AWSMachine.Spec.ImageLookupBaseOS
+			if lookup.BaseOS == "" {
+				lookup.BaseOS = scope.InfraCluster.ImageLookupBaseOS()
+			}
+		}
+	}
+
+	templateImages, err := scope.TemplateImages()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.resolveImage(lookup, append([]*infrav1.ResolvedImage{scope.AWSMachine.Status.Image}, templateImages...)...)
+}
+
 // checkRootVolume checks the input root volume options against the requested AMI's defaults
 // and returns the AMI's root device name
 func (s *Service) checkRootVolume(rootVolume *infrav1.Volume, imageID string) (*string, error) {
@@ -937,3 +1103,29 @@ func getInstanceMarketOptionsRequest(spotMarketOptions *infrav1.SpotMarketOption
 
 	return instanceMarketOptionsRequest
 }
+
+func getCapacityReservationTarget(capacityReservation *infrav1.CapacityReservationSpec) *ec2.CapacityReservationTarget {
+	if !capacityReservation.IsTargeted() {
+		return nil
+	}
+
+	return &ec2.CapacityReservationTarget{
+		CapacityReservationId:               capacityReservation.ID,
+		CapacityReservationResourceGroupArn: capacityReservation.ResourceGroupARN,
+	}
+}
+
+func getCapacityReservationSpecification(capacityReservation *infrav1.CapacityReservationSpec) *ec2.CapacityReservationSpecification {
+	if capacityReservation == nil {
+		return nil
+	}
+
+	spec := &ec2.CapacityReservationSpecification{
+		CapacityReservationTarget: getCapacityReservationTarget(capacityReservation),
+	}
+	if capacityReservation.Preference != "" {
+		spec.CapacityReservationPreference = aws.String(string(capacityReservation.Preference))
+	}
+
+	return spec
+}
@@ -259,6 +259,98 @@ func TestTerminateInstance(t *testing.T) {
 	}
 }
 
+func TestChangeInstanceState(t *testing.T) {
+	tests := []struct {
+		name        string
+		call        func(s *Service) error
+		expect      func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "stop instance",
+			call: func(s *Service) error { return s.StopInstance("i-1") },
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.StopInstances(gomock.Eq(&ec2.StopInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})})).
+					Return(&ec2.StopInstancesOutput{}, nil)
+			},
+		},
+		{
+			name: "reboot instance",
+			call: func(s *Service) error { return s.RebootInstance("i-1") },
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.RebootInstances(gomock.Eq(&ec2.RebootInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})})).
+					Return(&ec2.RebootInstancesOutput{}, nil)
+			},
+		},
+		{
+			name: "modify instance type",
+			call: func(s *Service) error { return s.ModifyInstanceType("i-1", "m5.xlarge") },
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.ModifyInstanceAttribute(gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+					InstanceId:   aws.String("i-1"),
+					InstanceType: &ec2.AttributeValue{Value: aws.String("m5.xlarge")},
+				})).
+					Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+			},
+		},
+		{
+			name: "modify instance type fails",
+			call: func(s *Service) error { return s.ModifyInstanceType("i-1", "m5.xlarge") },
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.ModifyInstanceAttribute(gomock.Any()).
+					Return(nil, errors.New("IncorrectInstanceState"))
+			},
+			expectError: true,
+		},
+		{
+			name: "start instance",
+			call: func(s *Service) error { return s.StartInstance("i-1") },
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.StartInstances(gomock.Eq(&ec2.StartInstancesInput{InstanceIds: aws.StringSlice([]string{"i-1"})})).
+					Return(&ec2.StartInstancesOutput{}, nil)
+			},
+		},
+		{
+			name: "start instance fails",
+			call: func(s *Service) error { return s.StartInstance("i-1") },
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.StartInstances(gomock.Any()).
+					Return(nil, errors.New("InsufficientInstanceCapacity"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster:    &clusterv1.Cluster{},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = tc.call(s)
+			if tc.expectError && err == nil {
+				t.Fatal("expected error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
 func TestCreateInstance(t *testing.T) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -558,7 +650,7 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
-				amiName, err := amiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "v1.16.1")
+				amiName, err := amiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "x86_64", "v1.16.1")
 				if err != nil {
 					t.Fatalf("Failed to process ami format: %v", err)
 				}
@@ -687,7 +779,7 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
-				amiName, err := amiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "v1.16.1")
+				amiName, err := amiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "x86_64", "v1.16.1")
 				if err != nil {
 					t.Fatalf("Failed to process ami format: %v", err)
 				}
@@ -817,7 +909,7 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
-				amiName, err := amiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "v1.16.1")
+				amiName, err := amiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-18.04", "x86_64", "v1.16.1")
 				if err != nil {
 					t.Fatalf("Failed to process ami format: %v", err)
 				}
@@ -1222,6 +1314,31 @@ func TestCreateInstance(t *testing.T) {
 									},
 								},
 							},
+							{
+								ResourceType: aws.String("volume"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("default/machine-aws-test1"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
+									},
+								},
+							},
 						},
 						UserData: aws.String(base64.StdEncoding.EncodeToString(userData)),
 					})).
@@ -1263,6 +1380,117 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "with a managed placement group",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:    map[string]string{"set": "node"},
+					Namespace: "default",
+					Name:      "machine-aws-test1",
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.StringPtr("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				PlacementGroup: &infrav1.PlacementGroup{
+					Name:            "pg",
+					Strategy:        infrav1.PlacementGroupStrategyPartition,
+					PartitionNumber: aws.Int64(2),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							&infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.Network{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.ClassicELB{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribePlacementGroups(gomock.Any()).
+					Return(&ec2.DescribePlacementGroupsOutput{}, nil)
+				m.
+					CreatePlacementGroup(gomock.Any()).
+					Return(&ec2.CreatePlacementGroupOutput{}, nil)
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil).AnyTimes()
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if aws.StringValue(input.Placement.GroupName) != "pg" {
+							t.Fatalf("expected placement group %q, got %q", "pg", aws.StringValue(input.Placement.GroupName))
+						}
+						if aws.Int64Value(input.Placement.PartitionNumber) != 2 {
+							t.Fatalf("expected partition number 2, got %d", aws.Int64Value(input.Placement.PartitionNumber))
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-1"),
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+										GroupName:        aws.String("pg"),
+										PartitionNumber:  aws.Int64(2),
+									},
+								},
+							},
+						}, nil
+					})
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.PlacementGroupName != "pg" {
+					t.Fatalf("expected placement group %q, got %q", "pg", instance.PlacementGroupName)
+				}
+			},
+		},
 		{
 			name: "expect the default SSH key when none is provided",
 			machine: clusterv1.Machine{
@@ -1983,6 +2211,14 @@ func TestCreateInstance(t *testing.T) {
 				t.Fatalf("Failed to create test context: %v", err)
 			}
 			machineScope.AWSMachine.Spec = *tc.machineConfig
+			// The architecture of the instance type is looked up for machines without an AMI ID.
+			ec2Mock.EXPECT().DescribeInstanceTypes(gomock.Any()).
+				Return(&ec2.DescribeInstanceTypesOutput{
+					InstanceTypes: []*ec2.InstanceTypeInfo{
+						{ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{"i386", "x86_64"})}},
+					},
+				}, nil).
+				AnyTimes()
 			tc.expect(ec2Mock.EXPECT())
 
 			s := NewService(clusterScope)
@@ -2055,6 +2291,69 @@ func TestGetInstanceMarketOptionsRequest(t *testing.T) {
 	}
 }
 
+func TestGetCapacityReservationSpecification(t *testing.T) {
+	testCases := []struct {
+		name                string
+		capacityReservation *infrav1.CapacityReservationSpec
+		expectedRequest     *ec2.CapacityReservationSpecification
+	}{
+		{
+			name:                "with no capacity reservation",
+			capacityReservation: nil,
+			expectedRequest:     nil,
+		},
+		{
+			name: "with open preference",
+			capacityReservation: &infrav1.CapacityReservationSpec{
+				Preference: infrav1.CapacityReservationPreferenceOpen,
+			},
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationPreference: aws.String(ec2.CapacityReservationPreferenceOpen),
+			},
+		},
+		{
+			name: "with none preference",
+			capacityReservation: &infrav1.CapacityReservationSpec{
+				Preference: infrav1.CapacityReservationPreferenceNone,
+			},
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationPreference: aws.String(ec2.CapacityReservationPreferenceNone),
+			},
+		},
+		{
+			name: "with a capacity reservation ID",
+			capacityReservation: &infrav1.CapacityReservationSpec{
+				ID: aws.String("cr-1234"),
+			},
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationTarget: &ec2.CapacityReservationTarget{
+					CapacityReservationId: aws.String("cr-1234"),
+				},
+			},
+		},
+		{
+			name: "with a capacity reservation resource group",
+			capacityReservation: &infrav1.CapacityReservationSpec{
+				ResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-reservations"),
+			},
+			expectedRequest: &ec2.CapacityReservationSpecification{
+				CapacityReservationTarget: &ec2.CapacityReservationTarget{
+					CapacityReservationResourceGroupArn: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/my-reservations"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getCapacityReservationSpecification(tc.capacityReservation)
+			if !reflect.DeepEqual(request, tc.expectedRequest) {
+				t.Errorf("Case: %s. Got: %v, expected: %v", tc.name, request, tc.expectedRequest)
+			}
+		})
+	}
+}
+
 func setupScheme() (*runtime.Scheme, error) {
 	scheme := runtime.NewScheme()
 	if err := clusterv1.AddToScheme(scheme); err != nil {
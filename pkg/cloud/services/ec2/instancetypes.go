@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// imageArchitecture returns the architecture of the image to look up for an instance type,
+// unless an architecture is explicitly requested.
+func (s *Service) imageArchitecture(architecture, instanceType string) (string, error) {
+	if architecture != "" {
+		return architecture, nil
+	}
+	if instanceType == "" {
+		return ec2.ArchitectureValuesX8664, nil
+	}
+	return s.instanceTypeArchitecture(instanceType)
+}
+
+// instanceTypeArchitecture returns the architecture of an instance type, preferring x86_64 for
+// instance types which support several architectures.
+func (s *Service) instanceTypeArchitecture(instanceType string) (string, error) {
+	info, err := s.describeInstanceType(instanceType)
+	if err != nil {
+		return "", err
+	}
+
+	if info.ProcessorInfo == nil {
+		return "", errors.Errorf("no processor information found for instance type %q", instanceType)
+	}
+
+	supported := sets.NewString(aws.StringValueSlice(info.ProcessorInfo.SupportedArchitectures)...)
+	switch {
+	case supported.Has(ec2.ArchitectureValuesX8664):
+		return ec2.ArchitectureValuesX8664, nil
+	case supported.Has(ec2.ArchitectureValuesArm64):
+		return ec2.ArchitectureValuesArm64, nil
+	default:
+		return "", errors.Errorf("instance type %q has no supported architecture, got %v", instanceType, supported.List())
+	}
+}
+
+// imageSupportsInstanceType returns whether the architecture of an AMI is supported by an instance type.
+func (s *Service) imageSupportsInstanceType(imageID, instanceType string) (bool, error) {
+	image, err := s.describeImage(imageID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe AMI %q", imageID)
+	}
+
+	info, err := s.describeInstanceType(instanceType)
+	if err != nil {
+		return false, err
+	}
+
+	if info.ProcessorInfo == nil {
+		return false, errors.Errorf("no processor information found for instance type %q", instanceType)
+	}
+
+	supported := sets.NewString(aws.StringValueSlice(info.ProcessorInfo.SupportedArchitectures)...)
+	return supported.Has(aws.StringValue(image.Architecture)), nil
+}
+
+// describeInstanceType returns the description of an instance type, which is cached per region.
+func (s *Service) describeInstanceType(instanceType string) (*ec2.InstanceTypeInfo, error) {
+	if info, ok := instanceTypeCache.get(s.scope.Region(), s.lookupCacheIdentity(), instanceType); ok {
+		return info.(*ec2.InstanceTypeInfo), nil
+	}
+
+	out, err := s.EC2Client.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: aws.StringSlice([]string{instanceType}),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance type %q", instanceType)
+	}
+
+	if len(out.InstanceTypes) == 0 {
+		return nil, errors.Errorf("no instance type found for %q", instanceType)
+	}
+
+	instanceTypeCache.set(out.InstanceTypes[0], s.scope.Region(), s.lookupCacheIdentity(), instanceType)
+	return out.InstanceTypes[0], nil
+}
@@ -30,6 +30,7 @@ import (
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/exp/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/userdata"
 )
 
 // GetLaunchTemplate returns the existing LaunchTemplate or nothing if it doesn't exist.
@@ -47,7 +48,7 @@ func (s *Service) GetLaunchTemplate(name string) (*expinfrav1.AWSLaunchTemplate,
 	case awserrors.IsNotFound(err):
 		return nil, nil
 	case err != nil:
-		s.scope.Info("", "aerr", err.Error())
+		return nil, errors.Wrapf(err, "failed to describe launch template %q", name)
 	}
 
 	if len(out.LaunchTemplateVersions) == 0 {
@@ -125,6 +126,10 @@ func (s *Service) CreateLaunchTemplateVersion(scope *scope.MachinePoolScope, ima
 func (s *Service) createLaunchTemplateData(scope *scope.MachinePoolScope, imageID *string, userData []byte) (*ec2.RequestLaunchTemplateData, error) {
 	lt := scope.AWSMachinePool.Spec.AWSLaunchTemplate
 
+	if scope.IsWindows() {
+		userData = userdata.PowerShell(userData)
+	}
+
 	data := &ec2.RequestLaunchTemplateData{
 		InstanceType: aws.String(lt.InstanceType),
 		IamInstanceProfile: &ec2.LaunchTemplateIamInstanceProfileSpecificationRequest{
@@ -151,6 +156,16 @@ func (s *Service) createLaunchTemplateData(scope *scope.MachinePoolScope, imageI
 	// set the AMI ID
 	data.ImageId = imageID
 
+	if pg := scope.AWSMachinePool.Spec.PlacementGroup; pg != nil {
+		if err := s.ReconcilePlacementGroup(pg); err != nil {
+			return nil, err
+		}
+		data.Placement = &ec2.LaunchTemplatePlacementRequest{
+			GroupName:       aws.String(pg.Name),
+			PartitionNumber: pg.PartitionNumber,
+		}
+	}
+
 	// Set up root volume
 	if lt.RootVolume != nil {
 		rootDeviceName, err := s.checkRootVolume(lt.RootVolume, *data.ImageId)
@@ -168,6 +183,10 @@ func (s *Service) createLaunchTemplateData(scope *scope.MachinePoolScope, imageI
 			ebsRootDevice.Iops = aws.Int64(lt.RootVolume.IOPS)
 		}
 
+		if lt.RootVolume.Throughput != nil {
+			ebsRootDevice.Throughput = lt.RootVolume.Throughput
+		}
+
 		if lt.RootVolume.EncryptionKey != "" {
 			ebsRootDevice.Encrypted = aws.Bool(true)
 			ebsRootDevice.KmsKeyId = aws.String(lt.RootVolume.EncryptionKey)
@@ -185,6 +204,15 @@ func (s *Service) createLaunchTemplateData(scope *scope.MachinePoolScope, imageI
 		}
 	}
 
+	if lt.CapacityReservation != nil {
+		data.CapacityReservationSpecification = &ec2.LaunchTemplateCapacityReservationSpecificationRequest{
+			CapacityReservationTarget: getCapacityReservationTarget(lt.CapacityReservation),
+		}
+		if lt.CapacityReservation.Preference != "" {
+			data.CapacityReservationSpecification.CapacityReservationPreference = aws.String(string(lt.CapacityReservation.Preference))
+		}
+	}
+
 	data.TagSpecifications = s.buildLaunchTemplateTagSpecificationRequest(scope)
 
 	return data, nil
@@ -215,10 +243,13 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		AMI: infrav1.AWSResourceReference{
 			ID: v.ImageId,
 		},
-		IamInstanceProfile: aws.StringValue(v.IamInstanceProfile.Name),
-		InstanceType:       aws.StringValue(v.InstanceType),
-		SSHKeyName:         v.KeyName,
-		VersionNumber:      d.VersionNumber,
+		InstanceType:  aws.StringValue(v.InstanceType),
+		SSHKeyName:    v.KeyName,
+		VersionNumber: d.VersionNumber,
+	}
+
+	if v.IamInstanceProfile != nil {
+		i.IamInstanceProfile = aws.StringValue(v.IamInstanceProfile.Name)
 	}
 
 	// Extract IAM Instance Profile name from ARN
@@ -229,6 +260,16 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
+	if crs := v.CapacityReservationSpecification; crs != nil {
+		i.CapacityReservation = &infrav1.CapacityReservationSpec{
+			Preference: infrav1.CapacityReservationPreference(aws.StringValue(crs.CapacityReservationPreference)),
+		}
+		if crs.CapacityReservationTarget != nil {
+			i.CapacityReservation.ID = nonEmptyString(crs.CapacityReservationTarget.CapacityReservationId)
+			i.CapacityReservation.ResourceGroupARN = nonEmptyString(crs.CapacityReservationTarget.CapacityReservationResourceGroupArn)
+		}
+	}
+
 	for _, id := range v.SecurityGroupIds {
 		// This will include the core security groups as well, making the "Additional" a bit
 		// dishonest. However, including the core groups drastically simplifies comparison with
@@ -239,6 +280,46 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 	return i, nil
 }
 
+// capacityReservationNeedsUpdate compares the requested capacity reservation with the one read back from EC2.
+// EC2 reports the default "open" preference for launch templates created without a reservation, so an
+// unset preference is normalized before comparing, and targets are compared by value.
+func capacityReservationNeedsUpdate(incoming, existing *infrav1.CapacityReservationSpec) bool {
+	if incoming == nil {
+		incoming = &infrav1.CapacityReservationSpec{}
+	}
+	if existing == nil {
+		existing = &infrav1.CapacityReservationSpec{}
+	}
+
+	if aws.StringValue(incoming.ID) != aws.StringValue(existing.ID) ||
+		aws.StringValue(incoming.ResourceGroupARN) != aws.StringValue(existing.ResourceGroupARN) {
+		return true
+	}
+
+	// A targeted reservation takes precedence over the preference, so EC2 may report either.
+	if incoming.IsTargeted() {
+		return false
+	}
+
+	return capacityReservationPreference(incoming) != capacityReservationPreference(existing)
+}
+
+// capacityReservationPreference returns the preference of a spec, defaulting to the one EC2 applies.
+func capacityReservationPreference(c *infrav1.CapacityReservationSpec) infrav1.CapacityReservationPreference {
+	if c.Preference == "" {
+		return infrav1.CapacityReservationPreferenceOpen
+	}
+	return c.Preference
+}
+
+// nonEmptyString returns nil for a nil or empty string, as EC2 may return empty fields in a reservation target.
+func nonEmptyString(s *string) *string {
+	if aws.StringValue(s) == "" {
+		return nil
+	}
+	return s
+}
+
 // LaunchTemplateNeedsUpdate checks if a new launch template version is needed
 func (s *Service) LaunchTemplateNeedsUpdate(scope *scope.MachinePoolScope, incoming *expinfrav1.AWSLaunchTemplate, existing *expinfrav1.AWSLaunchTemplate) (bool, error) {
 	if incoming.IamInstanceProfile != existing.IamInstanceProfile {
@@ -249,6 +330,10 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope *scope.MachinePoolScope, incom
 		return true, nil
 	}
 
+	if capacityReservationNeedsUpdate(incoming.CapacityReservation, existing.CapacityReservation) {
+		return true, nil
+	}
+
 	incomingIDs := make([]string, len(incoming.AdditionalSecurityGroups))
 	for i, ref := range incoming.AdditionalSecurityGroups {
 		incomingIDs[i] = aws.StringValue(ref.ID)
@@ -276,6 +361,8 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope *scope.MachinePoolScope, incom
 	return false, nil
 }
 
+// DiscoverLaunchTemplateAMI returns the AMI ID for the launch template. AMIs which aren't referenced by ID
+// are pinned in the AWSMachinePool's status, and only resolved again when their source changes.
 func (s *Service) DiscoverLaunchTemplateAMI(scope *scope.MachinePoolScope) (*string, error) {
 	lt := scope.AWSMachinePool.Spec.AWSLaunchTemplate
 
@@ -283,44 +370,48 @@ func (s *Service) DiscoverLaunchTemplateAMI(scope *scope.MachinePoolScope) (*str
 		return lt.AMI.ID, nil
 	}
 
-	if scope.MachinePool.Spec.Template.Spec.Version == nil {
-		err := errors.New("Either AWSMachinePool's spec.awslaunchtemplate.ami.id or MachinePool's spec.template.spec.version must be defined")
-		s.scope.Error(err, "")
-		return nil, err
-	}
-
-	var lookupAMI string
-	var err error
-
-	if scope.IsEKSManaged() { // nolint:nestif
-		lookupAMI, err = s.eksAMILookup(*scope.MachinePool.Spec.Template.Spec.Version)
-		if err != nil {
+	lookup := imageLookup{SSMParameter: lt.AMI.SSMParameter}
+	if lookup.SSMParameter == nil {
+		if scope.MachinePool.Spec.Template.Spec.Version == nil {
+			err := errors.New("Either AWSMachinePool's spec.awslaunchtemplate.ami.id, spec.awslaunchtemplate.ami.ssmParameter or MachinePool's spec.template.spec.version must be defined")
+			s.scope.Error(err, "")
 			return nil, err
 		}
-	} else {
-
-		imageLookupFormat := lt.ImageLookupFormat
-		if imageLookupFormat == "" {
-			imageLookupFormat = scope.InfraCluster.ImageLookupFormat()
-		}
 
-		imageLookupOrg := lt.ImageLookupOrg
-		if imageLookupOrg == "" {
-			imageLookupOrg = scope.InfraCluster.ImageLookupOrg()
+		architecture, err := s.imageArchitecture(lt.ImageLookupArchitecture, lt.InstanceType)
+		if err != nil {
+			return nil, err
 		}
 
-		imageLookupBaseOS := lt.ImageLookupBaseOS
-		if imageLookupBaseOS == "" {
-			imageLookupBaseOS = scope.InfraCluster.ImageLookupBaseOS()
+		lookup.EKS = scope.IsEKSManaged()
+		lookup.Architecture = architecture
+		lookup.Platform = lt.Platform
+		lookup.KubernetesVersion = *scope.MachinePool.Spec.Template.Spec.Version
+		if !lookup.EKS {
+			lookup.Format = lt.ImageLookupFormat
+			if lookup.Format == "" {
+				lookup.Format = scope.InfraCluster.ImageLookupFormat()
+			}
+
+			lookup.Org = lt.ImageLookupOrg
+			if lookup.Org == "" {
+				lookup.Org = scope.InfraCluster.ImageLookupOrg()
+			}
+
+			lookup.BaseOS = lt.ImageLookupBaseOS
+			if lookup.BaseOS == "" {
+				lookup.BaseOS = scope.InfraCluster.ImageLookupBaseOS()
+			}
 		}
+	}
 
-		lookupAMI, err = s.defaultAMILookup(imageLookupFormat, imageLookupOrg, imageLookupBaseOS, *scope.MachinePool.Spec.Template.Spec.Version)
-		if err != nil {
-			return nil, err
-		}
+	image, err := s.resolveImage(lookup, scope.AWSMachinePool.Status.Image)
+	if err != nil {
+		return nil, err
 	}
+	scope.SetImage(image)
 
-	return aws.String(lookupAMI), nil
+	return aws.String(image.ID), nil
 }
 
 func (s *Service) buildLaunchTemplateTagSpecificationRequest(scope *scope.MachinePoolScope) []*ec2.LaunchTemplateTagSpecificationRequest {
@@ -349,8 +440,12 @@ func (s *Service) buildLaunchTemplateTagSpecificationRequest(scope *scope.Machin
 		tagSpecifications = append(tagSpecifications, spec)
 
 		// tag EBS volumes
+		volumeTags := tags.DeepCopy()
+		if rootVolume := scope.AWSMachinePool.Spec.AWSLaunchTemplate.RootVolume; rootVolume != nil {
+			volumeTags.Merge(rootVolume.Tags)
+		}
 		spec = &ec2.LaunchTemplateTagSpecificationRequest{ResourceType: aws.String(ec2.ResourceTypeVolume)}
-		for key, value := range tags {
+		for key, value := range volumeTags {
 			spec.Tags = append(spec.Tags, &ec2.Tag{
 				Key:   aws.String(key),
 				Value: aws.String(value),
@@ -134,6 +134,34 @@ func TestService_SDKToLaunchTemplate(t *testing.T) {
 				VersionNumber:      aws.Int64(1),
 			},
 		},
+		{
+			name: "capacity reservation with an empty target",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId: aws.String("foo-image"),
+					CapacityReservationSpecification: &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+						CapacityReservationPreference: aws.String("open"),
+						CapacityReservationTarget: &ec2.CapacityReservationTargetResponse{
+							CapacityReservationId: aws.String(""),
+						},
+					},
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			want: &expinfrav1.AWSLaunchTemplate{
+				ID:   "lt-12345",
+				Name: "foo",
+				AMI: infrav1.AWSResourceReference{
+					ID: aws.String("foo-image"),
+				},
+				CapacityReservation: &infrav1.CapacityReservationSpec{
+					Preference: infrav1.CapacityReservationPreferenceOpen,
+				},
+				VersionNumber: aws.Int64(1),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -208,6 +236,77 @@ func TestService_LaunchTemplateNeedsUpdate(t *testing.T) {
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "default capacity reservation preference read back from EC2",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CapacityReservation: &infrav1.CapacityReservationSpec{},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+				CapacityReservation: &infrav1.CapacityReservationSpec{
+					Preference: infrav1.CapacityReservationPreferenceOpen,
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "targeted capacity reservation read back with a preference",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CapacityReservation: &infrav1.CapacityReservationSpec{
+					ID: aws.String("cr-123"),
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+				CapacityReservation: &infrav1.CapacityReservationSpec{
+					Preference: infrav1.CapacityReservationPreferenceOpen,
+					ID:         aws.String("cr-123"),
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "capacity reservation preference changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CapacityReservation: &infrav1.CapacityReservationSpec{
+					Preference: infrav1.CapacityReservationPreferenceNone,
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+				CapacityReservation: &infrav1.CapacityReservationSpec{
+					Preference: infrav1.CapacityReservationPreferenceOpen,
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:     "capacity reservation target removed",
+			incoming: &expinfrav1.AWSLaunchTemplate{},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+				CapacityReservation: &infrav1.CapacityReservationSpec{
+					ResourceGroupARN: aws.String("arn:aws:resource-groups:us-east-1:123456789012:group/foo"),
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,294 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// ReconcileSecondaryNetworkInterfaces creates the secondary network interfaces of the machine and
+// attaches them to its instance. Network interfaces created by previous reconciles are reused.
+// It returns the status of the attached network interfaces.
+func (s *Service) ReconcileSecondaryNetworkInterfaces(scope *scope.MachineScope, instance *infrav1.Instance) ([]infrav1.NetworkInterfaceStatus, error) {
+	if len(scope.AWSMachine.Spec.SecondaryNetworkInterfaces) == 0 {
+		return nil, nil
+	}
+
+	existing, err := s.describeSecondaryNetworkInterfaces(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*ec2.NetworkInterface, len(existing))
+	for _, eni := range existing {
+		for _, tag := range eni.TagSet {
+			if aws.StringValue(tag.Key) == "Name" {
+				byName[aws.StringValue(tag.Value)] = eni
+			}
+		}
+	}
+
+	statuses := make([]infrav1.NetworkInterfaceStatus, 0, len(scope.AWSMachine.Spec.SecondaryNetworkInterfaces))
+	for i := range scope.AWSMachine.Spec.SecondaryNetworkInterfaces {
+		spec := &scope.AWSMachine.Spec.SecondaryNetworkInterfaces[i]
+		name := secondaryNetworkInterfaceName(scope, spec.DeviceIndex)
+
+		eni, ok := byName[name]
+		if !ok {
+			eni, err = s.createSecondaryNetworkInterface(scope, instance, spec, name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.attachSecondaryNetworkInterface(scope, instance, eni, spec.DeviceIndex); err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, sdkToNetworkInterfaceStatus(eni, spec.DeviceIndex))
+	}
+
+	return statuses, nil
+}
+
+// DeleteSecondaryNetworkInterfaces deletes the secondary network interfaces of the machine which
+// are no longer attached to an instance. Network interfaces still attached with delete on termination
+// set are left to be deleted by EC2 along with the instance.
+func (s *Service) DeleteSecondaryNetworkInterfaces(scope *scope.MachineScope) error {
+	existing, err := s.describeSecondaryNetworkInterfaces(scope)
+	if err != nil {
+		return err
+	}
+
+	for _, eni := range existing {
+		id := aws.StringValue(eni.NetworkInterfaceId)
+
+		if aws.StringValue(eni.Status) != ec2.NetworkInterfaceStatusAvailable {
+			if eni.Attachment != nil && aws.BoolValue(eni.Attachment.DeleteOnTermination) {
+				s.scope.V(2).Info("Network interface will be deleted with its instance", "network-interface-id", id)
+				continue
+			}
+			return errors.Errorf("network interface %q is still in use", id)
+		}
+
+		if _, err := s.EC2Client.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{
+			NetworkInterfaceId: eni.NetworkInterfaceId,
+		}); err != nil {
+			if code, ok := awserrors.Code(err); ok && code == "InvalidNetworkInterfaceID.NotFound" {
+				continue
+			}
+			record.Warnf(scope.AWSMachine, "FailedDeleteNetworkInterface", "Failed to delete network interface %q: %v", id, err)
+			return errors.Wrapf(err, "failed to delete network interface %q", id)
+		}
+
+		record.Eventf(scope.AWSMachine, "SuccessfulDeleteNetworkInterface", "Deleted network interface %q", id)
+		s.scope.V(2).Info("Deleted network interface", "network-interface-id", id)
+	}
+
+	return nil
+}
+
+func (s *Service) createSecondaryNetworkInterface(scope *scope.MachineScope, instance *infrav1.Instance, spec *infrav1.SecondaryNetworkInterface, name string) (*ec2.NetworkInterface, error) {
+	subnetID, err := s.findSecondaryNetworkInterfaceSubnet(instance, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []string
+	if len(spec.SecurityGroups) > 0 {
+		for _, sg := range spec.SecurityGroups {
+			groups = append(groups, aws.StringValue(sg.ID))
+		}
+	} else {
+		groups, err = s.GetCoreSecurityGroups(scope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	input := &ec2.CreateNetworkInterfaceInput{
+		SubnetId:                       aws.String(subnetID),
+		Groups:                         aws.StringSlice(groups),
+		SecondaryPrivateIpAddressCount: spec.SecondaryPrivateIPCount,
+		Ipv4PrefixCount:                spec.IPv4PrefixCount,
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeNetworkInterface, infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(name),
+				Role:        aws.String(infrav1.SecondaryNetworkInterfaceRoleTagValue),
+				Additional:  scope.AdditionalTags(),
+			}.WithMachineName(scope.Machine)),
+		},
+	}
+	if spec.Description != "" {
+		input.Description = aws.String(spec.Description)
+	}
+
+	out, err := s.EC2Client.CreateNetworkInterface(input)
+	if err != nil {
+		record.Warnf(scope.AWSMachine, "FailedCreateNetworkInterface", "Failed to create network interface for device index %d: %v", spec.DeviceIndex, err)
+		return nil, errors.Wrapf(err, "failed to create network interface for device index %d", spec.DeviceIndex)
+	}
+
+	record.Eventf(scope.AWSMachine, "SuccessfulCreateNetworkInterface", "Created network interface %q for device index %d", aws.StringValue(out.NetworkInterface.NetworkInterfaceId), spec.DeviceIndex)
+	s.scope.V(2).Info("Created network interface", "network-interface-id", aws.StringValue(out.NetworkInterface.NetworkInterfaceId), "device-index", spec.DeviceIndex)
+
+	return out.NetworkInterface, nil
+}
+
+func (s *Service) attachSecondaryNetworkInterface(scope *scope.MachineScope, instance *infrav1.Instance, eni *ec2.NetworkInterface, deviceIndex int64) error {
+	id := aws.StringValue(eni.NetworkInterfaceId)
+
+	if att := eni.Attachment; att != nil && aws.StringValue(att.Status) != ec2.AttachmentStatusDetached {
+		if aws.StringValue(att.InstanceId) != instance.ID {
+			return errors.Errorf("network interface %q is attached to instance %q", id, aws.StringValue(att.InstanceId))
+		}
+		if aws.BoolValue(att.DeleteOnTermination) {
+			return nil
+		}
+		return s.setNetworkInterfaceDeleteOnTermination(id, att.AttachmentId)
+	}
+
+	out, err := s.EC2Client.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(deviceIndex),
+		InstanceId:         aws.String(instance.ID),
+		NetworkInterfaceId: aws.String(id),
+	})
+	if err != nil {
+		record.Warnf(scope.AWSMachine, "FailedAttachNetworkInterface", "Failed to attach network interface %q to instance %q: %v", id, instance.ID, err)
+		return errors.Wrapf(err, "failed to attach network interface %q to instance %q", id, instance.ID)
+	}
+
+	record.Eventf(scope.AWSMachine, "SuccessfulAttachNetworkInterface", "Attached network interface %q to instance %q at device index %d", id, instance.ID, deviceIndex)
+
+	return s.setNetworkInterfaceDeleteOnTermination(id, out.AttachmentId)
+}
+
+func (s *Service) setNetworkInterfaceDeleteOnTermination(id string, attachmentID *string) error {
+	if _, err := s.EC2Client.ModifyNetworkInterfaceAttribute(&ec2.ModifyNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: aws.String(id),
+		Attachment: &ec2.NetworkInterfaceAttachmentChanges{
+			AttachmentId:        attachmentID,
+			DeleteOnTermination: aws.Bool(true),
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set delete on termination for network interface %q", id)
+	}
+	return nil
+}
+
+func (s *Service) findSecondaryNetworkInterfaceSubnet(instance *infrav1.Instance, spec *infrav1.SecondaryNetworkInterface) (string, error) {
+	switch {
+	case spec.Subnet == nil:
+		return instance.SubnetID, nil
+	case spec.Subnet.ID != nil:
+		return *spec.Subnet.ID, nil
+	}
+
+	input := &ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+			filter.EC2.VPC(s.scope.VPC().ID),
+		},
+	}
+	if instance.AvailabilityZone != "" {
+		input.Filters = append(input.Filters, filter.EC2.AvailabilityZone(instance.AvailabilityZone))
+	}
+	for _, f := range spec.Subnet.Filters {
+		input.Filters = append(input.Filters, &ec2.Filter{Name: aws.String(f.Name), Values: aws.StringSlice(f.Values)})
+	}
+
+	out, err := s.EC2Client.DescribeSubnets(input)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to describe subnets")
+	}
+
+	if len(out.Subnets) == 0 {
+		return "", awserrors.NewFailedDependency(
+			fmt.Sprintf("no subnets available for network interface at device index %d matching filters %q", spec.DeviceIndex, spec.Subnet.Filters),
+		)
+	}
+
+	return aws.StringValue(out.Subnets[0].SubnetId), nil
+}
+
+func (s *Service) describeSecondaryNetworkInterfaces(scope *scope.MachineScope) ([]*ec2.NetworkInterface, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.ProviderRole(infrav1.SecondaryNetworkInterfaceRoleTagValue),
+			{
+				Name:   aws.String("tag:" + infrav1.MachineNameTagKey),
+				Values: aws.StringSlice([]string{types.NamespacedName{Namespace: scope.Machine.Namespace, Name: scope.Machine.Name}.String()}),
+			},
+		},
+	}
+
+	var enis []*ec2.NetworkInterface
+	err := s.EC2Client.DescribeNetworkInterfacesPages(input,
+		func(page *ec2.DescribeNetworkInterfacesOutput, lastPage bool) bool {
+			enis = append(enis, page.NetworkInterfaces...)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe network interfaces")
+	}
+
+	return enis, nil
+}
+
+func secondaryNetworkInterfaceName(scope *scope.MachineScope, deviceIndex int64) string {
+	return fmt.Sprintf("%s-eni-%d", scope.Name(), deviceIndex)
+}
+
+func sdkToNetworkInterfaceStatus(eni *ec2.NetworkInterface, deviceIndex int64) infrav1.NetworkInterfaceStatus {
+	status := infrav1.NetworkInterfaceStatus{
+		ID:          aws.StringValue(eni.NetworkInterfaceId),
+		DeviceIndex: deviceIndex,
+		SubnetID:    aws.StringValue(eni.SubnetId),
+		MACAddress:  aws.StringValue(eni.MacAddress),
+	}
+
+	if eni.PrivateIpAddress != nil {
+		status.PrivateIPs = append(status.PrivateIPs, aws.StringValue(eni.PrivateIpAddress))
+	}
+	for _, ip := range eni.PrivateIpAddresses {
+		if aws.BoolValue(ip.Primary) {
+			continue
+		}
+		status.PrivateIPs = append(status.PrivateIPs, aws.StringValue(ip.PrivateIpAddress))
+	}
+
+	for _, prefix := range eni.Ipv4Prefixes {
+		status.IPv4Prefixes = append(status.IPv4Prefixes, aws.StringValue(prefix.Ipv4Prefix))
+	}
+
+	return status
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileSecondaryNetworkInterfaces(t *testing.T) {
+	instance := &infrav1.Instance{
+		ID:               "i-1",
+		SubnetID:         "subnet-1",
+		AvailabilityZone: "us-east-1a",
+	}
+
+	interfaces := infrav1.SecondaryNetworkInterfaces{
+		{
+			DeviceIndex:             1,
+			SecurityGroups:          []infrav1.AWSResourceReference{{ID: aws.String("sg-1")}},
+			SecondaryPrivateIPCount: aws.Int64(1),
+			IPv4PrefixCount:         aws.Int64(2),
+		},
+	}
+
+	existingENI := &ec2.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-1"),
+		SubnetId:           aws.String("subnet-1"),
+		MacAddress:         aws.String("0a:00:00:00:00:01"),
+		PrivateIpAddress:   aws.String("10.0.0.10"),
+		PrivateIpAddresses: []*ec2.NetworkInterfacePrivateIpAddress{
+			{PrivateIpAddress: aws.String("10.0.0.10"), Primary: aws.Bool(true)},
+			{PrivateIpAddress: aws.String("10.0.0.11"), Primary: aws.Bool(false)},
+		},
+		Ipv4Prefixes: []*ec2.Ipv4PrefixSpecification{
+			{Ipv4Prefix: aws.String("10.0.1.0/28")},
+			{Ipv4Prefix: aws.String("10.0.1.16/28")},
+		},
+		TagSet: []*ec2.Tag{
+			{Key: aws.String("Name"), Value: aws.String("aws-test-eni-1")},
+		},
+	}
+
+	expectedStatus := []infrav1.NetworkInterfaceStatus{
+		{
+			ID:           "eni-1",
+			DeviceIndex:  1,
+			SubnetID:     "subnet-1",
+			MACAddress:   "0a:00:00:00:00:01",
+			PrivateIPs:   []string{"10.0.0.10", "10.0.0.11"},
+			IPv4Prefixes: []string{"10.0.1.0/28", "10.0.1.16/28"},
+		},
+	}
+
+	describeENIs := func(m *mock_ec2iface.MockEC2APIMockRecorder, enis ...*ec2.NetworkInterface) {
+		m.DescribeNetworkInterfacesPages(gomock.Any(), gomock.Any()).
+			Do(func(_ *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool) {
+				fn(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: enis}, true)
+			}).
+			Return(nil)
+	}
+
+	tests := []struct {
+		name         string
+		interfaces   infrav1.SecondaryNetworkInterfaces
+		expect       func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectStatus []infrav1.NetworkInterfaceStatus
+		expectError  bool
+	}{
+		{
+			name:       "no secondary network interfaces is a no-op",
+			interfaces: nil,
+			expect:     func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:       "creates and attaches a missing network interface",
+			interfaces: interfaces,
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeENIs(m)
+				m.CreateNetworkInterface(gomock.Any()).
+					DoAndReturn(func(input *ec2.CreateNetworkInterfaceInput) (*ec2.CreateNetworkInterfaceOutput, error) {
+						if aws.StringValue(input.SubnetId) != "subnet-1" ||
+							aws.Int64Value(input.SecondaryPrivateIpAddressCount) != 1 ||
+							aws.Int64Value(input.Ipv4PrefixCount) != 2 ||
+							aws.StringValueSlice(input.Groups)[0] != "sg-1" {
+							t.Fatalf("unexpected create request: %v", input)
+						}
+						return &ec2.CreateNetworkInterfaceOutput{NetworkInterface: existingENI}, nil
+					})
+				m.AttachNetworkInterface(gomock.Eq(&ec2.AttachNetworkInterfaceInput{
+					DeviceIndex:        aws.Int64(1),
+					InstanceId:         aws.String("i-1"),
+					NetworkInterfaceId: aws.String("eni-1"),
+				})).
+					Return(&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-1")}, nil)
+				m.ModifyNetworkInterfaceAttribute(gomock.Eq(&ec2.ModifyNetworkInterfaceAttributeInput{
+					NetworkInterfaceId: aws.String("eni-1"),
+					Attachment: &ec2.NetworkInterfaceAttachmentChanges{
+						AttachmentId:        aws.String("eni-attach-1"),
+						DeleteOnTermination: aws.Bool(true),
+					},
+				})).
+					Return(&ec2.ModifyNetworkInterfaceAttributeOutput{}, nil)
+			},
+			expectStatus: expectedStatus,
+		},
+		{
+			name:       "reuses an attached network interface",
+			interfaces: interfaces,
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				eni := *existingENI
+				eni.Attachment = &ec2.NetworkInterfaceAttachment{
+					AttachmentId:        aws.String("eni-attach-1"),
+					InstanceId:          aws.String("i-1"),
+					Status:              aws.String(ec2.AttachmentStatusAttached),
+					DeleteOnTermination: aws.Bool(true),
+				}
+				describeENIs(m, &eni)
+			},
+			expectStatus: expectedStatus,
+		},
+		{
+			name:       "network interface attached to another instance",
+			interfaces: interfaces,
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				eni := *existingENI
+				eni.Attachment = &ec2.NetworkInterfaceAttachment{
+					InstanceId: aws.String("i-2"),
+					Status:     aws.String(ec2.AttachmentStatusAttached),
+				}
+				describeENIs(m, &eni)
+			},
+			expectError: true,
+		},
+		{
+			name:       "create fails",
+			interfaces: interfaces,
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeENIs(m)
+				m.CreateNetworkInterface(gomock.Any()).
+					Return(nil, errors.New("InsufficientFreeAddressesInSubnet"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s, machineScope := newMachineTestService(g, ec2Mock)
+			machineScope.AWSMachine.Spec.SecondaryNetworkInterfaces = tc.interfaces
+			tc.expect(ec2Mock.EXPECT())
+
+			status, err := s.ReconcileSecondaryNetworkInterfaces(machineScope, instance)
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(status).To(Equal(tc.expectStatus))
+		})
+	}
+}
+
+func TestDeleteSecondaryNetworkInterfaces(t *testing.T) {
+	tests := []struct {
+		name        string
+		enis        []*ec2.NetworkInterface
+		expect      func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:   "no network interfaces",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "deletes available network interfaces",
+			enis: []*ec2.NetworkInterface{
+				{
+					NetworkInterfaceId: aws.String("eni-1"),
+					Status:             aws.String(ec2.NetworkInterfaceStatusAvailable),
+				},
+				{
+					NetworkInterfaceId: aws.String("eni-2"),
+					Status:             aws.String(ec2.NetworkInterfaceStatusInUse),
+					Attachment: &ec2.NetworkInterfaceAttachment{
+						DeleteOnTermination: aws.Bool(true),
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DeleteNetworkInterface(gomock.Eq(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: aws.String("eni-1")})).
+					Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+			},
+		},
+		{
+			name: "network interface still in use",
+			enis: []*ec2.NetworkInterface{
+				{
+					NetworkInterfaceId: aws.String("eni-1"),
+					Status:             aws.String(ec2.NetworkInterfaceStatusInUse),
+					Attachment:         &ec2.NetworkInterfaceAttachment{},
+				},
+			},
+			expect:      func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s, machineScope := newMachineTestService(g, ec2Mock)
+			ec2Mock.EXPECT().DescribeNetworkInterfacesPages(gomock.Any(), gomock.Any()).
+				Do(func(_ *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool) {
+					fn(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: tc.enis}, true)
+				}).
+				Return(nil)
+			tc.expect(ec2Mock.EXPECT())
+
+			err := s.DeleteSecondaryNetworkInterfaces(machineScope)
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
+func newMachineTestService(g *WithT, ec2Mock *mock_ec2iface.MockEC2API, objs ...runtime.Object) (*Service, *scope.MachineScope) {
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+
+	client := fake.NewFakeClientWithScheme(scheme, objs...)
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+	}
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client:     client,
+		Cluster:    cluster,
+		AWSCluster: &infrav1.AWSCluster{},
+	})
+	g.Expect(err).To(BeNil())
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:  client,
+		Cluster: cluster,
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		},
+		AWSMachine: &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-test", Namespace: "default"},
+		},
+		InfraCluster: clusterScope,
+	})
+	g.Expect(err).To(BeNil())
+
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+	return s, machineScope
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+const filterNamePlacementGroupName = "group-name"
+
+// ReconcilePlacementGroup ensures the placement group exists. Managed placement groups
+// are created and tagged as owned by the cluster if they do not exist yet.
+func (s *Service) ReconcilePlacementGroup(pg *infrav1.PlacementGroup) error {
+	if pg == nil {
+		return nil
+	}
+
+	existing, err := s.describePlacementGroup(pg.Name)
+	switch {
+	case err == nil:
+		if pg.IsManaged() && aws.StringValue(existing.Strategy) != string(pg.Strategy) {
+			return errors.Errorf("placement group %q already exists with strategy %q, expected %q",
+				pg.Name, aws.StringValue(existing.Strategy), pg.Strategy)
+		}
+		return nil
+	case !awserrors.IsNotFound(err):
+		return err
+	case !pg.IsManaged():
+		return awserrors.NewFailedDependency(fmt.Sprintf("placement group %q does not exist", pg.Name))
+	}
+
+	input := &ec2.CreatePlacementGroupInput{
+		GroupName:      aws.String(pg.Name),
+		Strategy:       aws.String(string(pg.Strategy)),
+		PartitionCount: pg.PartitionCount,
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypePlacementGroup, infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(pg.Name),
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		},
+	}
+
+	if _, err := s.EC2Client.CreatePlacementGroup(input); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreatePlacementGroup", "Failed to create placement group %q: %v", pg.Name, err)
+		return errors.Wrapf(err, "failed to create placement group %q", pg.Name)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreatePlacementGroup", "Created placement group %q", pg.Name)
+	s.scope.V(2).Info("Created placement group", "name", pg.Name, "strategy", pg.Strategy)
+
+	return nil
+}
+
+// DeletePlacementGroups deletes all placement groups owned by the cluster.
+func (s *Service) DeletePlacementGroups() error {
+	out, err := s.EC2Client.DescribePlacementGroups(&ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ClusterOwned(s.scope.Name()),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to describe placement groups")
+	}
+
+	for _, pg := range out.PlacementGroups {
+		if aws.StringValue(pg.State) == ec2.PlacementGroupStateDeleted {
+			continue
+		}
+
+		if _, err := s.EC2Client.DeletePlacementGroup(&ec2.DeletePlacementGroupInput{GroupName: pg.GroupName}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeletePlacementGroup", "Failed to delete placement group %q: %v", aws.StringValue(pg.GroupName), err)
+			return errors.Wrapf(err, "failed to delete placement group %q", aws.StringValue(pg.GroupName))
+		}
+
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeletePlacementGroup", "Deleted placement group %q", aws.StringValue(pg.GroupName))
+		s.scope.V(2).Info("Deleted placement group", "name", aws.StringValue(pg.GroupName))
+	}
+
+	return nil
+}
+
+func (s *Service) describePlacementGroup(name string) (*ec2.PlacementGroup, error) {
+	out, err := s.EC2Client.DescribePlacementGroups(&ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String(filterNamePlacementGroupName),
+				Values: aws.StringSlice([]string{name}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe placement group %q", name)
+	}
+
+	for _, pg := range out.PlacementGroups {
+		state := aws.StringValue(pg.State)
+		if state != ec2.PlacementGroupStateDeleting && state != ec2.PlacementGroupStateDeleted {
+			return pg, nil
+		}
+	}
+
+	return nil, awserrors.NewNotFound(fmt.Sprintf("placement group %q not found", name))
+}
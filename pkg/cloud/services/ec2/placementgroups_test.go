@@ -0,0 +1,285 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcilePlacementGroup(t *testing.T) {
+	describeInput := &ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("group-name"),
+				Values: aws.StringSlice([]string{"pg"}),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		placementGroup *infrav1.PlacementGroup
+		expect         func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		check          func(g *WithT, err error)
+	}{
+		{
+			name:           "nil placement group is a no-op",
+			placementGroup: nil,
+			expect:         func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			check: func(g *WithT, err error) {
+				g.Expect(err).To(BeNil())
+			},
+		},
+		{
+			name:           "unmanaged placement group exists",
+			placementGroup: &infrav1.PlacementGroup{Name: "pg"},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroups(gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{
+								GroupName: aws.String("pg"),
+								State:     aws.String(ec2.PlacementGroupStateAvailable),
+								Strategy:  aws.String(ec2.PlacementStrategySpread),
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, err error) {
+				g.Expect(err).To(BeNil())
+			},
+		},
+		{
+			name:           "unmanaged placement group does not exist",
+			placementGroup: &infrav1.PlacementGroup{Name: "pg"},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroups(gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{}, nil)
+			},
+			check: func(g *WithT, err error) {
+				g.Expect(awserrors.IsFailedDependency(err)).To(BeTrue())
+			},
+		},
+		{
+			name: "managed placement group is created when missing",
+			placementGroup: &infrav1.PlacementGroup{
+				Name:           "pg",
+				Strategy:       infrav1.PlacementGroupStrategyPartition,
+				PartitionCount: aws.Int64(3),
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroups(gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{
+								GroupName: aws.String("pg"),
+								State:     aws.String(ec2.PlacementGroupStateDeleted),
+							},
+						},
+					}, nil)
+				m.CreatePlacementGroup(gomock.Eq(&ec2.CreatePlacementGroupInput{
+					GroupName:      aws.String("pg"),
+					Strategy:       aws.String("partition"),
+					PartitionCount: aws.Int64(3),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String(ec2.ResourceTypePlacementGroup),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("pg"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+							},
+						},
+					},
+				})).
+					Return(&ec2.CreatePlacementGroupOutput{}, nil)
+			},
+			check: func(g *WithT, err error) {
+				g.Expect(err).To(BeNil())
+			},
+		},
+		{
+			name: "managed placement group exists with a different strategy",
+			placementGroup: &infrav1.PlacementGroup{
+				Name:     "pg",
+				Strategy: infrav1.PlacementGroupStrategyCluster,
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroups(gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{
+								GroupName: aws.String("pg"),
+								State:     aws.String(ec2.PlacementGroupStateAvailable),
+								Strategy:  aws.String(ec2.PlacementStrategySpread),
+							},
+						},
+					}, nil)
+			},
+			check: func(g *WithT, err error) {
+				g.Expect(err).NotTo(BeNil())
+			},
+		},
+		{
+			name: "create fails",
+			placementGroup: &infrav1.PlacementGroup{
+				Name:     "pg",
+				Strategy: infrav1.PlacementGroupStrategyCluster,
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroups(gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{}, nil)
+				m.CreatePlacementGroup(gomock.Any()).
+					Return(nil, errors.New("some error"))
+			},
+			check: func(g *WithT, err error) {
+				g.Expect(err).NotTo(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s := newClusterTestService(g, ec2Mock)
+			tc.expect(ec2Mock.EXPECT())
+
+			tc.check(g, s.ReconcilePlacementGroup(tc.placementGroup))
+		})
+	}
+}
+
+func TestDeletePlacementGroups(t *testing.T) {
+	describeInput := &ec2.DescribePlacementGroupsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ClusterOwned("test-cluster"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		expect      func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "no placement groups",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroups(gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{}, nil)
+			},
+		},
+		{
+			name: "deletes owned placement groups",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroups(gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{
+								GroupName: aws.String("pg-1"),
+								State:     aws.String(ec2.PlacementGroupStateAvailable),
+							},
+							{
+								GroupName: aws.String("pg-2"),
+								State:     aws.String(ec2.PlacementGroupStateDeleted),
+							},
+						},
+					}, nil)
+				m.DeletePlacementGroup(gomock.Eq(&ec2.DeletePlacementGroupInput{GroupName: aws.String("pg-1")})).
+					Return(&ec2.DeletePlacementGroupOutput{}, nil)
+			},
+		},
+		{
+			name: "delete fails",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribePlacementGroups(gomock.Eq(describeInput)).
+					Return(&ec2.DescribePlacementGroupsOutput{
+						PlacementGroups: []*ec2.PlacementGroup{
+							{
+								GroupName: aws.String("pg-1"),
+								State:     aws.String(ec2.PlacementGroupStateAvailable),
+							},
+						},
+					}, nil)
+				m.DeletePlacementGroup(gomock.Any()).
+					Return(nil, errors.New("InvalidPlacementGroup.InUse"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s := newClusterTestService(g, ec2Mock)
+			tc.expect(ec2Mock.EXPECT())
+
+			err := s.DeletePlacementGroups()
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
+func newClusterTestService(g *WithT, ec2Mock *mock_ec2iface.MockEC2API) *Service {
+	scheme, err := setupScheme()
+	g.Expect(err).To(BeNil())
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewFakeClientWithScheme(scheme),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{},
+	})
+	g.Expect(err).To(BeNil())
+
+	s := NewService(clusterScope)
+	s.EC2Client = ec2Mock
+	return s
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package ec2
 
 import (
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 
@@ -32,6 +33,9 @@ type Service struct {
 
 	// SSMClient is used to look up the official EKS AMI ID
 	SSMClient ssmiface.SSMAPI
+
+	// ASGClient is used to run the highly available bastion host
+	ASGClient autoscalingiface.AutoScalingAPI
 }
 
 // NewService returns a new service given the ec2 api client.
@@ -40,5 +44,6 @@ func NewService(clusterScope scope.EC2Scope) *Service {
 		scope:     clusterScope,
 		EC2Client: scope.NewEC2Client(clusterScope, clusterScope, clusterScope.InfraCluster()),
 		SSMClient: scope.NewSSMClient(clusterScope, clusterScope, clusterScope.InfraCluster()),
+		ASGClient: scope.NewASGClient(clusterScope, clusterScope, clusterScope.InfraCluster()),
 	}
 }
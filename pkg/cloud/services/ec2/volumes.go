@@ -0,0 +1,251 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// volumeModificationCooldown is how long EC2 requires between two modifications of a volume.
+const volumeModificationCooldown = 6 * time.Hour
+
+// volumeTypesWithFixedIOPS are the volume types whose IOPS can't be set, e.g. as they depend on their size.
+var volumeTypesWithFixedIOPS = sets.NewString(ec2.VolumeTypeGp2, ec2.VolumeTypeStandard, ec2.VolumeTypeSt1, ec2.VolumeTypeSc1)
+
+// ReconcileVolumes ensures the root and non root volumes of the machine's instance are tagged, and
+// modifies them in place to match the requested size, IOPS and throughput. Volumes are never shrunk, and
+// are not modified again until the cooldown EC2 requires after their last modification has passed.
+// It returns true while volume modifications are in progress, along with the IDs of all the volumes attached
+// to the instance.
+func (s *Service) ReconcileVolumes(scope *scope.MachineScope, instance *infrav1.Instance) (bool, []string, error) {
+	volumes, err := s.describeInstanceVolumes(instance.ID)
+	if err != nil {
+		return false, nil, err
+	}
+
+	ids := make([]string, 0, len(volumes))
+	for _, volume := range volumes {
+		ids = append(ids, aws.StringValue(volume.VolumeId))
+	}
+
+	managed := make([]*ec2.Volume, 0, len(volumes))
+	specs := make(map[string]*infrav1.Volume, len(volumes))
+	for _, volume := range volumes {
+		device, isRoot := volumeDevice(volume, instance)
+		spec := scope.AWSMachine.Spec.RootVolume
+		if !isRoot {
+			spec = nonRootVolumeSpec(scope, device)
+			if spec == nil {
+				// Not launched with the instance, e.g. attached by a CSI driver.
+				continue
+			}
+		}
+
+		if err := s.ensureVolumeTags(scope, volume, spec); err != nil {
+			return false, nil, err
+		}
+
+		if spec != nil {
+			managed = append(managed, volume)
+			specs[aws.StringValue(volume.VolumeId)] = spec
+		}
+	}
+
+	if len(managed) == 0 {
+		return false, ids, nil
+	}
+
+	modifications, err := s.describeLatestVolumeModifications(managed)
+	if err != nil {
+		return false, nil, err
+	}
+
+	inProgress := false
+	for _, volume := range managed {
+		id := aws.StringValue(volume.VolumeId)
+
+		if mod, ok := modifications[id]; ok {
+			switch aws.StringValue(mod.ModificationState) {
+			case ec2.VolumeModificationStateModifying, ec2.VolumeModificationStateOptimizing:
+				s.scope.V(2).Info("Volume modification in progress", "volume-id", id, "state", aws.StringValue(mod.ModificationState), "progress", aws.Int64Value(mod.Progress))
+				inProgress = true
+				continue
+			}
+		}
+
+		input := volumeModificationInput(volume, specs[id])
+		if input == nil {
+			continue
+		}
+
+		// Retrying a failed modification, or modifying the volume again, fails until the cooldown has passed.
+		if mod, ok := modifications[id]; ok && time.Since(aws.TimeValue(mod.StartTime)) < volumeModificationCooldown {
+			s.scope.V(2).Info("Waiting for the cooldown after the last volume modification", "volume-id", id, "state", aws.StringValue(mod.ModificationState), "start-time", aws.TimeValue(mod.StartTime))
+			continue
+		}
+
+		if _, err := s.EC2Client.ModifyVolume(input); err != nil {
+			record.Warnf(scope.AWSMachine, "FailedModifyVolume", "Failed to modify volume %q: %v", id, err)
+			return false, nil, errors.Wrapf(err, "failed to modify volume %q", id)
+		}
+
+		record.Eventf(scope.AWSMachine, "SuccessfulModifyVolume", "Started modification of volume %q", id)
+		s.scope.V(2).Info("Started volume modification", "volume-id", id)
+		inProgress = true
+	}
+
+	return inProgress, ids, nil
+}
+
+// ensureVolumeTags adds the cluster ownership tags, the additional tags of the machine and the tags
+// of the volume configuration to a volume. Tags are only ever added or updated.
+func (s *Service) ensureVolumeTags(scope *scope.MachineScope, volume *ec2.Volume, spec *infrav1.Volume) error {
+	additional := scope.AdditionalTags()
+	if spec != nil {
+		additional.Merge(spec.Tags)
+	}
+
+	desired := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(scope.Name()),
+		Role:        aws.String(scope.Role()),
+		Additional:  additional,
+	}.WithCloudProvider(s.scope.Name()).WithMachineName(scope.Machine))
+
+	create := desired.Difference(converters.TagsToMap(volume.Tags))
+	if len(create) == 0 {
+		return nil
+	}
+
+	return s.UpdateResourceTags(volume.VolumeId, create, nil)
+}
+
+func (s *Service) describeInstanceVolumes(instanceID string) ([]*ec2.Volume, error) {
+	input := &ec2.DescribeVolumesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("attachment.instance-id"),
+				Values: aws.StringSlice([]string{instanceID}),
+			},
+		},
+	}
+
+	var volumes []*ec2.Volume
+	err := s.EC2Client.DescribeVolumesPages(input,
+		func(page *ec2.DescribeVolumesOutput, lastPage bool) bool {
+			volumes = append(volumes, page.Volumes...)
+			return !lastPage
+		})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe volumes of instance %q", instanceID)
+	}
+
+	return volumes, nil
+}
+
+func (s *Service) describeLatestVolumeModifications(volumes []*ec2.Volume) (map[string]*ec2.VolumeModification, error) {
+	ids := make([]*string, 0, len(volumes))
+	for _, volume := range volumes {
+		ids = append(ids, volume.VolumeId)
+	}
+
+	// Filtering by volume ID, rather than requesting volume IDs, does not fail for volumes which were never modified.
+	input := &ec2.DescribeVolumesModificationsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("volume-id"),
+				Values: ids,
+			},
+		},
+	}
+
+	latest := make(map[string]*ec2.VolumeModification)
+	err := s.EC2Client.DescribeVolumesModificationsPages(input,
+		func(page *ec2.DescribeVolumesModificationsOutput, lastPage bool) bool {
+			for _, mod := range page.VolumesModifications {
+				id := aws.StringValue(mod.VolumeId)
+				if prev, ok := latest[id]; ok && aws.TimeValue(prev.StartTime).After(aws.TimeValue(mod.StartTime)) {
+					continue
+				}
+				latest[id] = mod
+			}
+			return !lastPage
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe volume modifications")
+	}
+
+	return latest, nil
+}
+
+// volumeDevice returns the device name a volume is attached at, and whether it is the root volume of the instance.
+func volumeDevice(volume *ec2.Volume, instance *infrav1.Instance) (string, bool) {
+	for _, attachment := range volume.Attachments {
+		if aws.StringValue(attachment.InstanceId) == instance.ID {
+			device := aws.StringValue(attachment.Device)
+			return device, device == instance.RootDeviceName
+		}
+	}
+	return "", false
+}
+
+func nonRootVolumeSpec(scope *scope.MachineScope, device string) *infrav1.Volume {
+	for _, volume := range scope.AWSMachine.Spec.NonRootVolumes {
+		if volume != nil && volume.DeviceName == device {
+			return volume
+		}
+	}
+	return nil
+}
+
+// volumeModificationInput returns the modification needed for a volume to match its configuration, or nil if none is needed.
+func volumeModificationInput(volume *ec2.Volume, spec *infrav1.Volume) *ec2.ModifyVolumeInput {
+	input := &ec2.ModifyVolumeInput{VolumeId: volume.VolumeId}
+	modified := false
+
+	if spec.Size > aws.Int64Value(volume.Size) {
+		input.Size = aws.Int64(spec.Size)
+		modified = true
+	}
+
+	if spec.IOPS != 0 && spec.IOPS != aws.Int64Value(volume.Iops) && !volumeTypesWithFixedIOPS.Has(aws.StringValue(volume.VolumeType)) {
+		input.Iops = aws.Int64(spec.IOPS)
+		modified = true
+	}
+
+	if spec.Throughput != nil && *spec.Throughput != aws.Int64Value(volume.Throughput) {
+		input.Throughput = spec.Throughput
+		modified = true
+	}
+
+	if !modified {
+		return nil
+	}
+
+	return input
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+)
+
+func TestReconcileVolumes(t *testing.T) {
+	instance := &infrav1.Instance{
+		ID:             "i-1",
+		RootDeviceName: "/dev/xvda",
+	}
+
+	// Tags already applied to the volumes, so that only modifications are exercised.
+	ownedTags := []*ec2.Tag{
+		{Key: aws.String("MachineName"), Value: aws.String("default/test")},
+		{Key: aws.String("Name"), Value: aws.String("aws-test")},
+		{Key: aws.String("kubernetes.io/cluster/test-cluster"), Value: aws.String("owned")},
+		{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+		{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("node")},
+	}
+
+	volume := func(id, device string, size int64, tags []*ec2.Tag) *ec2.Volume {
+		return &ec2.Volume{
+			VolumeId:   aws.String(id),
+			Size:       aws.Int64(size),
+			Iops:       aws.Int64(3000),
+			Throughput: aws.Int64(125),
+			Attachments: []*ec2.VolumeAttachment{
+				{InstanceId: aws.String("i-1"), Device: aws.String(device)},
+			},
+			Tags: tags,
+		}
+	}
+
+	tests := []struct {
+		name            string
+		rootVolume      *infrav1.Volume
+		nonRootVolumes  []*infrav1.Volume
+		volumes         []*ec2.Volume
+		modifications   []*ec2.VolumeModification
+		expect          func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectModifying bool
+		expectError     bool
+	}{
+		{
+			name:    "tags the root volume",
+			volumes: []*ec2.Volume{volume("vol-root", "/dev/xvda", 8, nil)},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.CreateTags(gomock.Any()).
+					DoAndReturn(func(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+						if aws.StringValue(input.Resources[0]) != "vol-root" || len(input.Tags) != len(ownedTags) {
+							t.Fatalf("unexpected tags request: %v", input)
+						}
+						return &ec2.CreateTagsOutput{}, nil
+					})
+			},
+		},
+		{
+			name:           "ignores volumes which were not launched with the instance",
+			nonRootVolumes: []*infrav1.Volume{{DeviceName: "/dev/sdb", Size: 100}},
+			volumes: []*ec2.Volume{
+				volume("vol-root", "/dev/xvda", 8, ownedTags),
+				volume("vol-csi", "/dev/xvdba", 10, nil),
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:       "grows the root volume and sets throughput",
+			rootVolume: &infrav1.Volume{Size: 16, Type: "gp3", Throughput: aws.Int64(250)},
+			volumes:    []*ec2.Volume{volume("vol-root", "/dev/xvda", 8, ownedTags)},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeVolumesModificationsPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.ModifyVolume(gomock.Eq(&ec2.ModifyVolumeInput{
+					VolumeId:   aws.String("vol-root"),
+					Size:       aws.Int64(16),
+					Throughput: aws.Int64(250),
+				})).
+					Return(&ec2.ModifyVolumeOutput{}, nil)
+			},
+			expectModifying: true,
+		},
+		{
+			name:           "does not shrink volumes",
+			rootVolume:     &infrav1.Volume{Size: 8},
+			nonRootVolumes: []*infrav1.Volume{{DeviceName: "/dev/sdb", Size: 50}},
+			volumes: []*ec2.Volume{
+				volume("vol-root", "/dev/xvda", 16, ownedTags),
+				volume("vol-data", "/dev/sdb", 100, ownedTags),
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeVolumesModificationsPages(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:           "waits for modifications in progress",
+			nonRootVolumes: []*infrav1.Volume{{DeviceName: "/dev/sdb", Size: 200, IOPS: 6000}},
+			volumes: []*ec2.Volume{
+				volume("vol-root", "/dev/xvda", 8, ownedTags),
+				volume("vol-data", "/dev/sdb", 100, ownedTags),
+			},
+			modifications: []*ec2.VolumeModification{
+				{
+					VolumeId:          aws.String("vol-data"),
+					ModificationState: aws.String(ec2.VolumeModificationStateCompleted),
+					StartTime:         aws.Time(time.Now().Add(-time.Hour)),
+				},
+				{
+					VolumeId:          aws.String("vol-data"),
+					ModificationState: aws.String(ec2.VolumeModificationStateOptimizing),
+					StartTime:         aws.Time(time.Now()),
+				},
+			},
+			expect:          func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expectModifying: true,
+		},
+		{
+			name:       "does not set the IOPS of gp2 volumes",
+			rootVolume: &infrav1.Volume{Size: 8, IOPS: 6000},
+			volumes: []*ec2.Volume{func() *ec2.Volume {
+				v := volume("vol-root", "/dev/xvda", 8, ownedTags)
+				v.VolumeType = aws.String(ec2.VolumeTypeGp2)
+				return v
+			}()},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeVolumesModificationsPages(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:       "waits for the cooldown after a failed modification",
+			rootVolume: &infrav1.Volume{Size: 16},
+			volumes:    []*ec2.Volume{volume("vol-root", "/dev/xvda", 8, ownedTags)},
+			modifications: []*ec2.VolumeModification{
+				{
+					VolumeId:          aws.String("vol-root"),
+					ModificationState: aws.String(ec2.VolumeModificationStateFailed),
+					StartTime:         aws.Time(time.Now().Add(-time.Hour)),
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:       "retries a failed modification after the cooldown",
+			rootVolume: &infrav1.Volume{Size: 16},
+			volumes:    []*ec2.Volume{volume("vol-root", "/dev/xvda", 8, ownedTags)},
+			modifications: []*ec2.VolumeModification{
+				{
+					VolumeId:          aws.String("vol-root"),
+					ModificationState: aws.String(ec2.VolumeModificationStateFailed),
+					StartTime:         aws.Time(time.Now().Add(-7 * time.Hour)),
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.ModifyVolume(gomock.Any()).Return(&ec2.ModifyVolumeOutput{}, nil)
+			},
+			expectModifying: true,
+		},
+		{
+			name:       "modification fails",
+			rootVolume: &infrav1.Volume{Size: 16},
+			volumes:    []*ec2.Volume{volume("vol-root", "/dev/xvda", 8, ownedTags)},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeVolumesModificationsPages(gomock.Any(), gomock.Any()).Return(nil)
+				m.ModifyVolume(gomock.Any()).
+					Return(nil, errors.New("VolumeModificationRateExceeded"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			s, machineScope := newMachineTestService(g, ec2Mock)
+			machineScope.AWSMachine.Spec.RootVolume = tc.rootVolume
+			machineScope.AWSMachine.Spec.NonRootVolumes = tc.nonRootVolumes
+
+			ec2Mock.EXPECT().DescribeVolumesPages(gomock.Any(), gomock.Any()).
+				Do(func(_ *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool) {
+					fn(&ec2.DescribeVolumesOutput{Volumes: tc.volumes}, true)
+				}).
+				Return(nil)
+			if tc.modifications != nil {
+				ec2Mock.EXPECT().DescribeVolumesModificationsPages(gomock.Any(), gomock.Any()).
+					Do(func(_ *ec2.DescribeVolumesModificationsInput, fn func(*ec2.DescribeVolumesModificationsOutput, bool) bool) {
+						fn(&ec2.DescribeVolumesModificationsOutput{VolumesModifications: tc.modifications}, true)
+					}).
+					Return(nil)
+			}
+			tc.expect(ec2Mock.EXPECT())
+
+			modifying, volumeIDs, err := s.ReconcileVolumes(machineScope, instance)
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(modifying).To(Equal(tc.expectModifying))
+			g.Expect(volumeIDs).To(HaveLen(len(tc.volumes)))
+			for i, volume := range tc.volumes {
+				g.Expect(volumeIDs[i]).To(Equal(aws.StringValue(volume.VolumeId)))
+			}
+		})
+	}
+}
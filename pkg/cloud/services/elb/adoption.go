@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+)
+
+// AdoptLoadBalancer takes ownership of the existing API server load balancer named in the spec of the cluster,
+// by tagging it as owned by the cluster. Its other tags are removed by the next reconcile of the load balancer.
+// In dry run mode, the tags are only reported. Nothing is adopted when the spec doesn't name a load balancer.
+func (s *Service) AdoptLoadBalancer(dryRun bool) ([]infrav1.AdoptedResource, error) {
+	lb := s.scope.ControlPlaneLoadBalancer()
+	if lb == nil || lb.Name == nil || *lb.Name == "" {
+		return nil, nil
+	}
+	name := *lb.Name
+
+	// Also ensures the load balancer runs in the VPC of the cluster.
+	if _, err := s.describeClassicELB(name); err != nil {
+		return nil, errors.Wrapf(err, "failed to describe load balancer %q", name)
+	}
+
+	out, err := s.ELBClient.DescribeTags(&elb.DescribeTagsInput{
+		LoadBalancerNames: []*string{aws.String(name)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe tags of load balancer %q", name)
+	}
+	if len(out.TagDescriptions) == 0 {
+		return nil, errors.Errorf("no tag information returned for load balancer %q", name)
+	}
+
+	current := infrav1.Tags(converters.ELBTagsToMap(out.TagDescriptions[0].Tags))
+	if owner := current.GetOtherOwner(s.scope.Name()); owner != "" {
+		return nil, errors.Errorf("load balancer %q belongs to cluster %q", name, owner)
+	}
+
+	desired := s.getAPIServerELBTags()
+	resource := infrav1.AdoptedResource{
+		Kind: "LoadBalancer",
+		ID:   name,
+		Tags: desired.Difference(current),
+	}
+	for key := range current {
+		if _, ok := desired[key]; !ok {
+			resource.RemovedTags = append(resource.RemovedTags, key)
+		}
+	}
+	sort.Strings(resource.RemovedTags)
+
+	if !dryRun && len(resource.Tags) > 0 {
+		input := &elb.AddTagsInput{
+			LoadBalancerNames: []*string{aws.String(name)},
+		}
+		for key, value := range resource.Tags {
+			input.Tags = append(input.Tags, &elb.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		if _, err := s.ELBClient.AddTags(input); err != nil {
+			return nil, errors.Wrapf(err, "failed to tag load balancer %q", name)
+		}
+		s.scope.V(2).Info("Adopted load balancer", "elb-name", name)
+	}
+
+	return []infrav1.AdoptedResource{resource}, nil
+}
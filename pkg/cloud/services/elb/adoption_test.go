@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/elb/mock_elbiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestAdoptLoadBalancer(t *testing.T) {
+	tests := []struct {
+		name        string
+		lbName      *string
+		tags        []*elb.Tag
+		dryRun      bool
+		expectError bool
+	}{
+		{
+			name:   "tags the load balancer as owned by the cluster",
+			lbName: aws.String("legacy-apiserver"),
+			tags:   []*elb.Tag{{Key: aws.String("KubernetesCluster"), Value: aws.String("kops.example.com")}},
+		},
+		{
+			name:   "only reports the tags in dry run mode",
+			lbName: aws.String("legacy-apiserver"),
+			tags:   []*elb.Tag{{Key: aws.String("KubernetesCluster"), Value: aws.String("kops.example.com")}},
+			dryRun: true,
+		},
+		{
+			name:        "rejects a load balancer of another cluster",
+			lbName:      aws.String("legacy-apiserver"),
+			tags:        []*elb.Tag{{Key: aws.String(infrav1.ClusterTagKey("other-cluster")), Value: aws.String("owned")}},
+			expectError: true,
+		},
+		{
+			name: "adopts nothing without a load balancer name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec:              infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-1"}},
+						ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{Name: tc.lbName},
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			if tc.lbName != nil {
+				elbMock.EXPECT().DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{LoadBalancerNames: aws.StringSlice([]string{"legacy-apiserver"})}).
+					Return(&elb.DescribeLoadBalancersOutput{
+						LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+							{LoadBalancerName: aws.String("legacy-apiserver"), Scheme: aws.String("internet-facing"), VPCId: aws.String("vpc-1")},
+						},
+					}, nil)
+				elbMock.EXPECT().DescribeLoadBalancerAttributes(gomock.Any()).
+					Return(&elb.DescribeLoadBalancerAttributesOutput{
+						LoadBalancerAttributes: &elb.LoadBalancerAttributes{CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(false)}},
+					}, nil)
+				elbMock.EXPECT().DescribeTags(&elb.DescribeTagsInput{LoadBalancerNames: aws.StringSlice([]string{"legacy-apiserver"})}).
+					Return(&elb.DescribeTagsOutput{TagDescriptions: []*elb.TagDescription{{Tags: tc.tags}}}, nil)
+			}
+			if tc.lbName != nil && !tc.dryRun && !tc.expectError {
+				elbMock.EXPECT().AddTags(gomock.Any()).
+					DoAndReturn(func(input *elb.AddTagsInput) (*elb.AddTagsOutput, error) {
+						g.Expect(aws.StringValueSlice(input.LoadBalancerNames)).To(Equal([]string{"legacy-apiserver"}))
+						g.Expect(input.Tags).To(HaveLen(2))
+						return &elb.AddTagsOutput{}, nil
+					})
+			}
+
+			s := &Service{
+				scope:     clusterScope,
+				ELBClient: elbMock,
+			}
+
+			adopted, err := s.AdoptLoadBalancer(tc.dryRun)
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			if tc.lbName == nil {
+				g.Expect(adopted).To(BeEmpty())
+				return
+			}
+			g.Expect(adopted).To(Equal([]infrav1.AdoptedResource{
+				{
+					Kind: "LoadBalancer",
+					ID:   "legacy-apiserver",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+						infrav1.NameAWSClusterAPIRole:         "apiserver",
+					},
+					RemovedTags: []string{"KubernetesCluster"},
+				},
+			}))
+		})
+	}
+}
+
+func TestAPIServerELBName(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{},
+	})
+	g.Expect(err).To(BeNil())
+	s := &Service{scope: clusterScope}
+
+	name, err := s.apiServerELBName()
+	g.Expect(err).To(BeNil())
+	g.Expect(name).To(Equal("test-cluster-apiserver"))
+
+	clusterScope.AWSCluster.Spec.ControlPlaneLoadBalancer = &infrav1.AWSLoadBalancerSpec{Name: aws.String("legacy-apiserver")}
+	name, err = s.apiServerELBName()
+	g.Expect(err).To(BeNil())
+	g.Expect(name).To(Equal("legacy-apiserver"))
+}
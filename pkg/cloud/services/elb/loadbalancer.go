@@ -114,7 +114,7 @@ func (s *Service) ReconcileLoadbalancers() error {
 
 // GetAPIServerDNSName returns the DNS name endpoint for the API server
 func (s *Service) GetAPIServerDNSName() (string, error) {
-	elbName, err := GenerateELBName(s.scope.Name())
+	elbName, err := s.apiServerELBName()
 	if err != nil {
 		return "", err
 	}
@@ -134,11 +134,15 @@ func (s *Service) DeleteLoadbalancers() error {
 		return err
 	}
 
-	elbName, err := GenerateELBName(s.scope.Name())
+	// The API server load balancer is deleted by name, which is the one given in the spec for an adopted
+	// load balancer, as it is only tagged as owned by the cluster, not by the cloud provider.
+	elbName, err := s.apiServerELBName()
 	if err != nil {
 		return err
 	}
-	elbs = append(elbs, elbName)
+	if !sets.NewString(elbs...).Has(elbName) {
+		elbs = append(elbs, elbName)
+	}
 
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 	if err := s.scope.PatchObject(); err != nil {
@@ -187,7 +191,7 @@ func (s *Service) RegisterInstanceWithClassicELB(instanceID, loadBalancer string
 
 // InstanceIsRegisteredWithAPIServerELB returns true if the instance is already registered with the APIServer ELB.
 func (s *Service) InstanceIsRegisteredWithAPIServerELB(i *infrav1.Instance) (bool, error) {
-	name, err := GenerateELBName(s.scope.Name())
+	name, err := s.apiServerELBName()
 	if err != nil {
 		return false, err
 	}
@@ -215,7 +219,7 @@ func (s *Service) InstanceIsRegisteredWithAPIServerELB(i *infrav1.Instance) (boo
 
 // RegisterInstanceWithAPIServerELB registers an instance with a classic ELB
 func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
-	name, err := GenerateELBName(s.scope.Name())
+	name, err := s.apiServerELBName()
 	if err != nil {
 		return err
 	}
@@ -252,7 +256,7 @@ func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
 
 // DeregisterInstanceFromAPIServerELB de-registers an instance from a classic ELB
 func (s *Service) DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error {
-	name, err := GenerateELBName(s.scope.Name())
+	name, err := s.apiServerELBName()
 	if err != nil {
 		return err
 	}
@@ -294,6 +298,15 @@ func GenerateELBName(clusterName string) (string, error) {
 	return elbName, nil
 }
 
+// apiServerELBName returns the name of the API server load balancer, either the name of the
+// existing load balancer set in the spec or the name generated from the cluster name.
+func (s *Service) apiServerELBName() (string, error) {
+	if lb := s.scope.ControlPlaneLoadBalancer(); lb != nil && lb.Name != nil && *lb.Name != "" {
+		return *lb.Name, nil
+	}
+	return GenerateELBName(s.scope.Name())
+}
+
 // generateStandardELBName generates a formatted ELB name based on cluster
 // and ELB name
 func generateStandardELBName(clusterName string) string {
@@ -314,7 +327,7 @@ func generateHashedELBName(clusterName string) (string, error) {
 }
 
 func (s *Service) getAPIServerClassicELBSpec() (*infrav1.ClassicELB, error) {
-	elbName, err := GenerateELBName(s.scope.Name())
+	elbName, err := s.apiServerELBName()
 	if err != nil {
 		return nil, err
 	}
@@ -346,12 +359,7 @@ func (s *Service) getAPIServerClassicELBSpec() (*infrav1.ClassicELB, error) {
 		res.Attributes.CrossZoneLoadBalancing = s.scope.ControlPlaneLoadBalancer().CrossZoneLoadBalancing
 	}
 
-	res.Tags = infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.Name(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Role:        aws.String(infrav1.APIServerRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
-	})
+	res.Tags = s.getAPIServerELBTags()
 
 	// If subnet IDs have been specified for this load balancer
 	if s.scope.ControlPlaneLoadBalancer() != nil && len(s.scope.ControlPlaneLoadBalancer().Subnets) > 0 {
@@ -393,6 +401,15 @@ func (s *Service) getAPIServerClassicELBSpec() (*infrav1.ClassicELB, error) {
 	return res, nil
 }
 
+func (s *Service) getAPIServerELBTags() infrav1.Tags {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Role:        aws.String(infrav1.APIServerRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	})
+}
+
 func (s *Service) createClassicELB(spec *infrav1.ClassicELB) (*infrav1.ClassicELB, error) {
 	input := &elb.CreateLoadBalancerInput{
 		LoadBalancerName: aws.String(spec.Name),
@@ -191,6 +191,7 @@ func TestDeleteLoadbalancers(t *testing.T) {
 	clusterName := "bar"
 	tests := []struct {
 		name                  string
+		lbName                *string
 		rgAPIMocks            func(m *mock_resourcegroupstaggingapiiface.MockResourceGroupsTaggingAPIAPIMockRecorder)
 		elbAPIMocks           func(m *mock_elbiface.MockELBAPIMockRecorder)
 		postDeleteElbAPIMocks func(m *mock_elbiface.MockELBAPIMockRecorder)
@@ -209,6 +210,21 @@ func TestDeleteLoadbalancers(t *testing.T) {
 				})).Return(nil, awserr.New(elb.ErrCodeAccessPointNotFoundException, "", nil))
 			},
 		},
+		{
+			name:   "deletes an adopted ELB with a custom name",
+			lbName: aws.String("legacy-apiserver"),
+			rgAPIMocks: func(m *mock_resourcegroupstaggingapiiface.MockResourceGroupsTaggingAPIAPIMockRecorder) {
+				m.GetResourcesPages(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			},
+			elbAPIMocks: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				m.DeleteLoadBalancer(gomock.Eq(&elb.DeleteLoadBalancerInput{LoadBalancerName: aws.String("legacy-apiserver")})).Return(nil, nil)
+			},
+			postDeleteElbAPIMocks: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				m.DescribeLoadBalancers(gomock.Eq(&elb.DescribeLoadBalancersInput{
+					LoadBalancerNames: aws.StringSlice([]string{"legacy-apiserver"}),
+				})).Return(nil, awserr.New(elb.ErrCodeAccessPointNotFoundException, "", nil))
+			},
+		},
 		{
 			name: "successful delete. falls back to listing all ELBs when listing by tag fails",
 			rgAPIMocks: func(m *mock_resourcegroupstaggingapiiface.MockResourceGroupsTaggingAPIAPIMockRecorder) {
@@ -278,7 +294,9 @@ func TestDeleteLoadbalancers(t *testing.T) {
 			}
 
 			awsCluster := &infrav1.AWSCluster{
-				Spec: infrav1.AWSClusterSpec{},
+				Spec: infrav1.AWSClusterSpec{
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{Name: tc.lbName},
+				},
 			}
 
 			client := fake.NewFakeClientWithScheme(scheme)
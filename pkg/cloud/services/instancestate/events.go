@@ -0,0 +1,156 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/pkg/errors"
+)
+
+// EventType is the EventBridge detail type of an EC2 instance event.
+type EventType string
+
+var (
+	// EventTypeSpotInterruption is sent two minutes before a Spot instance is interrupted.
+	EventTypeSpotInterruption = EventType("EC2 Spot Instance Interruption Warning")
+
+	// EventTypeRebalanceRecommendation is sent when a Spot instance is at elevated risk of interruption.
+	EventTypeRebalanceRecommendation = EventType("EC2 Instance Rebalance Recommendation")
+
+	// EventTypeStateChange is sent when an instance changes state.
+	EventTypeStateChange = EventType("EC2 Instance State-change Notification")
+)
+
+const (
+	eventSourceEC2 = "aws.ec2"
+
+	// maxReceivedMessages is the maximum number of messages SQS returns per receive.
+	maxReceivedMessages = 10
+
+	// receiveWaitTimeSeconds enables long polling, which queries all SQS servers
+	// instead of a sample and avoids returning empty responses for non-empty queues.
+	receiveWaitTimeSeconds = 1
+)
+
+// InstanceEvent is an EC2 instance event received from the instance events queue.
+type InstanceEvent struct {
+	// Type is the type of the event.
+	Type EventType
+	// InstanceID is the ID of the instance the event refers to.
+	InstanceID string
+	// State is the new state of the instance, set for state-change notifications.
+	State string
+	// Time is when the event was emitted.
+	Time time.Time
+
+	receiptHandle *string
+}
+
+// eventBridgeEvent is the EventBridge envelope of the messages delivered to the queue.
+type eventBridgeEvent struct {
+	Source     string    `json:"source"`
+	DetailType string    `json:"detail-type"`
+	Time       time.Time `json:"time"`
+	Detail     struct {
+		InstanceID string `json:"instance-id"`
+		State      string `json:"state"`
+	} `json:"detail"`
+}
+
+// ReceiveInstanceEvents receives the pending instance events from the queue of the cluster.
+// Received events must be deleted with DeleteInstanceEvent once handled, otherwise they are
+// delivered again after the visibility timeout of the queue.
+func (s *Service) ReceiveInstanceEvents() ([]*InstanceEvent, error) {
+	queueURL := s.scope.InstanceEventsQueueURL()
+	if queueURL == "" {
+		return nil, nil
+	}
+
+	out, err := s.SQSClient.ReceiveMessage(&sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(queueURL),
+		MaxNumberOfMessages: aws.Int64(maxReceivedMessages),
+		WaitTimeSeconds:     aws.Int64(receiveWaitTimeSeconds),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to receive messages from queue %q", queueURL)
+	}
+
+	events := make([]*InstanceEvent, 0, len(out.Messages))
+	for _, msg := range out.Messages {
+		event, err := parseInstanceEvent(aws.StringValue(msg.Body))
+		if err != nil {
+			// Messages that are not instance events would be delivered forever, drop them.
+			s.scope.Info("Dropping unexpected message from instance events queue", "message-id", aws.StringValue(msg.MessageId), "reason", err.Error())
+			if err := s.deleteMessage(queueURL, msg.ReceiptHandle); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		event.receiptHandle = msg.ReceiptHandle
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// DeleteInstanceEvent deletes a handled instance event from the queue of the cluster.
+func (s *Service) DeleteInstanceEvent(event *InstanceEvent) error {
+	return s.deleteMessage(s.scope.InstanceEventsQueueURL(), event.receiptHandle)
+}
+
+func (s *Service) deleteMessage(queueURL string, receiptHandle *string) error {
+	if _, err := s.SQSClient.DeleteMessage(&sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: receiptHandle,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete message from queue %q", queueURL)
+	}
+	return nil
+}
+
+func parseInstanceEvent(body string) (*InstanceEvent, error) {
+	e := &eventBridgeEvent{}
+	if err := json.Unmarshal([]byte(body), e); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal event")
+	}
+
+	if e.Source != eventSourceEC2 {
+		return nil, errors.Errorf("unexpected event source %q", e.Source)
+	}
+
+	eventType := EventType(e.DetailType)
+	switch eventType {
+	case EventTypeSpotInterruption, EventTypeRebalanceRecommendation, EventTypeStateChange:
+	default:
+		return nil, errors.Errorf("unexpected event type %q", e.DetailType)
+	}
+
+	if e.Detail.InstanceID == "" {
+		return nil, errors.New("event has no instance id")
+	}
+
+	return &InstanceEvent{
+		Type:       eventType,
+		InstanceID: e.Detail.InstanceID,
+		State:      e.Detail.State,
+		Time:       e.Time,
+	}, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+)
+
+// localQueue is an in-memory stand-in for an SQS queue. Received messages
+// stay on the queue until they are deleted.
+type localQueue struct {
+	sqsiface.SQSAPI
+
+	next     int
+	handles  []string
+	messages map[string]string
+}
+
+func newLocalQueue() *localQueue {
+	return &localQueue{messages: map[string]string{}}
+}
+
+func (q *localQueue) send(body string) {
+	q.next++
+	handle := fmt.Sprintf("receipt-%d", q.next)
+	q.handles = append(q.handles, handle)
+	q.messages[handle] = body
+}
+
+func (q *localQueue) ReceiveMessage(input *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+	out := &sqs.ReceiveMessageOutput{}
+	for _, handle := range q.handles {
+		body, ok := q.messages[handle]
+		if !ok {
+			continue
+		}
+		if int64(len(out.Messages)) == aws.Int64Value(input.MaxNumberOfMessages) {
+			break
+		}
+		out.Messages = append(out.Messages, &sqs.Message{
+			MessageId:     aws.String("id-" + handle),
+			ReceiptHandle: aws.String(handle),
+			Body:          aws.String(body),
+		})
+	}
+	return out, nil
+}
+
+func (q *localQueue) DeleteMessage(input *sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error) {
+	delete(q.messages, aws.StringValue(input.ReceiptHandle))
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func eventBody(detailType, detail string) string {
+	return fmt.Sprintf(`{"version":"0","id":"1","detail-type":%q,"source":"aws.ec2","account":"123456789012","time":"2020-11-05T10:00:00Z","region":"us-east-1","resources":[],"detail":%s}`, detailType, detail)
+}
+
+func TestReceiveInstanceEvents(t *testing.T) {
+	g := NewWithT(t)
+
+	queue := newLocalQueue()
+	queue.send(eventBody("EC2 Spot Instance Interruption Warning", `{"instance-id":"i-spot","instance-action":"terminate"}`))
+	queue.send(eventBody("EC2 Instance Rebalance Recommendation", `{"instance-id":"i-rebalance"}`))
+	queue.send(eventBody("EC2 Instance State-change Notification", `{"instance-id":"i-stopped","state":"stopped"}`))
+	queue.send(`not json`)
+	queue.send(`{"source":"aws.autoscaling","detail-type":"EC2 Instance Launch Successful","detail":{}}`)
+
+	s, _ := newTestService(g, &infrav1.InstanceEventsSpec{}, testQueueURL)
+	s.SQSClient = queue
+
+	events, err := s.ReceiveInstanceEvents()
+	g.Expect(err).To(BeNil())
+	g.Expect(events).To(HaveLen(3))
+
+	g.Expect(events[0].Type).To(Equal(EventTypeSpotInterruption))
+	g.Expect(events[0].InstanceID).To(Equal("i-spot"))
+	g.Expect(events[0].Time).To(Equal(time.Date(2020, 11, 5, 10, 0, 0, 0, time.UTC)))
+	g.Expect(events[1].Type).To(Equal(EventTypeRebalanceRecommendation))
+	g.Expect(events[1].InstanceID).To(Equal("i-rebalance"))
+	g.Expect(events[2].Type).To(Equal(EventTypeStateChange))
+	g.Expect(events[2].InstanceID).To(Equal("i-stopped"))
+	g.Expect(events[2].State).To(Equal("stopped"))
+
+	// Unexpected messages are dropped, instance events stay until deleted.
+	g.Expect(queue.messages).To(HaveLen(3))
+
+	for _, e := range events {
+		g.Expect(s.DeleteInstanceEvent(e)).To(Succeed())
+	}
+	g.Expect(queue.messages).To(BeEmpty())
+
+	events, err = s.ReceiveInstanceEvents()
+	g.Expect(err).To(BeNil())
+	g.Expect(events).To(BeEmpty())
+}
+
+func TestReceiveInstanceEventsWithoutQueue(t *testing.T) {
+	g := NewWithT(t)
+
+	s, _ := newTestService(g, &infrav1.InstanceEventsSpec{}, "")
+	s.SQSClient = newLocalQueue()
+
+	events, err := s.ReceiveInstanceEvents()
+	g.Expect(err).To(BeNil())
+	g.Expect(events).To(BeEmpty())
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/cmd/clusterawsadm/api/iam/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+const (
+	// queueTargetID is the ID of the SQS queue target of the EventBridge rules.
+	queueTargetID = "instance-events-queue"
+
+	// queueMessageRetentionPeriod is how long, in seconds, undelivered events are kept.
+	// Spot interruption warnings are issued two minutes ahead, so older events are useless.
+	queueMessageRetentionPeriod = "300"
+)
+
+// instanceEventRules are the EventBridge rules feeding the managed queue, keyed by rule name suffix.
+var instanceEventRules = []struct {
+	suffix    string
+	eventType EventType
+}{
+	{suffix: "spot-interruption", eventType: EventTypeSpotInterruption},
+	{suffix: "rebalance-recommendation", eventType: EventTypeRebalanceRecommendation},
+	{suffix: "instance-state-change", eventType: EventTypeStateChange},
+}
+
+// ReconcileInstanceEvents ensures the queue instance events are consumed from exists and records
+// its URL in the cluster status. Unless the cluster consumes an existing queue, the queue and the
+// EventBridge rules feeding it are created and tagged as owned by the cluster.
+func (s *Service) ReconcileInstanceEvents() error {
+	spec := s.scope.InstanceEvents()
+	if spec == nil {
+		return nil
+	}
+
+	if spec.QueueURL != "" {
+		s.scope.SetInstanceEventsQueueURL(spec.QueueURL)
+		return nil
+	}
+
+	queueURL, queueARN, err := s.reconcileQueue()
+	if err != nil {
+		return err
+	}
+
+	ruleARNs := make([]string, 0, len(instanceEventRules))
+	for _, rule := range instanceEventRules {
+		ruleARN, err := s.reconcileRule(s.ruleName(rule.suffix), rule.eventType, queueARN)
+		if err != nil {
+			return err
+		}
+		ruleARNs = append(ruleARNs, ruleARN)
+	}
+
+	if err := s.reconcileQueuePolicy(queueURL, queueARN, ruleARNs); err != nil {
+		return err
+	}
+
+	s.scope.SetInstanceEventsQueueURL(queueURL)
+	return nil
+}
+
+// DeleteInstanceEvents deletes the queue and EventBridge rules owned by the cluster.
+// Queues consumed but not managed by the cluster are left untouched.
+func (s *Service) DeleteInstanceEvents() error {
+	if spec := s.scope.InstanceEvents(); spec != nil && spec.QueueURL != "" {
+		return nil
+	}
+
+	for _, rule := range instanceEventRules {
+		if err := s.deleteRule(s.ruleName(rule.suffix)); err != nil {
+			return err
+		}
+	}
+
+	out, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String(s.queueName())})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == sqs.ErrCodeQueueDoesNotExist {
+			s.scope.SetInstanceEventsQueueURL("")
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get url of queue %q", s.queueName())
+	}
+
+	if _, err := s.SQSClient.DeleteQueue(&sqs.DeleteQueueInput{QueueUrl: out.QueueUrl}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteInstanceEventsQueue", "Failed to delete instance events queue %q: %v", s.queueName(), err)
+		return errors.Wrapf(err, "failed to delete queue %q", s.queueName())
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteInstanceEventsQueue", "Deleted instance events queue %q", s.queueName())
+	s.scope.V(2).Info("Deleted instance events queue", "queue-url", aws.StringValue(out.QueueUrl))
+	s.scope.SetInstanceEventsQueueURL("")
+
+	return nil
+}
+
+func (s *Service) reconcileQueue() (string, string, error) {
+	// CreateQueue is idempotent and returns the URL of the existing queue
+	// when one with the same name and attributes already exists.
+	out, err := s.SQSClient.CreateQueue(&sqs.CreateQueueInput{
+		QueueName: aws.String(s.queueName()),
+		Attributes: map[string]*string{
+			sqs.QueueAttributeNameMessageRetentionPeriod: aws.String(queueMessageRetentionPeriod),
+		},
+		Tags: aws.StringMap(s.tags(s.queueName())),
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateInstanceEventsQueue", "Failed to create instance events queue %q: %v", s.queueName(), err)
+		return "", "", errors.Wrapf(err, "failed to create queue %q", s.queueName())
+	}
+
+	attrs, err := s.SQSClient.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       out.QueueUrl,
+		AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn}),
+	})
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get attributes of queue %q", s.queueName())
+	}
+
+	queueARN := aws.StringValue(attrs.Attributes[sqs.QueueAttributeNameQueueArn])
+	if queueARN == "" {
+		return "", "", errors.Errorf("queue %q has no ARN", s.queueName())
+	}
+
+	return aws.StringValue(out.QueueUrl), queueARN, nil
+}
+
+func (s *Service) reconcileRule(name string, eventType EventType, queueARN string) (string, error) {
+	pattern, err := json.Marshal(map[string][]string{
+		"source":      {eventSourceEC2},
+		"detail-type": {string(eventType)},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal event pattern of rule %q", name)
+	}
+
+	out, err := s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+		Name:         aws.String(name),
+		Description:  aws.String(fmt.Sprintf("Forwards %s events to the instance events queue of cluster %s", eventType, s.scope.Name())),
+		EventPattern: aws.String(string(pattern)),
+		State:        aws.String(eventbridge.RuleStateEnabled),
+		Tags:         converters.MapToEventBridgeTags(s.tags(name)),
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedPutInstanceEventsRule", "Failed to put EventBridge rule %q: %v", name, err)
+		return "", errors.Wrapf(err, "failed to put rule %q", name)
+	}
+
+	targets, err := s.EventBridgeClient.PutTargets(&eventbridge.PutTargetsInput{
+		Rule: aws.String(name),
+		Targets: []*eventbridge.Target{
+			{
+				Id:  aws.String(queueTargetID),
+				Arn: aws.String(queueARN),
+			},
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to put targets of rule %q", name)
+	}
+	if aws.Int64Value(targets.FailedEntryCount) > 0 {
+		entry := targets.FailedEntries[0]
+		return "", errors.Errorf("failed to put target of rule %q: %s", name, aws.StringValue(entry.ErrorMessage))
+	}
+
+	s.scope.V(2).Info("Reconciled instance events rule", "rule", name)
+	return aws.StringValue(out.RuleArn), nil
+}
+
+func (s *Service) reconcileQueuePolicy(queueURL, queueARN string, ruleARNs []string) error {
+	policy, err := json.Marshal(&iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		Statement: iamv1.Statements{
+			{
+				Effect:    iamv1.EffectAllow,
+				Principal: iamv1.Principals{iamv1.PrincipalService: iamv1.PrincipalID{"events.amazonaws.com"}},
+				Action:    iamv1.Actions{"sqs:SendMessage"},
+				Resource:  iamv1.Resources{queueARN},
+				Condition: iamv1.Conditions{
+					iamv1.ArnEquals: map[string][]string{"aws:SourceArn": ruleARNs},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal policy of queue %q", s.queueName())
+	}
+
+	if _, err := s.SQSClient.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		Attributes: map[string]*string{
+			sqs.QueueAttributeNamePolicy: aws.String(string(policy)),
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to set policy of queue %q", s.queueName())
+	}
+
+	return nil
+}
+
+func (s *Service) deleteRule(name string) error {
+	if _, err := s.EventBridgeClient.RemoveTargets(&eventbridge.RemoveTargetsInput{
+		Rule: aws.String(name),
+		Ids:  aws.StringSlice([]string{queueTargetID}),
+	}); err != nil {
+		if code, _ := awserrors.Code(err); code == eventbridge.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to remove targets of rule %q", name)
+	}
+
+	if _, err := s.EventBridgeClient.DeleteRule(&eventbridge.DeleteRuleInput{Name: aws.String(name)}); err != nil {
+		if code, _ := awserrors.Code(err); code == eventbridge.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteInstanceEventsRule", "Failed to delete EventBridge rule %q: %v", name, err)
+		return errors.Wrapf(err, "failed to delete rule %q", name)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteInstanceEventsRule", "Deleted EventBridge rule %q", name)
+	s.scope.V(2).Info("Deleted instance events rule", "rule", name)
+
+	return nil
+}
+
+// queueName returns the name of the managed queue. Queue names only allow
+// alphanumeric characters, hyphens and underscores.
+func (s *Service) queueName() string {
+	return fmt.Sprintf("%s-instance-events", strings.ReplaceAll(s.scope.Name(), ".", "-"))
+}
+
+func (s *Service) ruleName(suffix string) string {
+	return fmt.Sprintf("%s-%s", s.scope.Name(), suffix)
+}
+
+func (s *Service) tags(name string) infrav1.Tags {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Additional:  s.scope.AdditionalTags(),
+	})
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/instancestate/mock_eventbridgeiface"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/instancestate/mock_sqsiface"
+)
+
+const (
+	testQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-cluster-instance-events"
+	testQueueARN = "arn:aws:sqs:us-east-1:123456789012:test-cluster-instance-events"
+)
+
+func TestReconcileInstanceEvents(t *testing.T) {
+	tests := []struct {
+		name             string
+		instanceEvents   *infrav1.InstanceEventsSpec
+		expectSQS        func(m *mock_sqsiface.MockSQSAPIMockRecorder)
+		expectEB         func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+		expectError      bool
+		expectedQueueURL string
+	}{
+		{
+			name:           "disabled instance events is a no-op",
+			instanceEvents: nil,
+			expectSQS:      func(m *mock_sqsiface.MockSQSAPIMockRecorder) {},
+			expectEB:       func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {},
+		},
+		{
+			name:             "existing queue is consumed as is",
+			instanceEvents:   &infrav1.InstanceEventsSpec{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/existing"},
+			expectSQS:        func(m *mock_sqsiface.MockSQSAPIMockRecorder) {},
+			expectEB:         func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {},
+			expectedQueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/existing",
+		},
+		{
+			name:           "managed queue and rules are created",
+			instanceEvents: &infrav1.InstanceEventsSpec{},
+			expectSQS: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.CreateQueue(gomock.Eq(&sqs.CreateQueueInput{
+					QueueName: aws.String("test-cluster-instance-events"),
+					Attributes: map[string]*string{
+						sqs.QueueAttributeNameMessageRetentionPeriod: aws.String("300"),
+					},
+					Tags: map[string]*string{
+						"Name": aws.String("test-cluster-instance-events"),
+						"sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster": aws.String("owned"),
+					},
+				})).Return(&sqs.CreateQueueOutput{QueueUrl: aws.String(testQueueURL)}, nil)
+				m.GetQueueAttributes(gomock.Any()).
+					Return(&sqs.GetQueueAttributesOutput{
+						Attributes: map[string]*string{sqs.QueueAttributeNameQueueArn: aws.String(testQueueARN)},
+					}, nil)
+				m.SetQueueAttributes(gomock.Any()).
+					DoAndReturn(func(input *sqs.SetQueueAttributesInput) (*sqs.SetQueueAttributesOutput, error) {
+						policy := aws.StringValue(input.Attributes[sqs.QueueAttributeNamePolicy])
+						if aws.StringValue(input.QueueUrl) != testQueueURL || policy == "" {
+							t.Fatalf("unexpected queue policy update: %v", input)
+						}
+						return &sqs.SetQueueAttributesOutput{}, nil
+					})
+			},
+			expectEB: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				for _, name := range []string{"test-cluster-spot-interruption", "test-cluster-rebalance-recommendation", "test-cluster-instance-state-change"} {
+					ruleName := name
+					m.PutRule(gomock.Any()).
+						DoAndReturn(func(input *eventbridge.PutRuleInput) (*eventbridge.PutRuleOutput, error) {
+							if aws.StringValue(input.Name) != ruleName {
+								t.Fatalf("expected rule %q, got %q", ruleName, aws.StringValue(input.Name))
+							}
+							return &eventbridge.PutRuleOutput{RuleArn: aws.String("arn:aws:events:us-east-1:123456789012:rule/" + ruleName)}, nil
+						})
+					m.PutTargets(gomock.Eq(&eventbridge.PutTargetsInput{
+						Rule: aws.String(ruleName),
+						Targets: []*eventbridge.Target{
+							{Id: aws.String("instance-events-queue"), Arn: aws.String(testQueueARN)},
+						},
+					})).Return(&eventbridge.PutTargetsOutput{FailedEntryCount: aws.Int64(0)}, nil)
+				}
+			},
+			expectedQueueURL: testQueueURL,
+		},
+		{
+			name:           "queue creation fails",
+			instanceEvents: &infrav1.InstanceEventsSpec{},
+			expectSQS: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.CreateQueue(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			expectEB:    func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {},
+			expectError: true,
+		},
+		{
+			name:           "rule target fails",
+			instanceEvents: &infrav1.InstanceEventsSpec{},
+			expectSQS: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.CreateQueue(gomock.Any()).Return(&sqs.CreateQueueOutput{QueueUrl: aws.String(testQueueURL)}, nil)
+				m.GetQueueAttributes(gomock.Any()).
+					Return(&sqs.GetQueueAttributesOutput{
+						Attributes: map[string]*string{sqs.QueueAttributeNameQueueArn: aws.String(testQueueARN)},
+					}, nil)
+			},
+			expectEB: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.PutRule(gomock.Any()).Return(&eventbridge.PutRuleOutput{RuleArn: aws.String("arn")}, nil)
+				m.PutTargets(gomock.Any()).Return(&eventbridge.PutTargetsOutput{
+					FailedEntryCount: aws.Int64(1),
+					FailedEntries:    []*eventbridge.PutTargetsResultEntry{{ErrorMessage: aws.String("denied")}},
+				}, nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+			ebMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+
+			s, clusterScope := newTestService(g, tc.instanceEvents, "")
+			s.SQSClient = sqsMock
+			s.EventBridgeClient = ebMock
+			tc.expectSQS(sqsMock.EXPECT())
+			tc.expectEB(ebMock.EXPECT())
+
+			err := s.ReconcileInstanceEvents()
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(clusterScope.InstanceEventsQueueURL()).To(Equal(tc.expectedQueueURL))
+		})
+	}
+}
+
+func TestDeleteInstanceEvents(t *testing.T) {
+	notFound := awserr.New(eventbridge.ErrCodeResourceNotFoundException, "not found", nil)
+
+	tests := []struct {
+		name           string
+		instanceEvents *infrav1.InstanceEventsSpec
+		expectSQS      func(m *mock_sqsiface.MockSQSAPIMockRecorder)
+		expectEB       func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+		expectError    bool
+	}{
+		{
+			name:           "existing queue is left untouched",
+			instanceEvents: &infrav1.InstanceEventsSpec{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/existing"},
+			expectSQS:      func(m *mock_sqsiface.MockSQSAPIMockRecorder) {},
+			expectEB:       func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {},
+		},
+		{
+			name:           "managed queue and rules are deleted",
+			instanceEvents: &infrav1.InstanceEventsSpec{},
+			expectSQS: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.Eq(&sqs.GetQueueUrlInput{QueueName: aws.String("test-cluster-instance-events")})).
+					Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String(testQueueURL)}, nil)
+				m.DeleteQueue(gomock.Eq(&sqs.DeleteQueueInput{QueueUrl: aws.String(testQueueURL)})).
+					Return(&sqs.DeleteQueueOutput{}, nil)
+			},
+			expectEB: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.RemoveTargets(gomock.Any()).Return(&eventbridge.RemoveTargetsOutput{}, nil).Times(3)
+				m.DeleteRule(gomock.Any()).Return(&eventbridge.DeleteRuleOutput{}, nil).Times(3)
+			},
+		},
+		{
+			name:           "missing queue and rules are ignored",
+			instanceEvents: nil,
+			expectSQS: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.Any()).
+					Return(nil, awserr.New(sqs.ErrCodeQueueDoesNotExist, "not found", nil))
+			},
+			expectEB: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.RemoveTargets(gomock.Any()).Return(nil, notFound).Times(3)
+			},
+		},
+		{
+			name:           "rule deletion fails",
+			instanceEvents: &infrav1.InstanceEventsSpec{},
+			expectSQS:      func(m *mock_sqsiface.MockSQSAPIMockRecorder) {},
+			expectEB: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.RemoveTargets(gomock.Any()).Return(&eventbridge.RemoveTargetsOutput{}, nil)
+				m.DeleteRule(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+			ebMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+
+			s, _ := newTestService(g, tc.instanceEvents, testQueueURL)
+			s.SQSClient = sqsMock
+			s.EventBridgeClient = ebMock
+			tc.expectSQS(sqsMock.EXPECT())
+			tc.expectEB(ebMock.EXPECT())
+
+			err := s.DeleteInstanceEvents()
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
+func newTestService(g *WithT, instanceEvents *infrav1.InstanceEventsSpec, queueURL string) (*Service, *scope.ClusterScope) {
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewFakeClientWithScheme(scheme),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec:   infrav1.AWSClusterSpec{InstanceEvents: instanceEvents},
+			Status: infrav1.AWSClusterStatus{InstanceEventsQueueURL: queueURL},
+		},
+	})
+	g.Expect(err).To(BeNil())
+
+	return NewService(clusterScope), clusterScope
+}
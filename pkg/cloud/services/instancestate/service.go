@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+)
+
+// Scope is scope for use with the instance state service
+type Scope interface {
+	cloud.ClusterScoper
+
+	// InstanceEvents returns the instance event processing configuration of the cluster.
+	InstanceEvents() *infrav1.InstanceEventsSpec
+	// InstanceEventsQueueURL returns the URL of the queue instance events are consumed from.
+	InstanceEventsQueueURL() string
+	// SetInstanceEventsQueueURL sets the URL of the queue instance events are consumed from.
+	SetInstanceEventsQueueURL(url string)
+}
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+// One alternative is to have a large list of functions from the ec2 client.
+type Service struct {
+	scope             Scope
+	EventBridgeClient eventbridgeiface.EventBridgeAPI
+	SQSClient         sqsiface.SQSAPI
+}
+
+// NewService returns a new service given the api clients.
+func NewService(instanceStateScope Scope) *Service {
+	return &Service{
+		scope:             instanceStateScope,
+		EventBridgeClient: scope.NewEventBridgeClient(instanceStateScope, instanceStateScope, instanceStateScope.InfraCluster()),
+		SQSClient:         scope.NewSQSClient(instanceStateScope, instanceStateScope, instanceStateScope.InfraCluster()),
+	}
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package services
 
 import (
+	"time"
+
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/exp/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/instancestate"
 )
 
 const (
@@ -47,6 +50,7 @@ type EC2MachineInterface interface {
 	TerminateInstance(id string) error
 	CreateInstance(scope *scope.MachineScope, userData []byte) (*infrav1.Instance, error)
 	GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error)
+	AdoptInstance(scope *scope.MachineScope, instanceID string) (*infrav1.Instance, error)
 
 	GetCoreSecurityGroups(machine *scope.MachineScope) ([]string, error)
 	GetInstanceSecurityGroups(instanceID string) (map[string][]string, error)
@@ -54,7 +58,18 @@ type EC2MachineInterface interface {
 	UpdateResourceTags(resourceID *string, create, remove map[string]string) error
 
 	TerminateInstanceAndWait(instanceID string) error
+	StopInstance(instanceID string) error
+	StartInstance(instanceID string) error
+	RebootInstance(instanceID string) error
+	ModifyInstanceType(instanceID, instanceType string) error
+	ReleaseDedicatedHosts() error
 	DetachSecurityGroupsFromNetworkInterface(groups []string, interfaceID string) error
+	ReconcileSecondaryNetworkInterfaces(scope *scope.MachineScope, instance *infrav1.Instance) ([]infrav1.NetworkInterfaceStatus, error)
+	DeleteSecondaryNetworkInterfaces(scope *scope.MachineScope) error
+	ReconcileVolumes(scope *scope.MachineScope, instance *infrav1.Instance) (modifying bool, volumeIDs []string, err error)
+	ReconcileInstanceHealth(scope *scope.MachineScope, instance *infrav1.Instance, volumeIDs []string) error
+	GetConsoleOutput(instanceID string) (string, error)
+	GetConsoleScreenshot(instanceID string) ([]byte, error)
 
 	DiscoverLaunchTemplateAMI(scope *scope.MachinePoolScope) (*string, error)
 	GetLaunchTemplate(id string) (*expinfrav1.AWSLaunchTemplate, error)
@@ -67,7 +82,50 @@ type EC2MachineInterface interface {
 // SecretInterface encapsulated the methods exposed to the
 // machine actuator
 type SecretInterface interface {
-	Delete(m *scope.MachineScope) error
-	Create(m *scope.MachineScope, data []byte) (string, int32, error)
-	UserData(secretPrefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) ([]byte, error)
+	Delete(m scope.SecretScope) error
+	Create(m scope.SecretScope, data []byte) (string, int32, error)
+	UserData(secretPrefix string, chunks int32, keepSecrets bool, region string, endpoints []scope.ServiceEndpoint) ([]byte, error)
+	WindowsUserData(secretPrefix string, chunks int32, keepSecrets bool, region string, endpoints []scope.ServiceEndpoint) ([]byte, error)
+}
+
+// SecretSweeperInterface encapsulates the methods exposed to the
+// bootstrap secrets sweeper
+type SecretSweeperInterface interface {
+	ListOwnedSecrets() ([]*BootstrapSecret, error)
+	DeleteOwnedSecret(secret *BootstrapSecret) error
+}
+
+// BootstrapSecret is a set of secrets holding the bootstrap data of a machine or machine pool,
+// as created by a SecretInterface.
+type BootstrapSecret struct {
+	// Prefix is the prefix of the set, as recorded in the spec of the machine.
+	Prefix string
+	// Names are the names of the secrets of the set which still exist.
+	Names []string
+	// Owner is the name of the machine or machine pool the set was created for, when known.
+	Owner string
+	// LastModified is when a secret of the set was last created or modified.
+	LastModified time.Time
+}
+
+// ObjectStoreInterface encapsulates the methods exposed to the machine
+// actuators to pass bootstrap data to instances through S3
+type ObjectStoreInterface interface {
+	CreateObject(key string, data []byte) (string, error)
+	DeleteObject(key string) error
+}
+
+// ELBInterface encapsulates the methods exposed to the machine
+// actuator to attach control plane instances to the API server load balancer
+type ELBInterface interface {
+	InstanceIsRegisteredWithAPIServerELB(i *infrav1.Instance) (bool, error)
+	RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error
+	DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error
+}
+
+// InstanceStateInterface encapsulates the methods exposed to the
+// instance state controller
+type InstanceStateInterface interface {
+	ReceiveInstanceEvents() ([]*instancestate.InstanceEvent, error)
+	DeleteInstanceEvent(event *instancestate.InstanceEvent) error
 }
@@ -51,6 +51,21 @@ func (m *MockEC2MachineInterface) EXPECT() *MockEC2MachineInterfaceMockRecorder
 	return m.recorder
 }
 
+// AdoptInstance mocks base method
+func (m *MockEC2MachineInterface) AdoptInstance(arg0 *scope.MachineScope, arg1 string) (*v1alpha3.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdoptInstance", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha3.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdoptInstance indicates an expected call of AdoptInstance
+func (mr *MockEC2MachineInterfaceMockRecorder) AdoptInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdoptInstance", reflect.TypeOf((*MockEC2MachineInterface)(nil).AdoptInstance), arg0, arg1)
+}
+
 // CreateInstance mocks base method
 func (m *MockEC2MachineInterface) CreateInstance(arg0 *scope.MachineScope, arg1 []byte) (*v1alpha3.Instance, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +124,20 @@ func (mr *MockEC2MachineInterfaceMockRecorder) DeleteLaunchTemplate(arg0 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLaunchTemplate", reflect.TypeOf((*MockEC2MachineInterface)(nil).DeleteLaunchTemplate), arg0)
 }
 
+// DeleteSecondaryNetworkInterfaces mocks base method
+func (m *MockEC2MachineInterface) DeleteSecondaryNetworkInterfaces(arg0 *scope.MachineScope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecondaryNetworkInterfaces", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecondaryNetworkInterfaces indicates an expected call of DeleteSecondaryNetworkInterfaces
+func (mr *MockEC2MachineInterfaceMockRecorder) DeleteSecondaryNetworkInterfaces(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecondaryNetworkInterfaces", reflect.TypeOf((*MockEC2MachineInterface)(nil).DeleteSecondaryNetworkInterfaces), arg0)
+}
+
 // DetachSecurityGroupsFromNetworkInterface mocks base method
 func (m *MockEC2MachineInterface) DetachSecurityGroupsFromNetworkInterface(arg0 []string, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -138,6 +167,36 @@ func (mr *MockEC2MachineInterfaceMockRecorder) DiscoverLaunchTemplateAMI(arg0 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverLaunchTemplateAMI", reflect.TypeOf((*MockEC2MachineInterface)(nil).DiscoverLaunchTemplateAMI), arg0)
 }
 
+// GetConsoleOutput mocks base method
+func (m *MockEC2MachineInterface) GetConsoleOutput(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsoleOutput", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsoleOutput indicates an expected call of GetConsoleOutput
+func (mr *MockEC2MachineInterfaceMockRecorder) GetConsoleOutput(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsoleOutput", reflect.TypeOf((*MockEC2MachineInterface)(nil).GetConsoleOutput), arg0)
+}
+
+// GetConsoleScreenshot mocks base method
+func (m *MockEC2MachineInterface) GetConsoleScreenshot(arg0 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsoleScreenshot", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsoleScreenshot indicates an expected call of GetConsoleScreenshot
+func (mr *MockEC2MachineInterfaceMockRecorder) GetConsoleScreenshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsoleScreenshot", reflect.TypeOf((*MockEC2MachineInterface)(nil).GetConsoleScreenshot), arg0)
+}
+
 // GetCoreSecurityGroups mocks base method
 func (m *MockEC2MachineInterface) GetCoreSecurityGroups(arg0 *scope.MachineScope) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -228,6 +287,121 @@ func (mr *MockEC2MachineInterfaceMockRecorder) LaunchTemplateNeedsUpdate(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LaunchTemplateNeedsUpdate", reflect.TypeOf((*MockEC2MachineInterface)(nil).LaunchTemplateNeedsUpdate), arg0, arg1, arg2)
 }
 
+// ModifyInstanceType mocks base method
+func (m *MockEC2MachineInterface) ModifyInstanceType(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyInstanceType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyInstanceType indicates an expected call of ModifyInstanceType
+func (mr *MockEC2MachineInterfaceMockRecorder) ModifyInstanceType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyInstanceType", reflect.TypeOf((*MockEC2MachineInterface)(nil).ModifyInstanceType), arg0, arg1)
+}
+
+// RebootInstance mocks base method
+func (m *MockEC2MachineInterface) RebootInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebootInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebootInstance indicates an expected call of RebootInstance
+func (mr *MockEC2MachineInterfaceMockRecorder) RebootInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebootInstance", reflect.TypeOf((*MockEC2MachineInterface)(nil).RebootInstance), arg0)
+}
+
+// ReconcileInstanceHealth mocks base method
+func (m *MockEC2MachineInterface) ReconcileInstanceHealth(arg0 *scope.MachineScope, arg1 *v1alpha3.Instance, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileInstanceHealth", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileInstanceHealth indicates an expected call of ReconcileInstanceHealth
+func (mr *MockEC2MachineInterfaceMockRecorder) ReconcileInstanceHealth(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileInstanceHealth", reflect.TypeOf((*MockEC2MachineInterface)(nil).ReconcileInstanceHealth), arg0, arg1, arg2)
+}
+
+// ReconcileSecondaryNetworkInterfaces mocks base method
+func (m *MockEC2MachineInterface) ReconcileSecondaryNetworkInterfaces(arg0 *scope.MachineScope, arg1 *v1alpha3.Instance) ([]v1alpha3.NetworkInterfaceStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileSecondaryNetworkInterfaces", arg0, arg1)
+	ret0, _ := ret[0].([]v1alpha3.NetworkInterfaceStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileSecondaryNetworkInterfaces indicates an expected call of ReconcileSecondaryNetworkInterfaces
+func (mr *MockEC2MachineInterfaceMockRecorder) ReconcileSecondaryNetworkInterfaces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileSecondaryNetworkInterfaces", reflect.TypeOf((*MockEC2MachineInterface)(nil).ReconcileSecondaryNetworkInterfaces), arg0, arg1)
+}
+
+// ReconcileVolumes mocks base method
+func (m *MockEC2MachineInterface) ReconcileVolumes(arg0 *scope.MachineScope, arg1 *v1alpha3.Instance) (bool, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileVolumes", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReconcileVolumes indicates an expected call of ReconcileVolumes
+func (mr *MockEC2MachineInterfaceMockRecorder) ReconcileVolumes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileVolumes", reflect.TypeOf((*MockEC2MachineInterface)(nil).ReconcileVolumes), arg0, arg1)
+}
+
+// ReleaseDedicatedHosts mocks base method
+func (m *MockEC2MachineInterface) ReleaseDedicatedHosts() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDedicatedHosts")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDedicatedHosts indicates an expected call of ReleaseDedicatedHosts
+func (mr *MockEC2MachineInterfaceMockRecorder) ReleaseDedicatedHosts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDedicatedHosts", reflect.TypeOf((*MockEC2MachineInterface)(nil).ReleaseDedicatedHosts))
+}
+
+// StartInstance mocks base method
+func (m *MockEC2MachineInterface) StartInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartInstance indicates an expected call of StartInstance
+func (mr *MockEC2MachineInterfaceMockRecorder) StartInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartInstance", reflect.TypeOf((*MockEC2MachineInterface)(nil).StartInstance), arg0)
+}
+
+// StopInstance mocks base method
+func (m *MockEC2MachineInterface) StopInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopInstance indicates an expected call of StopInstance
+func (mr *MockEC2MachineInterfaceMockRecorder) StopInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopInstance", reflect.TypeOf((*MockEC2MachineInterface)(nil).StopInstance), arg0)
+}
+
 // TerminateInstance mocks base method
 func (m *MockEC2MachineInterface) TerminateInstance(arg0 string) error {
 	m.ctrl.T.Helper()
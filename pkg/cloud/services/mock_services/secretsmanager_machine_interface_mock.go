@@ -50,7 +50,7 @@ func (m *MockSecretInterface) EXPECT() *MockSecretInterfaceMockRecorder {
 }
 
 // Create mocks base method
-func (m *MockSecretInterface) Create(arg0 *scope.MachineScope, arg1 []byte) (string, int32, error) {
+func (m *MockSecretInterface) Create(arg0 scope.SecretScope, arg1 []byte) (string, int32, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", arg0, arg1)
 	ret0, _ := ret[0].(string)
@@ -66,7 +66,7 @@ func (mr *MockSecretInterfaceMockRecorder) Create(arg0, arg1 interface{}) *gomoc
 }
 
 // Delete mocks base method
-func (m *MockSecretInterface) Delete(arg0 *scope.MachineScope) error {
+func (m *MockSecretInterface) Delete(arg0 scope.SecretScope) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", arg0)
 	ret0, _ := ret[0].(error)
@@ -80,16 +80,31 @@ func (mr *MockSecretInterfaceMockRecorder) Delete(arg0 interface{}) *gomock.Call
 }
 
 // UserData mocks base method
-func (m *MockSecretInterface) UserData(arg0 string, arg1 int32, arg2 string, arg3 []scope.ServiceEndpoint) ([]byte, error) {
+func (m *MockSecretInterface) UserData(arg0 string, arg1 int32, arg2 bool, arg3 string, arg4 []scope.ServiceEndpoint) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserData", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "UserData", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UserData indicates an expected call of UserData
-func (mr *MockSecretInterfaceMockRecorder) UserData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockSecretInterfaceMockRecorder) UserData(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserData", reflect.TypeOf((*MockSecretInterface)(nil).UserData), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserData", reflect.TypeOf((*MockSecretInterface)(nil).UserData), arg0, arg1, arg2, arg3, arg4)
+}
+
+// WindowsUserData mocks base method
+func (m *MockSecretInterface) WindowsUserData(arg0 string, arg1 int32, arg2 bool, arg3 string, arg4 []scope.ServiceEndpoint) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WindowsUserData", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WindowsUserData indicates an expected call of WindowsUserData
+func (mr *MockSecretInterfaceMockRecorder) WindowsUserData(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WindowsUserData", reflect.TypeOf((*MockSecretInterface)(nil).WindowsUserData), arg0, arg1, arg2, arg3, arg4)
 }
@@ -0,0 +1,252 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/tags"
+)
+
+// adoptedResource is an existing resource to tag as owned by the cluster.
+type adoptedResource struct {
+	kind    string
+	params  infrav1.BuildParams
+	current infrav1.Tags
+	changes []string
+}
+
+// AdoptNetwork takes ownership of the existing VPC and subnets set in the network spec of the cluster, along with
+// the internet gateway of the VPC, and the NAT gateways, their Elastic IPs and the route tables of the subnets, by
+// tagging them as owned by the cluster. The VPC is then managed, and deleted with the cluster, like a VPC created by
+// the cluster. In dry run mode, the tags which would be added are only reported.
+//
+// The changes made to the routing of the subnets when the route tables of the cluster are reconciled after adoption
+// are reported as well: subnets associated with the main route table of the VPC are associated with a route table of
+// their own, and the default routes of the adopted route tables are replaced when they don't target the gateway the
+// cluster expects.
+func (s *Service) AdoptNetwork(dryRun bool) ([]infrav1.AdoptedResource, error) {
+	s.scope.V(2).Info("Adopting network", "vpc-id", s.scope.VPC().ID, "dry-run", dryRun)
+
+	vpc, err := s.describeVPC()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe vpc %q", s.scope.VPC().ID)
+	}
+
+	var resources []adoptedResource
+
+	existingSubnets, err := s.describeVpcSubnets()
+	if err != nil {
+		return nil, err
+	}
+	adoptedSubnets := make(infrav1.Subnets, 0, len(s.scope.Subnets()))
+	for _, sn := range s.scope.Subnets() {
+		existing := existingSubnets.FindEqual(sn)
+		if existing == nil {
+			return nil, errors.Errorf("subnet %q does not exist in vpc %q", sn.ID, vpc.ID)
+		}
+		adoptedSubnets = append(adoptedSubnets, existing)
+		resources = append(resources, adoptedResource{
+			kind:    "Subnet",
+			params:  s.getSubnetTagParams(existing.ID, existing.IsPublic, existing.AvailabilityZone, sn.Tags),
+			current: existing.Tags,
+		})
+	}
+
+	igws, err := s.describeVpcInternetGateways()
+	if err != nil && !awserrors.IsNotFound(err) {
+		return nil, err
+	}
+	for _, igw := range igws {
+		resources = append(resources, adoptedResource{
+			kind:    "InternetGateway",
+			params:  s.getGatewayTagParams(*igw.InternetGatewayId),
+			current: converters.TagsToMap(igw.Tags),
+		})
+	}
+
+	natGateways, err := s.describeNatGatewaysBySubnet()
+	if err != nil {
+		return nil, err
+	}
+	for _, sn := range adoptedSubnets {
+		ngw, ok := natGateways[sn.ID]
+		if !ok {
+			continue
+		}
+		resources = append(resources, adoptedResource{
+			kind:    "NATGateway",
+			params:  s.getNatGatewayTagParams(*ngw.NatGatewayId),
+			current: converters.TagsToMap(ngw.Tags),
+		})
+
+		// The Elastic IPs of the NAT gateways are released with the cluster.
+		for _, address := range ngw.NatGatewayAddresses {
+			if address.AllocationId == nil {
+				continue
+			}
+			eip, err := s.describeAddress(*address.AllocationId)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, adoptedResource{
+				kind:    "ElasticIP",
+				params:  s.getEIPTagParams(*address.AllocationId, infrav1.APIServerRoleTagValue),
+				current: eip,
+			})
+		}
+	}
+
+	routeTables, err := s.describeVpcRouteTablesBySubnet()
+	if err != nil {
+		return nil, err
+	}
+	main := routeTables[mainRouteTableInVPCKey]
+	adoptedRouteTables := make(map[string]int)
+	// The adopted subnets are the first resources, in the same order.
+	for i, sn := range adoptedSubnets {
+		gateway := defaultRouteTarget(sn, adoptedSubnets, igws)
+		target := describeRouteTarget(gateway, sn.IsPublic)
+
+		// The main route table of the VPC can't be deleted with the cluster. Subnets associated with it are
+		// associated with a route table of their own when the route tables of the cluster are reconciled.
+		rt, ok := routeTables[sn.ID]
+		if !ok || (main != nil && *main.RouteTableId == *rt.RouteTableId) {
+			mainID := "<none>"
+			if main != nil {
+				mainID = *main.RouteTableId
+			}
+			resources[i].changes = append(resources[i].changes,
+				fmt.Sprintf("associated with a new route table with a default route to %s instead of the main route table %q", target, mainID))
+			continue
+		}
+
+		index, ok := adoptedRouteTables[*rt.RouteTableId]
+		if !ok {
+			index = len(resources)
+			adoptedRouteTables[*rt.RouteTableId] = index
+			resources = append(resources, adoptedResource{
+				kind:    "RouteTable",
+				params:  s.getRouteTableTagParams(*rt.RouteTableId, sn.IsPublic, sn.AvailabilityZone),
+				current: converters.TagsToMap(rt.Tags),
+			})
+		}
+		for _, route := range rt.Routes {
+			if aws.StringValue(route.DestinationCidrBlock) != services.AnyIPv4CidrBlock {
+				continue
+			}
+			if current := routeTarget(route); current != "" && current != gateway {
+				change := fmt.Sprintf("default route to %q replaced with a route to %s for subnet %q", current, target, sn.ID)
+				resources[index].changes = append(resources[index].changes, change)
+			}
+		}
+	}
+
+	// The VPC is tagged last, as it remains unmanaged until then, so that adoption starts over when it fails.
+	resources = append(resources, adoptedResource{
+		kind:    "VPC",
+		params:  s.getVPCTagParams(vpc.ID),
+		current: vpc.Tags,
+	})
+
+	adopted := make([]infrav1.AdoptedResource, 0, len(resources))
+	for _, resource := range resources {
+		if owner := resource.current.GetOtherOwner(s.scope.Name()); owner != "" {
+			return nil, errors.Errorf("%s %q belongs to cluster %q", resource.kind, resource.params.ResourceID, owner)
+		}
+		adopted = append(adopted, infrav1.AdoptedResource{
+			Kind:    resource.kind,
+			ID:      resource.params.ResourceID,
+			Tags:    infrav1.Build(resource.params).Difference(resource.current),
+			Changes: resource.changes,
+		})
+	}
+
+	if dryRun {
+		return adopted, nil
+	}
+
+	for i := range resources {
+		resource := resources[i]
+		tagsBuilder := tags.New(&resource.params, tags.WithEC2(s.EC2Client))
+		if err := tagsBuilder.Ensure(resource.current); err != nil {
+			return nil, errors.Wrapf(err, "failed to tag %s %q", resource.kind, resource.params.ResourceID)
+		}
+	}
+
+	s.scope.V(2).Info("Adopted network", "vpc-id", vpc.ID)
+	return adopted, nil
+}
+
+// describeAddress returns the tags of the Elastic IP with the given allocation ID.
+func (s *Service) describeAddress(allocationID string) (infrav1.Tags, error) {
+	out, err := s.EC2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		AllocationIds: aws.StringSlice([]string{allocationID}),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe elastic IP %q", allocationID)
+	}
+	if len(out.Addresses) == 0 {
+		return nil, errors.Errorf("elastic IP %q does not exist", allocationID)
+	}
+	return converters.TagsToMap(out.Addresses[0].Tags), nil
+}
+
+// defaultRouteTarget returns the ID of the gateway the default route of the given adopted subnet targets when the
+// route tables of the cluster are reconciled, or an empty string when the gateway doesn't exist yet.
+func defaultRouteTarget(sn *infrav1.SubnetSpec, subnets infrav1.Subnets, igws []*ec2.InternetGateway) string {
+	if sn.IsPublic {
+		if len(igws) == 0 {
+			return ""
+		}
+		return aws.StringValue(igws[0].InternetGatewayId)
+	}
+	for _, psn := range subnets.FilterPublic() {
+		if psn.AvailabilityZone == sn.AvailabilityZone && psn.NatGatewayID != nil {
+			return *psn.NatGatewayID
+		}
+	}
+	return ""
+}
+
+// describeRouteTarget describes the gateway with the given ID, or the gateway to create when the ID is empty.
+func describeRouteTarget(id string, public bool) string {
+	switch {
+	case id != "":
+		return fmt.Sprintf("%q", id)
+	case public:
+		return "a new internet gateway"
+	default:
+		return "a new NAT gateway"
+	}
+}
+
+// routeTarget returns the ID of the gateway targeted by the given route.
+func routeTarget(route *ec2.Route) string {
+	if route.NatGatewayId != nil {
+		return *route.NatGatewayId
+	}
+	return aws.StringValue(route.GatewayId)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestAdoptNetwork(t *testing.T) {
+	legacyTags := []*ec2.Tag{{Key: aws.String("KubernetesCluster"), Value: aws.String("kops.example.com")}}
+
+	expectDescribeNetwork := func(m *mock_ec2iface.MockEC2APIMockRecorder, vpcTags []*ec2.Tag, publicGateway string) {
+		m.DescribeVpcs(gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).
+			Return(&ec2.DescribeVpcsOutput{
+				Vpcs: []*ec2.Vpc{
+					{VpcId: aws.String("vpc-1"), CidrBlock: aws.String("10.0.0.0/16"), State: aws.String(ec2.VpcStateAvailable), Tags: vpcTags},
+				},
+			}, nil)
+		m.DescribeSubnets(gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+			Return(&ec2.DescribeSubnetsOutput{
+				Subnets: []*ec2.Subnet{
+					{SubnetId: aws.String("subnet-public"), CidrBlock: aws.String("10.0.0.0/24"), AvailabilityZone: aws.String("us-east-1a"), Tags: legacyTags},
+					{SubnetId: aws.String("subnet-private"), CidrBlock: aws.String("10.0.1.0/24"), AvailabilityZone: aws.String("us-east-1a"), Tags: legacyTags},
+					{SubnetId: aws.String("subnet-other"), CidrBlock: aws.String("10.0.2.0/24"), AvailabilityZone: aws.String("us-east-1a")},
+				},
+			}, nil)
+		m.DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+			Return(&ec2.DescribeRouteTablesOutput{
+				RouteTables: []*ec2.RouteTable{
+					{
+						RouteTableId: aws.String("rtb-public"),
+						Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}},
+						Routes:       []*ec2.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String(publicGateway)}},
+					},
+					{
+						RouteTableId: aws.String("rtb-main"),
+						Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+						Routes:       []*ec2.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1")}},
+					},
+				},
+			}, nil).Times(2)
+		m.DescribeNatGatewaysPages(gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+			Do(func(_, fn interface{}) {
+				fn.(func(*ec2.DescribeNatGatewaysOutput, bool) bool)(&ec2.DescribeNatGatewaysOutput{
+					NatGateways: []*ec2.NatGateway{
+						{
+							NatGatewayId:        aws.String("nat-1"),
+							SubnetId:            aws.String("subnet-public"),
+							NatGatewayAddresses: []*ec2.NatGatewayAddress{{AllocationId: aws.String("eipalloc-1")}},
+						},
+						{
+							NatGatewayId: aws.String("nat-other"),
+							SubnetId:     aws.String("subnet-other"),
+						},
+					},
+				}, true)
+			}).Return(nil).Times(2)
+		m.DescribeInternetGateways(gomock.AssignableToTypeOf(&ec2.DescribeInternetGatewaysInput{})).
+			Return(&ec2.DescribeInternetGatewaysOutput{
+				InternetGateways: []*ec2.InternetGateway{{InternetGatewayId: aws.String("igw-1"), Tags: legacyTags}},
+			}, nil)
+		m.DescribeAddresses(&ec2.DescribeAddressesInput{AllocationIds: aws.StringSlice([]string{"eipalloc-1"})}).
+			Return(&ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: aws.String("eipalloc-1")}}}, nil)
+	}
+
+	mainRouteTableChange := `associated with a new route table with a default route to "nat-1" instead of the main route table "rtb-main"`
+
+	tests := []struct {
+		name            string
+		vpcTags         []*ec2.Tag
+		publicGateway   string
+		dryRun          bool
+		expectError     bool
+		expectedChanges map[string][]string
+	}{
+		{
+			name:            "tags the vpc, subnets, gateways and route tables of the subnets",
+			vpcTags:         legacyTags,
+			publicGateway:   "igw-1",
+			expectedChanges: map[string][]string{"Subnet/subnet-private": {mainRouteTableChange}},
+		},
+		{
+			name:            "only reports the tags in dry run mode",
+			vpcTags:         legacyTags,
+			publicGateway:   "igw-1",
+			dryRun:          true,
+			expectedChanges: map[string][]string{"Subnet/subnet-private": {mainRouteTableChange}},
+		},
+		{
+			name:          "reports the default routes which are replaced",
+			vpcTags:       legacyTags,
+			publicGateway: "igw-legacy",
+			dryRun:        true,
+			expectedChanges: map[string][]string{
+				"Subnet/subnet-private": {mainRouteTableChange},
+				"RouteTable/rtb-public": {`default route to "igw-legacy" replaced with a route to "igw-1" for subnet "subnet-public"`},
+			},
+		},
+		{
+			name:          "rejects a vpc of another cluster",
+			vpcTags:       []*ec2.Tag{{Key: aws.String(infrav1.ClusterTagKey("other-cluster")), Value: aws.String("owned")}},
+			publicGateway: "igw-1",
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC:     infrav1.VPCSpec{ID: "vpc-1"},
+							Subnets: infrav1.Subnets{{ID: "subnet-public"}, {ID: "subnet-private"}},
+						},
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			expectDescribeNetwork(ec2Mock.EXPECT(), tc.vpcTags, tc.publicGateway)
+			var tagged []string
+			if !tc.dryRun && !tc.expectError {
+				ec2Mock.EXPECT().CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					DoAndReturn(func(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+						tagged = append(tagged, aws.StringValueSlice(input.Resources)...)
+						return &ec2.CreateTagsOutput{}, nil
+					}).Times(7)
+			}
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			adopted, err := s.AdoptNetwork(tc.dryRun)
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+
+			ids := make([]string, 0, len(adopted))
+			changes := make(map[string][]string)
+			for _, resource := range adopted {
+				g.Expect(resource.Tags).To(HaveKeyWithValue(infrav1.ClusterTagKey("test-cluster"), "owned"))
+				ids = append(ids, resource.Kind+"/"+resource.ID)
+				if len(resource.Changes) > 0 {
+					changes[resource.Kind+"/"+resource.ID] = resource.Changes
+				}
+			}
+			g.Expect(changes).To(Equal(tc.expectedChanges))
+			// The main route table isn't adopted, nor are the resources of the other subnets.
+			g.Expect(ids).To(Equal([]string{
+				"Subnet/subnet-public",
+				"Subnet/subnet-private",
+				"InternetGateway/igw-1",
+				"NATGateway/nat-1",
+				"ElasticIP/eipalloc-1",
+				"RouteTable/rtb-public",
+				"VPC/vpc-1",
+			}))
+			if !tc.dryRun {
+				// The VPC is tagged last.
+				g.Expect(tagged).To(HaveLen(7))
+				g.Expect(tagged[6]).To(Equal("vpc-1"))
+			}
+		})
+	}
+
+	t.Run("fails for a subnet which doesn't exist in the vpc", func(t *testing.T) {
+		g := NewWithT(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+		scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			},
+			AWSCluster: &infrav1.AWSCluster{
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC:     infrav1.VPCSpec{ID: "vpc-1"},
+						Subnets: infrav1.Subnets{{ID: "subnet-missing"}},
+					},
+				},
+			},
+		})
+		g.Expect(err).To(BeNil())
+
+		ec2Mock.EXPECT().DescribeVpcs(gomock.Any()).
+			Return(&ec2.DescribeVpcsOutput{
+				Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-1"), CidrBlock: aws.String("10.0.0.0/16"), State: aws.String(ec2.VpcStateAvailable)}},
+			}, nil)
+		ec2Mock.EXPECT().DescribeSubnets(gomock.Any()).Return(&ec2.DescribeSubnetsOutput{}, nil)
+		ec2Mock.EXPECT().DescribeRouteTables(gomock.Any()).Return(&ec2.DescribeRouteTablesOutput{}, nil)
+		ec2Mock.EXPECT().DescribeNatGatewaysPages(gomock.Any(), gomock.Any()).Return(nil)
+
+		s := NewService(scope)
+		s.EC2Client = ec2Mock
+
+		_, err = s.AdoptNetwork(false)
+		g.Expect(err).NotTo(BeNil())
+	})
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/internal/mime"
+)
+
+const (
+	serviceID = "s3"
+)
+
+// UserData creates a multi-part MIME document including a script boothook to
+// download userdata from the cluster's S3 bucket and then restart cloud-init, and an include part
+// specifying the on disk location of the new userdata
+func (s *Service) UserData(secretPrefix string, chunks int32, keepSecrets bool, region string, endpoints []scope.ServiceEndpoint) ([]byte, error) {
+	source, err := s.objectURL(secretPrefix)
+	if err != nil {
+		return []byte{}, err
+	}
+	userData, err := mime.GenerateInitDocument(source, chunks, keepSecrets, region, serviceEndpoint(endpoints), secretFetchScript)
+	if err != nil {
+		return []byte{}, err
+	}
+	return userData, nil
+}
+
+// WindowsUserData creates a PowerShell script to download userdata from the cluster's S3 bucket
+// and run it on Windows instances, which don't run cloud-init
+func (s *Service) WindowsUserData(secretPrefix string, chunks int32, keepSecrets bool, region string, endpoints []scope.ServiceEndpoint) ([]byte, error) {
+	source, err := s.objectURL(secretPrefix)
+	if err != nil {
+		return []byte{}, err
+	}
+	userData, err := mime.GenerateScript(source, chunks, keepSecrets, region, serviceEndpoint(endpoints), windowsSecretFetchScript)
+	if err != nil {
+		return []byte{}, err
+	}
+	return userData, nil
+}
+
+func (s *Service) objectURL(key string) (string, error) {
+	bucket := s.scope.Bucket()
+	if bucket == nil {
+		return "", errors.New("the s3 secure secrets backend requires the cluster to have an S3 bucket")
+	}
+	return fmt.Sprintf("s3://%s/%s", bucket.Name, key), nil
+}
+
+func serviceEndpoint(endpoints []scope.ServiceEndpoint) string {
+	for _, v := range endpoints {
+		if v.ServiceID == serviceID {
+			return v.URL
+		}
+	}
+	return ""
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// Error codes which aren't declared by the S3 API.
+const (
+	s3NotFound       = "NotFound"
+	s3NoSuchTagSet   = "NoSuchTagSet"
+	s3BucketNotEmpty = "BucketNotEmpty"
+
+	s3NoSuchPublicAccessBlockConfiguration      = "NoSuchPublicAccessBlockConfiguration"
+	s3ServerSideEncryptionConfigurationNotFound = "ServerSideEncryptionConfigurationNotFoundError"
+)
+
+// ObjectKey returns the key of the object holding the bootstrap data of a machine of a cluster with a role,
+// either control-plane or node. Keys are prefixed with the namespace and name of the cluster, so that
+// clusters can share a bucket.
+func ObjectKey(namespace, clusterName, role, name string) string {
+	return path.Join(namespace, clusterName, role, name)
+}
+
+// ReconcileBucket ensures the cluster's bucket exists, encrypts objects at rest and blocks public access.
+// Buckets created for the cluster are tagged as owned by it, while the tags of an existing bucket are kept.
+// The configuration of an existing bucket is only changed when it drifted.
+func (s *Service) ReconcileBucket() error {
+	bucket := s.scope.Bucket()
+	if bucket == nil {
+		return nil
+	}
+
+	created, err := s.createBucketIfNotExists(bucket.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ensurePublicAccessBlock(bucket.Name, created); err != nil {
+		return err
+	}
+
+	if err := s.ensureEncryption(bucket.Name, created); err != nil {
+		return err
+	}
+
+	return s.ensureTags(bucket.Name, created)
+}
+
+// ensurePublicAccessBlock blocks public access to the bucket, unless it is already blocked.
+func (s *Service) ensurePublicAccessBlock(name string, created bool) error {
+	desired := &s3.PublicAccessBlockConfiguration{
+		BlockPublicAcls:       aws.Bool(true),
+		BlockPublicPolicy:     aws.Bool(true),
+		IgnorePublicAcls:      aws.Bool(true),
+		RestrictPublicBuckets: aws.Bool(true),
+	}
+
+	if !created {
+		out, err := s.S3Client.GetPublicAccessBlock(&s3.GetPublicAccessBlockInput{Bucket: aws.String(name)})
+		if code, _ := awserrors.Code(err); err != nil && code != s3NoSuchPublicAccessBlockConfiguration {
+			return errors.Wrapf(err, "failed to get public access block of bucket %q", name)
+		}
+		if err == nil && reflect.DeepEqual(out.PublicAccessBlockConfiguration, desired) {
+			return nil
+		}
+	}
+
+	if _, err := s.S3Client.PutPublicAccessBlock(&s3.PutPublicAccessBlockInput{
+		Bucket:                         aws.String(name),
+		PublicAccessBlockConfiguration: desired,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to block public access to bucket %q", name)
+	}
+	return nil
+}
+
+// ensureEncryption encrypts the objects of the bucket at rest with S3 managed keys, unless the bucket
+// already has a default encryption.
+func (s *Service) ensureEncryption(name string, created bool) error {
+	if !created {
+		out, err := s.S3Client.GetBucketEncryption(&s3.GetBucketEncryptionInput{Bucket: aws.String(name)})
+		if code, _ := awserrors.Code(err); err != nil && code != s3ServerSideEncryptionConfigurationNotFound {
+			return errors.Wrapf(err, "failed to get encryption of bucket %q", name)
+		}
+		if err == nil && out.ServerSideEncryptionConfiguration != nil && len(out.ServerSideEncryptionConfiguration.Rules) > 0 {
+			return nil
+		}
+	}
+
+	if _, err := s.S3Client.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+		Bucket: aws.String(name),
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{
+				{
+					ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+						SSEAlgorithm: aws.String(s3.ServerSideEncryptionAes256),
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to enable encryption of bucket %q", name)
+	}
+	return nil
+}
+
+// ensureTags tags a bucket created for the cluster, and adds the tags missing from a bucket owned by the cluster.
+func (s *Service) ensureTags(name string, created bool) error {
+	desired := s.tags(name)
+
+	if !created {
+		out, err := s.S3Client.GetBucketTagging(&s3.GetBucketTaggingInput{Bucket: aws.String(name)})
+		if code, _ := awserrors.Code(err); err != nil && code != s3NoSuchTagSet {
+			return errors.Wrapf(err, "failed to get tags of bucket %q", name)
+		}
+		var current infrav1.Tags
+		if err == nil {
+			current = converters.S3TagsToMap(out.TagSet)
+		}
+		if current[infrav1.ClusterTagKey(s.scope.Name())] != string(infrav1.ResourceLifecycleOwned) {
+			// The tags of existing buckets are kept.
+			return nil
+		}
+		if len(desired.Difference(current)) == 0 {
+			return nil
+		}
+		desired = current.DeepCopy()
+		desired.Merge(s.tags(name))
+	}
+
+	if _, err := s.S3Client.PutBucketTagging(&s3.PutBucketTaggingInput{
+		Bucket: aws.String(name),
+		Tagging: &s3.Tagging{
+			TagSet: converters.MapToS3Tags(desired),
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "failed to tag bucket %q", name)
+	}
+	return nil
+}
+
+// createBucketIfNotExists creates the bucket unless it exists already, and returns whether it was created.
+func (s *Service) createBucketIfNotExists(name string) (bool, error) {
+	// CreateBucket succeeds for a bucket which is already owned in us-east-1, so the bucket is looked up first.
+	_, err := s.S3Client.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(name)})
+	if err == nil {
+		return false, nil
+	}
+	if code, _ := awserrors.Code(err); code != s3NotFound && code != s3.ErrCodeNoSuchBucket {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateBucket", "Failed to look up bucket %q: %v", name, err)
+		return false, errors.Wrapf(err, "failed to look up bucket %q", name)
+	}
+
+	input := &s3.CreateBucketInput{Bucket: aws.String(name)}
+	// Buckets are created in us-east-1 unless another region is set as their location.
+	if s.scope.Region() != endpoints.UsEast1RegionID {
+		input.CreateBucketConfiguration = &s3.CreateBucketConfiguration{
+			LocationConstraint: aws.String(s.scope.Region()),
+		}
+	}
+
+	_, err = s.S3Client.CreateBucket(input)
+	if err == nil {
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateBucket", "Created bucket %q", name)
+		s.scope.V(2).Info("Created bucket", "bucket", name)
+		return true, nil
+	}
+
+	code, _ := awserrors.Code(err)
+	switch code {
+	case s3.ErrCodeBucketAlreadyOwnedByYou:
+		return false, nil
+	case s3.ErrCodeBucketAlreadyExists:
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateBucket", "Bucket %q is owned by another AWS account", name)
+		return false, errors.Wrapf(err, "bucket %q is owned by another AWS account", name)
+	default:
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateBucket", "Failed to create bucket %q: %v", name, err)
+		return false, errors.Wrapf(err, "failed to create bucket %q", name)
+	}
+}
+
+// DeleteBucket deletes the objects of the cluster from its bucket, and then the bucket itself if it is owned
+// by the cluster. Buckets which existed before the cluster, or still hold the objects of other clusters, are kept.
+func (s *Service) DeleteBucket() error {
+	bucket := s.scope.Bucket()
+	if bucket == nil {
+		return nil
+	}
+
+	for _, prefix := range s.objectPrefixes() {
+		err := s.S3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: aws.String(bucket.Name), Prefix: aws.String(prefix)},
+			func(out *s3.ListObjectsV2Output, _ bool) bool {
+				for _, object := range out.Contents {
+					if err := s.DeleteObject(aws.StringValue(object.Key)); err != nil {
+						s.scope.Error(err, "failed to delete object", "bucket", bucket.Name, "key", aws.StringValue(object.Key))
+					}
+				}
+				return true
+			})
+		if code, _ := awserrors.Code(err); code == s3.ErrCodeNoSuchBucket {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to list objects of bucket %q", bucket.Name)
+		}
+	}
+
+	owned, err := s.bucketIsOwned(bucket.Name)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		s.scope.V(2).Info("Keeping bucket which isn't owned by the cluster", "bucket", bucket.Name)
+		return nil
+	}
+
+	if _, err := s.S3Client.DeleteBucket(&s3.DeleteBucketInput{Bucket: aws.String(bucket.Name)}); err != nil {
+		switch code, _ := awserrors.Code(err); code {
+		case s3.ErrCodeNoSuchBucket:
+			return nil
+		case s3BucketNotEmpty:
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteBucket", "Keeping bucket %q, which holds the objects of other clusters", bucket.Name)
+			return nil
+		}
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteBucket", "Failed to delete bucket %q: %v", bucket.Name, err)
+		return errors.Wrapf(err, "failed to delete bucket %q", bucket.Name)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteBucket", "Deleted bucket %q", bucket.Name)
+	s.scope.V(2).Info("Deleted bucket", "bucket", bucket.Name)
+	return nil
+}
+
+// objectPrefixes returns the prefixes of the keys of the objects of the cluster.
+func (s *Service) objectPrefixes() []string {
+	prefix := ObjectKey(s.scope.Namespace(), s.scope.Name(), "", "") + "/"
+	return []string{prefix, path.Join(secretKeyPrefix, prefix) + "/"}
+}
+
+// bucketIsOwned returns whether the bucket is tagged as owned by the cluster.
+func (s *Service) bucketIsOwned(name string) (bool, error) {
+	out, err := s.S3Client.GetBucketTagging(&s3.GetBucketTaggingInput{Bucket: aws.String(name)})
+	if code, _ := awserrors.Code(err); code == s3NoSuchTagSet {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get tags of bucket %q", name)
+	}
+
+	for _, tag := range out.TagSet {
+		if aws.StringValue(tag.Key) == infrav1.ClusterTagKey(s.scope.Name()) && aws.StringValue(tag.Value) == string(infrav1.ResourceLifecycleOwned) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateObject stores data at key in the cluster's bucket, encrypted at rest, and returns
+// its S3 URL.
+func (s *Service) CreateObject(key string, data []byte) (string, error) {
+	return s.createObject(key, data, "")
+}
+
+// createObject stores data at key in the cluster's bucket, encrypted at rest with the KMS key
+// kmsKeyID, or with S3 managed keys when it is empty.
+func (s *Service) createObject(key string, data []byte, kmsKeyID string) (string, error) {
+	bucket := s.scope.Bucket()
+	if bucket == nil {
+		return "", errors.New("cluster has no S3 bucket")
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket:               aws.String(bucket.Name),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(data),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+	}
+	if kmsKeyID != "" {
+		input.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+		input.SSEKMSKeyId = aws.String(kmsKeyID)
+	}
+
+	if _, err := s.S3Client.PutObject(input); err != nil {
+		return "", errors.Wrapf(err, "failed to put object %q in bucket %q", key, bucket.Name)
+	}
+
+	return fmt.Sprintf("s3://%s/%s", bucket.Name, key), nil
+}
+
+// DeleteObject deletes the object at key from the cluster's bucket, ignoring if it is absent.
+func (s *Service) DeleteObject(key string) error {
+	bucket := s.scope.Bucket()
+	if bucket == nil {
+		return nil
+	}
+
+	_, err := s.S3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket.Name),
+		Key:    aws.String(key),
+	})
+	if code, _ := awserrors.Code(err); code == s3.ErrCodeNoSuchBucket || code == s3.ErrCodeNoSuchKey {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete object %q from bucket %q", key, bucket.Name)
+	}
+
+	return nil
+}
+
+func (s *Service) tags(name string) infrav1.Tags {
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	})
+}
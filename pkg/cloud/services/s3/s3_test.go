@@ -0,0 +1,371 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/s3/mock_s3iface"
+)
+
+const testBucket = "cluster-api-provider-aws-test-cluster"
+
+func TestReconcileBucket(t *testing.T) {
+	tests := []struct {
+		name        string
+		bucket      *infrav1.S3Bucket
+		region      string
+		expect      func(m *mock_s3iface.MockS3APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:   "no bucket is a no-op",
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {},
+		},
+		{
+			name:   "bucket is created in the cluster's region and secured",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			region: "eu-west-1",
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.HeadBucket(gomock.Eq(&s3.HeadBucketInput{Bucket: aws.String(testBucket)})).Return(nil, awserr.New("NotFound", "not found", nil))
+				m.CreateBucket(gomock.Eq(&s3.CreateBucketInput{
+					Bucket: aws.String(testBucket),
+					CreateBucketConfiguration: &s3.CreateBucketConfiguration{
+						LocationConstraint: aws.String("eu-west-1"),
+					},
+				})).Return(&s3.CreateBucketOutput{}, nil)
+				m.PutPublicAccessBlock(gomock.Any()).Return(&s3.PutPublicAccessBlockOutput{}, nil)
+				m.PutBucketEncryption(gomock.Any()).Return(&s3.PutBucketEncryptionOutput{}, nil)
+				m.PutBucketTagging(gomock.Any()).
+					DoAndReturn(func(input *s3.PutBucketTaggingInput) (*s3.PutBucketTaggingOutput, error) {
+						if aws.StringValue(input.Bucket) != testBucket {
+							t.Fatalf("expected bucket %q, got %q", testBucket, aws.StringValue(input.Bucket))
+						}
+						tags := map[string]string{}
+						for _, tag := range input.Tagging.TagSet {
+							tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+						}
+						expected := map[string]string{
+							"Name": testBucket,
+							"sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster": "owned",
+							"sigs.k8s.io/cluster-api-provider-aws/role":                 "common",
+						}
+						if !reflect.DeepEqual(tags, expected) {
+							t.Fatalf("expected tags %v, got %v", expected, tags)
+						}
+						return &s3.PutBucketTaggingOutput{}, nil
+					})
+			},
+		},
+		{
+			name:   "bucket in us-east-1 has no location constraint",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			region: "us-east-1",
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.HeadBucket(gomock.Eq(&s3.HeadBucketInput{Bucket: aws.String(testBucket)})).Return(nil, awserr.New("NotFound", "not found", nil))
+				m.CreateBucket(gomock.Eq(&s3.CreateBucketInput{Bucket: aws.String(testBucket)})).Return(&s3.CreateBucketOutput{}, nil)
+				m.PutPublicAccessBlock(gomock.Any()).Return(&s3.PutPublicAccessBlockOutput{}, nil)
+				m.PutBucketEncryption(gomock.Any()).Return(&s3.PutBucketEncryptionOutput{}, nil)
+				m.PutBucketTagging(gomock.Any()).Return(&s3.PutBucketTaggingOutput{}, nil)
+			},
+		},
+		{
+			name:   "existing bucket is secured without changing its tags",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			region: "us-east-1",
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.HeadBucket(gomock.Any()).Return(&s3.HeadBucketOutput{}, nil)
+				m.GetPublicAccessBlock(gomock.Any()).Return(nil, awserr.New("NoSuchPublicAccessBlockConfiguration", "not found", nil))
+				m.PutPublicAccessBlock(gomock.Any()).Return(&s3.PutPublicAccessBlockOutput{}, nil)
+				m.GetBucketEncryption(gomock.Any()).Return(nil, awserr.New("ServerSideEncryptionConfigurationNotFoundError", "not found", nil))
+				m.PutBucketEncryption(gomock.Any()).Return(&s3.PutBucketEncryptionOutput{}, nil)
+				m.GetBucketTagging(gomock.Any()).Return(nil, awserr.New("NoSuchTagSet", "not found", nil))
+			},
+		},
+		{
+			name:   "bucket owned by the cluster is left as is",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			region: "eu-west-1",
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.HeadBucket(gomock.Any()).Return(&s3.HeadBucketOutput{}, nil)
+				expectSecuredBucket(m)
+				m.GetBucketTagging(gomock.Any()).Return(&s3.GetBucketTaggingOutput{TagSet: []*s3.Tag{
+					{Key: aws.String("Name"), Value: aws.String(testBucket)},
+					{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+					{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("common")},
+				}}, nil)
+			},
+		},
+		{
+			name:   "missing tags are added to a bucket owned by the cluster",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			region: "eu-west-1",
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.HeadBucket(gomock.Any()).Return(&s3.HeadBucketOutput{}, nil)
+				expectSecuredBucket(m)
+				m.GetBucketTagging(gomock.Any()).Return(&s3.GetBucketTaggingOutput{TagSet: []*s3.Tag{
+					{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+					{Key: aws.String("team"), Value: aws.String("platform")},
+				}}, nil)
+				m.PutBucketTagging(gomock.Any()).
+					DoAndReturn(func(input *s3.PutBucketTaggingInput) (*s3.PutBucketTaggingOutput, error) {
+						if len(input.Tagging.TagSet) != 4 {
+							t.Fatalf("expected the existing and missing tags, got %v", input.Tagging.TagSet)
+						}
+						return &s3.PutBucketTaggingOutput{}, nil
+					})
+			},
+		},
+		{
+			name:   "bucket owned by another account fails",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			region: "eu-west-1",
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.HeadBucket(gomock.Any()).Return(nil, awserr.New("Forbidden", "forbidden", nil))
+			},
+			expectError: true,
+		},
+		{
+			name:   "encryption failure fails",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			region: "eu-west-1",
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.HeadBucket(gomock.Eq(&s3.HeadBucketInput{Bucket: aws.String(testBucket)})).Return(nil, awserr.New("NotFound", "not found", nil))
+				m.CreateBucket(gomock.Any()).Return(&s3.CreateBucketOutput{}, nil)
+				m.PutPublicAccessBlock(gomock.Any()).Return(&s3.PutPublicAccessBlockOutput{}, nil)
+				m.PutBucketEncryption(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+
+			s := newTestService(g, tc.bucket, tc.region)
+			s.S3Client = s3Mock
+			tc.expect(s3Mock.EXPECT())
+
+			err := s.ReconcileBucket()
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
+func TestDeleteBucket(t *testing.T) {
+	tests := []struct {
+		name        string
+		bucket      *infrav1.S3Bucket
+		expect      func(m *mock_s3iface.MockS3APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:   "no bucket is a no-op",
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {},
+		},
+		{
+			name:   "objects of the cluster are deleted before the bucket",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				expectListObjects(m, "default/test-cluster/", "default/test-cluster/node/machine-1")
+				expectListObjects(m, "secrets/default/test-cluster/", "secrets/default/test-cluster/node/machine-2/uuid")
+				m.DeleteObject(gomock.Eq(&s3.DeleteObjectInput{
+					Bucket: aws.String(testBucket),
+					Key:    aws.String("default/test-cluster/node/machine-1"),
+				})).Return(&s3.DeleteObjectOutput{}, nil)
+				m.DeleteObject(gomock.Eq(&s3.DeleteObjectInput{
+					Bucket: aws.String(testBucket),
+					Key:    aws.String("secrets/default/test-cluster/node/machine-2/uuid"),
+				})).Return(&s3.DeleteObjectOutput{}, nil)
+				expectBucketTags(m, "owned")
+				m.DeleteBucket(gomock.Eq(&s3.DeleteBucketInput{Bucket: aws.String(testBucket)})).Return(&s3.DeleteBucketOutput{}, nil)
+			},
+		},
+		{
+			name:   "bucket which isn't owned by the cluster is kept",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				expectListObjects(m, "default/test-cluster/")
+				expectListObjects(m, "secrets/default/test-cluster/")
+				expectBucketTags(m, "shared")
+			},
+		},
+		{
+			name:   "bucket holding the objects of other clusters is kept",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				expectListObjects(m, "default/test-cluster/")
+				expectListObjects(m, "secrets/default/test-cluster/")
+				expectBucketTags(m, "owned")
+				m.DeleteBucket(gomock.Any()).Return(nil, awserr.New("BucketNotEmpty", "not empty", nil))
+			},
+		},
+		{
+			name:   "missing bucket is ignored",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.ListObjectsV2Pages(gomock.Any(), gomock.Any()).Return(awserr.New(s3.ErrCodeNoSuchBucket, "not found", nil))
+			},
+		},
+		{
+			name:   "bucket deletion fails",
+			bucket: &infrav1.S3Bucket{Name: testBucket},
+			expect: func(m *mock_s3iface.MockS3APIMockRecorder) {
+				m.ListObjectsV2Pages(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				expectBucketTags(m, "owned")
+				m.DeleteBucket(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+
+			s := newTestService(g, tc.bucket, "eu-west-1")
+			s.S3Client = s3Mock
+			tc.expect(s3Mock.EXPECT())
+
+			err := s.DeleteBucket()
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
+func TestCreateObject(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+
+	s := newTestService(g, &infrav1.S3Bucket{Name: testBucket}, "eu-west-1")
+	s.S3Client = s3Mock
+	s3Mock.EXPECT().PutObject(gomock.Any()).
+		DoAndReturn(func(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+			g.Expect(aws.StringValue(input.Bucket)).To(Equal(testBucket))
+			g.Expect(aws.StringValue(input.Key)).To(Equal("default/test-cluster/control-plane/machine-1"))
+			g.Expect(aws.StringValue(input.ServerSideEncryption)).To(Equal(s3.ServerSideEncryptionAes256))
+			return &s3.PutObjectOutput{}, nil
+		})
+
+	source, err := s.CreateObject(ObjectKey("default", "test-cluster", "control-plane", "machine-1"), []byte("{}"))
+	g.Expect(err).To(BeNil())
+	g.Expect(source).To(Equal("s3://" + testBucket + "/default/test-cluster/control-plane/machine-1"))
+}
+
+func TestDeleteObject(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+
+	s := newTestService(g, &infrav1.S3Bucket{Name: testBucket}, "eu-west-1")
+	s.S3Client = s3Mock
+	s3Mock.EXPECT().DeleteObject(gomock.Any()).Return(nil, awserr.New(s3.ErrCodeNoSuchKey, "not found", nil))
+	s3Mock.EXPECT().DeleteObject(gomock.Any()).Return(nil, errors.New("some error"))
+
+	g.Expect(s.DeleteObject("node/machine-1")).To(Succeed())
+	g.Expect(s.DeleteObject("node/machine-1")).NotTo(Succeed())
+}
+
+func expectSecuredBucket(m *mock_s3iface.MockS3APIMockRecorder) {
+	m.GetPublicAccessBlock(gomock.Any()).Return(&s3.GetPublicAccessBlockOutput{
+		PublicAccessBlockConfiguration: &s3.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.Bool(true),
+			BlockPublicPolicy:     aws.Bool(true),
+			IgnorePublicAcls:      aws.Bool(true),
+			RestrictPublicBuckets: aws.Bool(true),
+		},
+	}, nil)
+	m.GetBucketEncryption(gomock.Any()).Return(&s3.GetBucketEncryptionOutput{
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{{}},
+		},
+	}, nil)
+}
+
+func expectListObjects(m *mock_s3iface.MockS3APIMockRecorder, prefix string, keys ...string) {
+	m.ListObjectsV2Pages(gomock.Eq(&s3.ListObjectsV2Input{Bucket: aws.String(testBucket), Prefix: aws.String(prefix)}), gomock.Any()).
+		DoAndReturn(func(_ *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+			objects := make([]*s3.Object, 0, len(keys))
+			for _, key := range keys {
+				objects = append(objects, &s3.Object{Key: aws.String(key)})
+			}
+			fn(&s3.ListObjectsV2Output{Contents: objects}, true)
+			return nil
+		})
+}
+
+func expectBucketTags(m *mock_s3iface.MockS3APIMockRecorder, lifecycle string) {
+	m.GetBucketTagging(gomock.Eq(&s3.GetBucketTaggingInput{Bucket: aws.String(testBucket)})).
+		Return(&s3.GetBucketTaggingOutput{TagSet: []*s3.Tag{
+			{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String(lifecycle)},
+		}}, nil)
+}
+
+func newTestService(g *WithT, bucket *infrav1.S3Bucket, region string) *Service {
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewFakeClientWithScheme(scheme),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{Region: region, S3Bucket: bucket},
+		},
+	})
+	g.Expect(err).To(BeNil())
+
+	return NewService(clusterScope)
+}
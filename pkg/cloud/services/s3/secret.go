@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+)
+
+// secretKeyPrefix is the prefix of the keys of the objects holding the bootstrap data of machines
+// using the s3 secure secrets backend.
+const secretKeyPrefix = "secrets"
+
+// Create stores data in the cluster's bucket for a given machine or machine pool. S3 objects don't need to be chunked,
+// so the key of the object is returned as the secret prefix, along with a single chunk.
+func (s *Service) Create(m scope.SecretScope, data []byte) (string, int32, error) {
+	if s.scope.Bucket() == nil {
+		return "", 0, errors.New("the s3 secure secrets backend requires the cluster to have an S3 bucket")
+	}
+
+	key := m.GetSecretPrefix()
+	if key == "" {
+		// The object is named uniquely so that the bootstrap data of a machine pool can be replaced
+		// while instances started from the previous launch template version may still fetch it.
+		key = path.Join(secretKeyPrefix, ObjectKey(s.scope.Namespace(), s.scope.Name(), m.Role(), m.Name()), string(uuid.NewUUID()))
+	}
+
+	if _, err := s.createObject(key, data, m.SecretsKMSKeyID()); err != nil {
+		return key, 0, err
+	}
+
+	return key, 1, nil
+}
+
+// Delete deletes the object holding the bootstrap data of a machine or machine pool from the cluster's bucket.
+func (s *Service) Delete(m scope.SecretScope) error {
+	return s.DeleteObject(m.GetSecretPrefix())
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+// nolint
+const secretFetchScript = `#cloud-boothook
+#!/bin/bash
+
+# Copyright 2020 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+umask 006
+
+REGION="{{.Region}}"
+if [ "{{.Endpoint}}" != "" ]; then
+  ENDPOINT="--endpoint-url {{.Endpoint}}"
+fi
+SOURCE="{{.SecretPrefix}}"
+FILE="/etc/secret-userdata.txt"
+
+# Log an error and exit.
+# Args:
+#   $1 Message to log with the error
+#   $2 The error code to return
+log::error_exit() {
+  local message="${1}"
+  local code="${2}"
+
+  log::error "${message}"
+  log::error "aws.cluster.x-k8s.io encrypted cloud-init script $0 exiting with status ${code}"
+  exit "${code}"
+}
+
+log::success_exit() {
+  log::info "aws.cluster.x-k8s.io encrypted cloud-init script $0 finished"
+  exit 0
+}
+
+# Log an error but keep going.
+log::error() {
+  local message="${1}"
+  timestamp=$(date --iso-8601=seconds)
+  echo "!!! [${timestamp}] ${1}" >&2
+  shift
+  for message; do
+    echo "    ${message}" >&2
+  done
+}
+
+# Print a status line.  Formatted to show up in a stream of output.
+log::info() {
+  timestamp=$(date --iso-8601=seconds)
+  echo "+++ [${timestamp}] ${1}"
+  shift
+  for message; do
+    echo "    ${message}"
+  done
+}
+
+check_aws_command() {
+  local command="${1}"
+  local code="${2}"
+  local out="${3}"
+  local sanitised="${out//[$'\t\r\n']/}"
+  case ${code} in
+  "0")
+    log::info "AWS CLI reported successful execution for ${command}"
+    ;;
+  "2")
+    log::error "AWS CLI reported that it could not parse ${command}"
+    log::error "${sanitised}"
+    ;;
+  "130")
+    log::error "AWS CLI reported SIGINT signal during ${command}"
+    log::error "${sanitised}"
+    ;;
+  "255")
+    log::error "AWS CLI reported service error for ${command}"
+    log::error "${sanitised}"
+    ;;
+  *)
+    log::error "AWS CLI reported unknown error ${code} for ${command}"
+    log::error "${sanitised}"
+    ;;
+  esac
+}
+get_secret_value() {
+  log::info "getting userdata from AWS S3"
+
+  local out
+  set +o errexit
+  set +o nounset
+  set +o pipefail
+  out=$(
+    aws s3 ${ENDPOINT} --region ${REGION} cp "${SOURCE}" "${FILE}.gz" 2>&1
+  )
+  local get_return=$?
+  check_aws_command "S3::GetObject" "${get_return}" "${out}"
+  set -o errexit
+  set -o nounset
+  set -o pipefail
+  if [ ${get_return} -ne 0 ]; then
+    rm -f "${FILE}.gz"
+    log::error_exit "could not get secret value" 1
+  fi
+}
+
+log::info "aws.cluster.x-k8s.io encrypted cloud-init script $0 started"
+log::info "secret source: ${SOURCE}"
+
+if test -f "${FILE}"; then
+  log::info "encrypted userdata already written to disk"
+  log::success_exit
+fi
+
+get_secret_value
+
+log::info "decompressing userdata to ${FILE}"
+gunzip "${FILE}.gz"
+GUNZIP_RETURN=$?
+if [ ${GUNZIP_RETURN} -ne 0 ]; then
+  log::error_exit "could not unzip data" 4
+fi
+
+log::info "restarting cloud-init"
+systemctl restart cloud-init
+log::success_exit
+`
+
+// windowsSecretFetchScript fetches the userdata on Windows instances using the AWS Tools for PowerShell
+// included in the Windows AMIs, and runs it.
+// nolint
+const windowsSecretFetchScript = `<powershell>
+# Copyright 2020 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+$ErrorActionPreference = "Stop"
+
+$Region = "{{.Region}}"
+$Endpoint = "{{.Endpoint}}"
+$Source = [System.Uri]"{{.SecretPrefix}}"
+$Dir = Join-Path $env:ProgramData "aws.cluster.x-k8s.io"
+$File = Join-Path $Dir "secret-userdata.ps1"
+
+$AWSParams = @{ Region = $Region }
+if ($Endpoint -ne "") {
+  $AWSParams.EndpointUrl = $Endpoint
+}
+
+# Print a status line.
+function Write-Info([string]$Message) {
+  Write-Output "+++ [$(Get-Date -Format o)] $Message"
+}
+
+# Create a directory only readable by administrators.
+function New-SecureDirectory {
+  New-Item -ItemType Directory -Force -Path $Dir | Out-Null
+  icacls $Dir /inheritance:r /grant:r "Administrators:(OI)(CI)F" "SYSTEM:(OI)(CI)F" | Out-Null
+}
+
+# The object holds the gzipped userdata.
+function Get-SecretValue {
+  Write-Info "getting userdata from AWS S3"
+  Read-S3Object @AWSParams -BucketName $Source.Host -Key $Source.AbsolutePath.TrimStart("/") -File "$File.gz" | Out-Null
+}
+
+# Decompress the userdata and strip the tags EC2Launch runs it in.
+function Write-UserData {
+  $Compressed = [System.IO.File]::OpenRead("$File.gz")
+  $Gzip = New-Object System.IO.Compression.GZipStream($Compressed, [System.IO.Compression.CompressionMode]::Decompress)
+  $Reader = New-Object System.IO.StreamReader($Gzip)
+  $UserData = $Reader.ReadToEnd()
+  $Reader.Close()
+  Remove-Item -Force "$File.gz"
+  $UserData = $UserData -replace '^\s*<powershell>', '' -replace '</powershell>\s*$', ''
+
+  Set-Content -Path $File -Value $UserData
+}
+
+Write-Info "aws.cluster.x-k8s.io encrypted userdata script started"
+Write-Info "secret source: $Source"
+
+if (Test-Path $File) {
+  Write-Info "encrypted userdata already written to disk"
+  Write-Info "aws.cluster.x-k8s.io encrypted userdata script finished"
+  exit 0
+}
+
+New-SecureDirectory
+Get-SecretValue
+
+Write-Info "decompressing userdata to $File"
+Write-UserData
+
+Write-Info "running userdata"
+& $File
+Write-Info "aws.cluster.x-k8s.io encrypted userdata script finished"
+</powershell>
+`
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"bytes"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/s3/mock_s3iface"
+)
+
+func TestCreateAndDeleteSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+
+	s := newTestService(g, &infrav1.S3Bucket{Name: testBucket}, "eu-west-1")
+	s.S3Client = s3Mock
+	machineScope := newTestMachineScope(g, s)
+
+	s3Mock.EXPECT().PutObject(gomock.Any()).
+		DoAndReturn(func(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+			g.Expect(aws.StringValue(input.Key)).To(HavePrefix("secrets/default/test-cluster/node/machine-1/"))
+			return &s3.PutObjectOutput{}, nil
+		}).Times(2)
+	prefix, chunks, err := s.Create(machineScope, []byte("data"))
+	g.Expect(err).To(BeNil())
+	g.Expect(prefix).To(HavePrefix("secrets/default/test-cluster/node/machine-1/"))
+	g.Expect(chunks).To(Equal(int32(1)))
+
+	// Objects created anew don't replace the previous ones, so these can be deleted afterwards.
+	otherPrefix, _, err := s.Create(machineScope, []byte("data"))
+	g.Expect(err).To(BeNil())
+	g.Expect(otherPrefix).NotTo(Equal(prefix))
+
+	machineScope.SetSecretPrefix(prefix)
+	s3Mock.EXPECT().DeleteObject(gomock.Eq(&s3.DeleteObjectInput{
+		Bucket: aws.String(testBucket),
+		Key:    aws.String(prefix),
+	})).Return(&s3.DeleteObjectOutput{}, nil)
+	g.Expect(s.Delete(machineScope)).To(Succeed())
+}
+
+func TestCreateSecretWithKMSKey(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s3Mock := mock_s3iface.NewMockS3API(mockCtrl)
+
+	s := newTestService(g, &infrav1.S3Bucket{Name: testBucket}, "eu-west-1")
+	s.S3Client = s3Mock
+	machineScope := newTestMachineScope(g, s)
+	machineScope.AWSMachine.Spec.CloudInit.KMSKeyID = "alias/bootstrap-secrets"
+
+	s3Mock.EXPECT().PutObject(gomock.Any()).
+		DoAndReturn(func(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+			g.Expect(aws.StringValue(input.ServerSideEncryption)).To(Equal(s3.ServerSideEncryptionAwsKms))
+			g.Expect(aws.StringValue(input.SSEKMSKeyId)).To(Equal("alias/bootstrap-secrets"))
+			return &s3.PutObjectOutput{}, nil
+		})
+	_, _, err := s.Create(machineScope, []byte("data"))
+	g.Expect(err).To(BeNil())
+}
+
+func TestCreateSecretWithoutBucket(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newTestService(g, nil, "eu-west-1")
+	_, _, err := s.Create(newTestMachineScope(g, s), []byte("data"))
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestUserData(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newTestService(g, &infrav1.S3Bucket{Name: testBucket}, "eu-west-1")
+	endpoints := []scope.ServiceEndpoint{
+		{
+			URL:           "https://localhost",
+			SigningRegion: "localhost",
+			ServiceID:     "s3",
+		},
+	}
+	doc, err := s.UserData("secrets/node/machine-1", 1, false, "eu-west-1", endpoints)
+	g.Expect(err).To(BeNil())
+
+	_, err = mail.ReadMessage(bytes.NewBuffer(doc))
+	if err != nil {
+		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
+	}
+	g.Expect(string(doc)).To(ContainSubstring(`SOURCE="s3://` + testBucket + `/secrets/node/machine-1"`))
+	g.Expect(string(doc)).To(ContainSubstring(`ENDPOINT="--endpoint-url https://localhost"`))
+}
+
+func TestWindowsUserData(t *testing.T) {
+	g := NewWithT(t)
+
+	s := newTestService(g, &infrav1.S3Bucket{Name: testBucket}, "eu-west-1")
+	doc, err := s.WindowsUserData("secrets/node/machine-1", 1, false, "eu-west-1", nil)
+	g.Expect(err).To(BeNil())
+
+	script := string(doc)
+	if !strings.HasPrefix(script, "<powershell>") || !strings.HasSuffix(strings.TrimSpace(script), "</powershell>") {
+		t.Fatalf("Script is not wrapped in powershell tags:\n%s", script)
+	}
+	g.Expect(script).To(ContainSubstring(`$Source = [System.Uri]"s3://` + testBucket + `/secrets/node/machine-1"`))
+	g.Expect(script).To(ContainSubstring(`$Region = "eu-west-1"`))
+}
+
+func newTestMachineScope(g *WithT, s *Service) *scope.MachineScope {
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client: fake.NewFakeClientWithScheme(scheme),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-1"},
+		},
+		AWSMachine: &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-1"},
+		},
+		InfraCluster: s.scope.(*scope.ClusterScope),
+	})
+	g.Expect(err).To(BeNil())
+
+	return machineScope
+}
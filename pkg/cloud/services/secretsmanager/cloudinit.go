@@ -28,14 +28,31 @@ const (
 // UserData creates a multi-part MIME document including a script boothook to
 // download userdata from AWS Secrets Manager and then restart cloud-init, and an include part
 // specifying the on disk location of the new userdata
-func (s *Service) UserData(secretPrefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) ([]byte, error) {
+func (s *Service) UserData(secretPrefix string, chunks int32, keepSecrets bool, region string, endpoints []scope.ServiceEndpoint) ([]byte, error) {
 	serviceEndpoint := ""
 	for _, v := range endpoints {
 		if v.ServiceID == serviceID {
 			serviceEndpoint = v.URL
 		}
 	}
-	userData, err := mime.GenerateInitDocument(secretPrefix, chunks, region, serviceEndpoint, secretFetchScript)
+	userData, err := mime.GenerateInitDocument(secretPrefix, chunks, keepSecrets, region, serviceEndpoint, secretFetchScript)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return userData, nil
+}
+
+// WindowsUserData creates a PowerShell script to download userdata from AWS Secrets Manager
+// and run it on Windows instances, which don't run cloud-init
+func (s *Service) WindowsUserData(secretPrefix string, chunks int32, keepSecrets bool, region string, endpoints []scope.ServiceEndpoint) ([]byte, error) {
+	serviceEndpoint := ""
+	for _, v := range endpoints {
+		if v.ServiceID == serviceID {
+			serviceEndpoint = v.URL
+		}
+	}
+	userData, err := mime.GenerateScript(secretPrefix, chunks, keepSecrets, region, serviceEndpoint, windowsSecretFetchScript)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -49,7 +49,7 @@ var retryableErrors = []string{
 
 // Create stores data in AWS Secrets Manager for a given machine, chunking at 10kb per secret. The prefix of the secret
 // ARN and the number of chunks are returned.
-func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, error) {
+func (s *Service) Create(m scope.SecretScope, data []byte) (string, int32, error) {
 	// Build the tags to apply to the secret.
 	additionalTags := m.AdditionalTags()
 	additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())] = string(infrav1.ResourceLifecycleOwned)
@@ -71,7 +71,7 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 	var err error
 	bytes.Split(data, false, maxSecretSizeBytes, func(chunk []byte) {
 		name := fmt.Sprintf("%s-%d", prefix, chunks)
-		retryFunc := func() (bool, error) { return s.retryableCreateSecret(name, chunk, tags) }
+		retryFunc := func() (bool, error) { return s.retryableCreateSecret(name, chunk, tags, m.SecretsKMSKeyID()) }
 		// Default timeout is 5 mins, but if Secrets Manager has got to the state where the timeout is reached,
 		// makes sense to slow down machine creation until AWS weather improves.
 		if err = wait.WaitForWithRetryable(wait.NewBackoff(), retryFunc, retryableErrors...); err != nil {
@@ -84,12 +84,16 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 }
 
 // retryableCreateSecret is a function to be passed into a waiter. In a separate function for ease of reading
-func (s *Service) retryableCreateSecret(name string, chunk []byte, tags infrav1.Tags) (bool, error) {
-	_, err := s.SecretsManagerClient.CreateSecret(&secretsmanager.CreateSecretInput{
+func (s *Service) retryableCreateSecret(name string, chunk []byte, tags infrav1.Tags, kmsKeyID string) (bool, error) {
+	input := &secretsmanager.CreateSecretInput{
 		Name:         aws.String(name),
 		SecretBinary: chunk,
 		Tags:         converters.MapToSecretsManagerTags(tags),
-	})
+	}
+	if kmsKeyID != "" {
+		input.KmsKeyId = aws.String(kmsKeyID)
+	}
+	_, err := s.SecretsManagerClient.CreateSecret(input)
 	// If the secret already exists, delete it, return request to retry, as deletes are eventually consistent
 	if awserrors.IsResourceExists(err) {
 		return false, s.forceDeleteSecretEntry(name)
@@ -113,7 +117,7 @@ func (s *Service) forceDeleteSecretEntry(name string) error {
 }
 
 // Delete the secret belonging to a machine from AWS Secrets Manager
-func (s *Service) Delete(m *scope.MachineScope) error {
+func (s *Service) Delete(m scope.SecretScope) error {
 	var errs []error
 	for i := int32(0); i < m.GetSecretCount(); i++ {
 		if err := s.forceDeleteSecretEntry(fmt.Sprintf("%s-%d", m.GetSecretPrefix(), i)); err != nil {
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+)
+
+// chunkSuffixRe matches the index appended to the prefix of a set of secrets to name each of them.
+var chunkSuffixRe = regexp.MustCompile(`-[0-9]+$`)
+
+// ListOwnedSecrets returns the sets of secrets holding bootstrap data which are owned by the cluster.
+func (s *Service) ListOwnedSecrets() ([]*services.BootstrapSecret, error) {
+	clusterTag := infrav1.ClusterTagKey(s.scope.Name())
+	input := &secretsmanager.ListSecretsInput{
+		Filters: []*secretsmanager.Filter{
+			{
+				Key:    aws.String(secretsmanager.FilterNameStringTypeTagKey),
+				Values: aws.StringSlice([]string{clusterTag}),
+			},
+			{
+				Key:    aws.String(secretsmanager.FilterNameStringTypeName),
+				Values: aws.StringSlice([]string{entryPrefix + "/"}),
+			},
+		},
+	}
+
+	sets := map[string]*services.BootstrapSecret{}
+	var secrets []*services.BootstrapSecret
+	err := s.SecretsManagerClient.ListSecretsPages(input, func(out *secretsmanager.ListSecretsOutput, last bool) bool {
+		for _, entry := range out.SecretList {
+			name := aws.StringValue(entry.Name)
+			tags := map[string]string{}
+			for _, tag := range entry.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			// Filters match secrets whose tag keys and names start with the values given.
+			if tags[clusterTag] != string(infrav1.ResourceLifecycleOwned) || !strings.HasPrefix(name, entryPrefix+"/") {
+				continue
+			}
+
+			prefix := chunkSuffixRe.ReplaceAllString(name, "")
+			set, ok := sets[prefix]
+			if !ok {
+				set = &services.BootstrapSecret{Prefix: prefix, Owner: tags["Name"]}
+				sets[prefix] = set
+				secrets = append(secrets, set)
+			}
+			set.Names = append(set.Names, name)
+			if modified := aws.TimeValue(entry.LastChangedDate); modified.After(set.LastModified) {
+				set.LastModified = modified
+			}
+			if created := aws.TimeValue(entry.CreatedDate); created.After(set.LastModified) {
+				set.LastModified = created
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list secrets of cluster %q", s.scope.Name())
+	}
+
+	return secrets, nil
+}
+
+// DeleteOwnedSecret deletes a set of secrets returned by ListOwnedSecrets.
+func (s *Service) DeleteOwnedSecret(secret *services.BootstrapSecret) error {
+	var errs []error
+	for _, name := range secret.Names {
+		if err := s.forceDeleteSecretEntry(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/secretsmanager/mock_secretsmanageriface"
+)
+
+func TestListOwnedSecrets(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	secretsManagerMock := mock_secretsmanageriface.NewMockSecretsManagerAPI(mockCtrl)
+
+	s := newTestService(g)
+	s.SecretsManagerClient = secretsManagerMock
+
+	created := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	owned := []*secretsmanager.Tag{
+		{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+		{Key: aws.String("Name"), Value: aws.String("machine-1")},
+	}
+	secretsManagerMock.EXPECT().ListSecretsPages(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(input *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) error {
+			g.Expect(aws.StringValue(input.Filters[0].Values[0])).To(Equal("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"))
+			fn(&secretsmanager.ListSecretsOutput{SecretList: []*secretsmanager.SecretListEntry{
+				{Name: aws.String("aws.cluster.x-k8s.io/a-0"), Tags: owned, CreatedDate: aws.Time(created)},
+				{Name: aws.String("aws.cluster.x-k8s.io/a-1"), Tags: owned, CreatedDate: aws.Time(created.Add(time.Second))},
+			}}, false)
+			fn(&secretsmanager.ListSecretsOutput{SecretList: []*secretsmanager.SecretListEntry{
+				// Tag keys are matched by prefix.
+				{Name: aws.String("aws.cluster.x-k8s.io/b-0"), Tags: []*secretsmanager.Tag{
+					{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster-2"), Value: aws.String("owned")},
+				}},
+				{Name: aws.String("aws.cluster.x-k8s.io/c-0"), Tags: []*secretsmanager.Tag{
+					{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("shared")},
+				}},
+			}}, true)
+			return nil
+		})
+
+	secrets, err := s.ListOwnedSecrets()
+	g.Expect(err).To(BeNil())
+	g.Expect(secrets).To(Equal([]*services.BootstrapSecret{
+		{
+			Prefix:       "aws.cluster.x-k8s.io/a",
+			Names:        []string{"aws.cluster.x-k8s.io/a-0", "aws.cluster.x-k8s.io/a-1"},
+			Owner:        "machine-1",
+			LastModified: created.Add(time.Second),
+		},
+	}))
+}
+
+func TestDeleteOwnedSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	secretsManagerMock := mock_secretsmanageriface.NewMockSecretsManagerAPI(mockCtrl)
+
+	s := newTestService(g)
+	s.SecretsManagerClient = secretsManagerMock
+
+	secretsManagerMock.EXPECT().DeleteSecret(gomock.Eq(&secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String("aws.cluster.x-k8s.io/a-0"),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+	secretsManagerMock.EXPECT().DeleteSecret(gomock.Eq(&secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String("aws.cluster.x-k8s.io/a-1"),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+
+	g.Expect(s.DeleteOwnedSecret(&services.BootstrapSecret{
+		Prefix: "aws.cluster.x-k8s.io/a",
+		Names:  []string{"aws.cluster.x-k8s.io/a-0", "aws.cluster.x-k8s.io/a-1"},
+	})).To(Succeed())
+}
+
+func newTestService(g *WithT) *Service {
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewFakeClientWithScheme(scheme),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{Region: "eu-west-1"},
+		},
+	})
+	g.Expect(err).To(BeNil())
+
+	return NewService(clusterScope)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroup
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/tags"
+)
+
+// AdoptSecurityGroups takes ownership of the existing security groups of the VPC given for each role, by tagging
+// them as owned by the cluster. The rules of their role are then added to them, while their existing ingress
+// rules are reported and kept, and they are deleted with the cluster, like the security groups created for the
+// other roles. In dry run mode, the tags are only reported.
+func (s *Service) AdoptSecurityGroups(groups map[infrav1.SecurityGroupRole]string, dryRun bool) ([]infrav1.AdoptedResource, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(groups))
+	for _, id := range groups {
+		ids = append(ids, id)
+	}
+	out, err := s.EC2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		GroupIds: aws.StringSlice(ids),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe security groups %v", ids)
+	}
+	existing := make(map[string]*ec2.SecurityGroup, len(out.SecurityGroups))
+	for _, sg := range out.SecurityGroups {
+		existing[*sg.GroupId] = sg
+	}
+
+	var adopted []infrav1.AdoptedResource
+	var params []infrav1.BuildParams
+	var current []infrav1.Tags
+	for _, role := range s.roles {
+		id, ok := groups[role]
+		if !ok {
+			continue
+		}
+		ec2sg, ok := existing[id]
+		if !ok {
+			return nil, errors.Errorf("security group %q does not exist", id)
+		}
+		if aws.StringValue(ec2sg.VpcId) != s.scope.VPC().ID {
+			return nil, errors.Errorf("security group %q is not in vpc %q", id, s.scope.VPC().ID)
+		}
+
+		sg := makeInfraSecurityGroup(ec2sg)
+		if owner := sg.Tags.GetOtherOwner(s.scope.Name()); owner != "" {
+			return nil, errors.Errorf("security group %q belongs to cluster %q", id, owner)
+		}
+
+		buildParams := s.getSecurityGroupTagParams(sg.Name, sg.ID, role)
+		var rules infrav1.IngressRules
+		for _, ec2rule := range ec2sg.IpPermissions {
+			rules = append(rules, ingressRuleFromSDKType(ec2rule))
+		}
+		adopted = append(adopted, infrav1.AdoptedResource{
+			Kind:         "SecurityGroup",
+			ID:           sg.ID,
+			Tags:         infrav1.Build(buildParams).Difference(sg.Tags),
+			IngressRules: rules,
+		})
+		params = append(params, buildParams)
+		current = append(current, sg.Tags)
+	}
+
+	if dryRun {
+		return adopted, nil
+	}
+
+	for i := range params {
+		tagsBuilder := tags.New(&params[i], tags.WithEC2(s.EC2Client))
+		if err := tagsBuilder.Ensure(current[i]); err != nil {
+			return nil, errors.Wrapf(err, "failed to tag security group %q", params[i].ResourceID)
+		}
+	}
+
+	s.scope.V(2).Info("Adopted security groups", "security-groups", groups)
+	return adopted, nil
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroup
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+)
+
+func TestAdoptSecurityGroups(t *testing.T) {
+	existingGroup := func() *ec2.SecurityGroup {
+		return &ec2.SecurityGroup{
+			GroupId:   aws.String("sg-nodes"),
+			GroupName: aws.String("nodes.kops.example.com"),
+			VpcId:     aws.String("vpc-1"),
+			Tags: []*ec2.Tag{
+				{Key: aws.String("KubernetesCluster"), Value: aws.String("kops.example.com")},
+			},
+			IpPermissions: []*ec2.IpPermission{customIPPermission()},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		group       func(sg *ec2.SecurityGroup)
+		dryRun      bool
+		expectError bool
+	}{
+		{
+			name:  "tags the security group as owned by the cluster",
+			group: func(sg *ec2.SecurityGroup) {},
+		},
+		{
+			name:   "only reports the tags in dry run mode",
+			group:  func(sg *ec2.SecurityGroup) {},
+			dryRun: true,
+		},
+		{
+			name: "rejects a security group of another vpc",
+			group: func(sg *ec2.SecurityGroup) {
+				sg.VpcId = aws.String("vpc-2")
+			},
+			expectError: true,
+		},
+		{
+			name: "rejects a security group of another cluster",
+			group: func(sg *ec2.SecurityGroup) {
+				sg.Tags = append(sg.Tags, &ec2.Tag{Key: aws.String(infrav1.ClusterTagKey("other-cluster")), Value: aws.String("owned")})
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-1"}},
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			group := existingGroup()
+			tc.group(group)
+			ec2Mock.EXPECT().DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: aws.StringSlice([]string{"sg-nodes"})}).
+				Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{group}}, nil)
+			if !tc.dryRun && !tc.expectError {
+				ec2Mock.EXPECT().CreateTags(gomock.Any()).
+					DoAndReturn(func(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+						g.Expect(aws.StringValueSlice(input.Resources)).To(Equal([]string{"sg-nodes"}))
+						g.Expect(input.Tags).To(HaveLen(3))
+						return &ec2.CreateTagsOutput{}, nil
+					})
+			}
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			adopted, err := s.AdoptSecurityGroups(map[infrav1.SecurityGroupRole]string{infrav1.SecurityGroupNode: "sg-nodes"}, tc.dryRun)
+			if tc.expectError {
+				g.Expect(err).NotTo(BeNil())
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(adopted).To(Equal([]infrav1.AdoptedResource{
+				{
+					Kind: "SecurityGroup",
+					ID:   "sg-nodes",
+					Tags: infrav1.Tags{
+						"Name":                                "nodes.kops.example.com",
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+						infrav1.NameAWSClusterAPIRole:         "node",
+					},
+					IngressRules: infrav1.IngressRules{customIngressRule()},
+				},
+			}))
+		})
+	}
+}
+
+func TestReconcileSecurityGroups_AdoptedSecurityGroup(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-1"}},
+			},
+			Status: infrav1.AWSClusterStatus{
+				Adoption: &infrav1.InfrastructureAdoptionStatus{
+					Mode:    infrav1.InfrastructureAdoptionModeAdopt,
+					Adopted: true,
+					Resources: []infrav1.AdoptedResource{
+						{Kind: "SecurityGroup", ID: "sg-nodes", IngressRules: infrav1.IngressRules{customIngressRule()}},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).To(BeNil())
+
+	// The custom rule of the adopted security group is kept, while a rule which the group didn't have
+	// when it was adopted is revoked.
+	ec2Mock.EXPECT().DescribeSecurityGroups(gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+		Return(&ec2.DescribeSecurityGroupsOutput{
+			SecurityGroups: []*ec2.SecurityGroup{
+				{
+					GroupId:   aws.String("sg-nodes"),
+					GroupName: aws.String("nodes.kops.example.com"),
+					VpcId:     aws.String("vpc-1"),
+					Tags: []*ec2.Tag{
+						{Key: aws.String("Name"), Value: aws.String("nodes.kops.example.com")},
+						{Key: aws.String(infrav1.ClusterTagKey("test-cluster")), Value: aws.String("owned")},
+						{Key: aws.String(infrav1.NameAWSClusterAPIRole), Value: aws.String("node")},
+					},
+					IpPermissions: []*ec2.IpPermission{
+						customIPPermission(),
+						{
+							IpProtocol: aws.String("tcp"),
+							FromPort:   aws.Int64(8080),
+							ToPort:     aws.Int64(8080),
+							IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0"), Description: aws.String("Legacy dashboard")}},
+						},
+					},
+				},
+			},
+		}, nil)
+	ec2Mock.EXPECT().RevokeSecurityGroupIngress(gomock.AssignableToTypeOf(&ec2.RevokeSecurityGroupIngressInput{})).
+		DoAndReturn(func(input *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+			g.Expect(input.IpPermissions).To(HaveLen(1))
+			g.Expect(*input.IpPermissions[0].FromPort).To(Equal(int64(8080)))
+			return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+		})
+	ec2Mock.EXPECT().AuthorizeSecurityGroupIngress(gomock.AssignableToTypeOf(&ec2.AuthorizeSecurityGroupIngressInput{})).
+		Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+
+	s := NewServiceWithRoles(scope, []infrav1.SecurityGroupRole{infrav1.SecurityGroupNode})
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.ReconcileSecurityGroups()).To(Succeed())
+	g.Expect(scope.SecurityGroups()[infrav1.SecurityGroupNode].ID).To(Equal("sg-nodes"))
+}
+
+// customIPPermission is a rule of an existing security group which the rules of its role don't allow.
+func customIPPermission() *ec2.IpPermission {
+	return &ec2.IpPermission{
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(9100),
+		ToPort:     aws.Int64(9100),
+		IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8"), Description: aws.String("Prometheus node exporter")}},
+	}
+}
+
+func customIngressRule() *infrav1.IngressRule {
+	return &infrav1.IngressRule{
+		Description: "Prometheus node exporter",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    9100,
+		ToPort:      9100,
+		CidrBlocks:  []string{"10.0.0.0/8"},
+	}
+}
@@ -77,6 +77,9 @@ func (s *Service) ReconcileSecurityGroups() error {
 		role := s.roles[i]
 		sg := s.getDefaultSecurityGroup(role)
 		existing, ok := sgs[*sg.GroupName]
+		if !ok {
+			existing, ok = s.findSecurityGroupByRole(sgs, role)
+		}
 
 		if !ok {
 			if err := s.createSecurityGroup(role, sg); err != nil {
@@ -128,7 +131,9 @@ func (s *Service) ReconcileSecurityGroups() error {
 			return err
 		}
 
-		toRevoke := current.Difference(want)
+		// The existing rules of an adopted security group are kept, so that adoption doesn't cut off traffic
+		// which the rules of its role don't allow.
+		toRevoke := current.Difference(want).Difference(s.scope.AdoptedIngressRules(sg.ID))
 		if len(toRevoke) > 0 {
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 				if err := s.revokeSecurityGroupIngressRules(sg.ID, toRevoke); err != nil {
@@ -282,6 +287,17 @@ func (s *Service) describeSecurityGroupsByName() (map[string]infrav1.SecurityGro
 	return res, nil
 }
 
+// findSecurityGroupByRole returns the security group owned by the cluster for the role whose name isn't
+// the default one, such as an adopted security group.
+func (s *Service) findSecurityGroupByRole(sgs map[string]infrav1.SecurityGroup, role infrav1.SecurityGroupRole) (infrav1.SecurityGroup, bool) {
+	for _, sg := range sgs {
+		if sg.Tags.HasOwned(s.scope.Name()) && sg.Tags.GetRole() == string(role) {
+			return sg, true
+		}
+	}
+	return infrav1.SecurityGroup{}, false
+}
+
 func makeInfraSecurityGroup(ec2sg *ec2.SecurityGroup) infrav1.SecurityGroup {
 	return infrav1.SecurityGroup{
 		ID:   *ec2sg.GroupId,
@@ -398,6 +414,10 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 
 	switch role {
 	case infrav1.SecurityGroupBastion:
+		// Session Manager sessions need no ingress.
+		if s.scope.Bastion().IsSSM() {
+			return infrav1.IngressRules{}, nil
+		}
 		return infrav1.IngressRules{
 			{
 				Description: "SSH",
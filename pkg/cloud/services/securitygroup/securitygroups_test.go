@@ -296,3 +296,32 @@ func TestControlPlaneSecurityGroupNotOpenToAnyCIDR(t *testing.T) {
 		}
 	}
 }
+
+func TestBastionSecurityGroupSessionManagerHasNoIngress(t *testing.T) {
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				Bastion: infrav1.Bastion{
+					Enabled:           true,
+					Mode:              infrav1.BastionModeSSM,
+					AllowedCIDRBlocks: []string{services.AnyIPv4CidrBlock},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	s := NewService(scope)
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupBastion)
+	if err != nil {
+		t.Fatalf("Failed to lookup bastion security group ingress rules: %v", err)
+	}
+	if len(rules) != 0 {
+		t.Fatalf("Expected no ingress rules, got %v", rules)
+	}
+}
@@ -37,6 +37,9 @@ type Scope interface {
 	// VPC returns the cluster VPC.
 	VPC() *infrav1.VPCSpec
 
+	// AdoptedIngressRules returns the existing ingress rules of the given security group when it has been adopted.
+	AdoptedIngressRules(groupID string) infrav1.IngressRules
+
 	// CNIIngressRules returns the CNI spec ingress rules.
 	CNIIngressRules() infrav1.CNIIngressRules
 
@@ -46,6 +46,7 @@ if [ "{{.Endpoint}}" != "" ]; then
 fi
 SECRET_PREFIX="{{.SecretPrefix}}"
 CHUNKS="{{.Chunks}}"
+KEEP_SECRETS="{{.KeepSecrets}}"
 FILE="/etc/secret-userdata.txt"
 FINAL_INDEX=$((CHUNKS - 1))
 
@@ -136,6 +137,10 @@ delete_secret_value() {
 }
 
 delete_secrets() {
+  if [ "${KEEP_SECRETS}" = "true" ]; then
+    log::info "secrets are shared with other instances and are not deleted"
+    return
+  fi
   for i in $(seq 0 ${FINAL_INDEX}); do
     delete_secret_value "$i"
   done
@@ -199,3 +204,112 @@ log::info "restarting cloud-init"
 systemctl restart cloud-init
 log::success_exit
 `
+
+// windowsSecretFetchScript fetches the userdata on Windows instances using the AWS Tools for PowerShell
+// included in the Windows AMIs, and runs it.
+// nolint
+const windowsSecretFetchScript = `<powershell>
+# Copyright 2020 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+$ErrorActionPreference = "Stop"
+
+$Region = "{{.Region}}"
+$Endpoint = "{{.Endpoint}}"
+$SecretPrefix = "{{.SecretPrefix}}"
+$Chunks = {{.Chunks}}
+$KeepSecrets = ${{.KeepSecrets}}
+$Dir = Join-Path $env:ProgramData "aws.cluster.x-k8s.io"
+$File = Join-Path $Dir "secret-userdata.ps1"
+
+$AWSParams = @{ Region = $Region }
+if ($Endpoint -ne "") {
+  $AWSParams.EndpointUrl = $Endpoint
+}
+
+# Print a status line.
+function Write-Info([string]$Message) {
+  Write-Output "+++ [$(Get-Date -Format o)] $Message"
+}
+
+# Log an error but keep going.
+function Write-Failure([string]$Message) {
+  Write-Error "!!! [$(Get-Date -Format o)] $Message" -ErrorAction Continue
+}
+
+function Remove-Secrets {
+  if ($KeepSecrets) {
+    Write-Info "secrets are shared with other instances and are not deleted"
+    return
+  }
+  Write-Info "deleting parameters from AWS Systems Manager"
+  for ($i = 0; $i -lt $Chunks; $i++) {
+    Remove-SSMParameter @AWSParams -Name "$SecretPrefix/$i" -Force
+  }
+}
+
+# SSM chunks are the base64 encoding of the gzipped userdata split into parameters.
+function Get-SecretValues {
+  Write-Info "getting userdata from AWS Systems Manager"
+  $Encoded = ""
+  for ($i = 0; $i -lt $Chunks; $i++) {
+    $Parameter = Get-SSMParameter @AWSParams -Name "$SecretPrefix/$i" -WithDecryption $true
+    $Encoded += $Parameter.Value
+  }
+  return ,(New-Object System.IO.MemoryStream(,[Convert]::FromBase64String($Encoded)))
+}
+
+# Decompress the userdata, strip the tags EC2Launch runs it in, and write it to a
+# directory only readable by administrators.
+function Write-UserData([System.IO.Stream]$Compressed) {
+  $Compressed.Position = 0
+  $Gzip = New-Object System.IO.Compression.GZipStream($Compressed, [System.IO.Compression.CompressionMode]::Decompress)
+  $Reader = New-Object System.IO.StreamReader($Gzip)
+  $UserData = $Reader.ReadToEnd()
+  $Reader.Close()
+  $UserData = $UserData -replace '^\s*<powershell>', '' -replace '</powershell>\s*$', ''
+
+  New-Item -ItemType Directory -Force -Path $Dir | Out-Null
+  icacls $Dir /inheritance:r /grant:r "Administrators:(OI)(CI)F" "SYSTEM:(OI)(CI)F" | Out-Null
+  Set-Content -Path $File -Value $UserData
+}
+
+Write-Info "aws.cluster.x-k8s.io encrypted userdata script started"
+Write-Info "secret prefix: $SecretPrefix"
+Write-Info "secret count: $Chunks"
+
+if (Test-Path $File) {
+  Write-Info "encrypted userdata already written to disk"
+  Write-Info "aws.cluster.x-k8s.io encrypted userdata script finished"
+  exit 0
+}
+
+try {
+  $Compressed = Get-SecretValues
+} catch {
+  Write-Failure "could not get secret value, deleting secret: $_"
+  Remove-Secrets
+  throw
+}
+
+Remove-Secrets
+
+Write-Info "decompressing userdata to $File"
+Write-UserData $Compressed
+
+Write-Info "running userdata"
+& $File
+Write-Info "aws.cluster.x-k8s.io encrypted userdata script finished"
+</powershell>
+`
@@ -18,16 +18,29 @@ package ssm
 
 import (
 	"bytes"
+	"fmt"
 	"net/mail"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ssm/mock_ssmiface"
 )
 
 func TestUserData(t *testing.T) {
 	service := Service{}
 	endpoints := []scope.ServiceEndpoint{}
-	doc, _ := service.UserData("secretARN", 1, "eu-west-1", endpoints)
+	doc, _ := service.UserData("secretARN", 1, false, "eu-west-1", endpoints)
 
 	_, err := mail.ReadMessage(bytes.NewBuffer(doc))
 	if err != nil {
@@ -44,10 +57,122 @@ func TestUserDataEndpoints(t *testing.T) {
 			ServiceID: "ssm",
 		},
 	}
-	doc, _ := service.UserData("secretARN", 1, "eu-west-1", endpoints)
+	doc, _ := service.UserData("secretARN", 1, false, "eu-west-1", endpoints)
 
 	_, err := mail.ReadMessage(bytes.NewBuffer(doc))
 	if err != nil {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
-}
\ No newline at end of file
+}
+func TestWindowsUserData(t *testing.T) {
+	service := Service{}
+	endpoints := []scope.ServiceEndpoint{
+		{
+			URL:           "https://localhost",
+			SigningRegion: "localhost",
+			ServiceID:     "ssm",
+		},
+	}
+	doc, err := service.WindowsUserData("secretARN", 2, false, "eu-west-1", endpoints)
+	if err != nil {
+		t.Fatalf("Cannot render script: %+v", err)
+	}
+
+	script := string(doc)
+	if !strings.HasPrefix(script, "<powershell>") || !strings.HasSuffix(strings.TrimSpace(script), "</powershell>") {
+		t.Fatalf("Script is not wrapped in powershell tags:\n%s", script)
+	}
+	for _, expected := range []string{`$SecretPrefix = "secretARN"`, `$Chunks = 2`, `$Region = "eu-west-1"`, `$Endpoint = "https://localhost"`} {
+		if !strings.Contains(script, expected) {
+			t.Fatalf("Expected script to contain %q:\n%s", expected, script)
+		}
+	}
+}
+
+func TestUserDataKeepSecrets(t *testing.T) {
+	service := Service{}
+	for _, keepSecrets := range []bool{true, false} {
+		doc, err := service.UserData("secretARN", 1, keepSecrets, "eu-west-1", nil)
+		if err != nil {
+			t.Fatalf("Cannot render MIME doc: %+v", err)
+		}
+		expected := fmt.Sprintf(`KEEP_SECRETS="%t"`, keepSecrets)
+		if !strings.Contains(string(doc), expected) {
+			t.Fatalf("Expected MIME doc to contain %q:\n%s", expected, string(doc))
+		}
+
+		doc, err = service.WindowsUserData("secretARN", 1, keepSecrets, "eu-west-1", nil)
+		if err != nil {
+			t.Fatalf("Cannot render script: %+v", err)
+		}
+		expected = fmt.Sprintf(`$KeepSecrets = $%t`, keepSecrets)
+		if !strings.Contains(string(doc), expected) {
+			t.Fatalf("Expected script to contain %q:\n%s", expected, string(doc))
+		}
+	}
+}
+
+func TestCreateKMSKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		kmsKeyID      string
+		expectedKeyID *string
+	}{
+		{
+			name: "uses the AWS managed key by default",
+		},
+		{
+			name:          "encrypts the secrets with the customer managed key",
+			kmsKeyID:      "alias/userdata",
+			expectedKeyID: aws.String("alias/userdata"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+
+			s := newTestService(g)
+			s.SSMClient = ssmMock
+			machineScope := newTestMachineScope(g, s)
+			machineScope.AWSMachine.Spec.CloudInit.KMSKeyID = tc.kmsKeyID
+
+			ssmMock.EXPECT().PutParameter(gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).
+				DoAndReturn(func(input *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+					g.Expect(input.KeyId).To(Equal(tc.expectedKeyID))
+					return &ssm.PutParameterOutput{}, nil
+				})
+
+			_, count, err := s.Create(machineScope, []byte("userdata"))
+			g.Expect(err).To(BeNil())
+			g.Expect(count).To(Equal(int32(1)))
+		})
+	}
+}
+
+func newTestMachineScope(g *WithT, s *Service) *scope.MachineScope {
+	clusterScope, ok := s.scope.(*scope.ClusterScope)
+	g.Expect(ok).To(BeTrue())
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:  fake.NewFakeClientWithScheme(scheme),
+		Cluster: clusterScope.Cluster,
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		},
+		AWSMachine: &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-test", Namespace: "default"},
+		},
+		InfraCluster: clusterScope,
+	})
+	g.Expect(err).To(BeNil())
+	return machineScope
+}
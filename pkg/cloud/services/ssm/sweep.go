@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssm
+
+import (
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+)
+
+// ListOwnedSecrets returns the sets of parameters holding bootstrap data which are owned by the cluster.
+// Parameters are listed without their tags, so the machine they were created for is unknown.
+func (s *Service) ListOwnedSecrets() ([]*services.BootstrapSecret, error) {
+	input := &ssm.DescribeParametersInput{
+		ParameterFilters: []*ssm.ParameterStringFilter{
+			{
+				Key:    aws.String("tag:" + infrav1.ClusterTagKey(s.scope.Name())),
+				Values: aws.StringSlice([]string{string(infrav1.ResourceLifecycleOwned)}),
+			},
+			{
+				Key:    aws.String("Name"),
+				Option: aws.String("BeginsWith"),
+				Values: aws.StringSlice([]string{"/" + prefixRe.ReplaceAllString(entryPrefix, "") + "/"}),
+			},
+		},
+	}
+
+	sets := map[string]*services.BootstrapSecret{}
+	var secrets []*services.BootstrapSecret
+	err := s.SSMClient.DescribeParametersPages(input, func(out *ssm.DescribeParametersOutput, last bool) bool {
+		for _, parameter := range out.Parameters {
+			name := aws.StringValue(parameter.Name)
+			prefix := path.Dir(name)
+			set, ok := sets[prefix]
+			if !ok {
+				set = &services.BootstrapSecret{Prefix: prefix}
+				sets[prefix] = set
+				secrets = append(secrets, set)
+			}
+			set.Names = append(set.Names, name)
+			if modified := aws.TimeValue(parameter.LastModifiedDate); modified.After(set.LastModified) {
+				set.LastModified = modified
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list parameters of cluster %q", s.scope.Name())
+	}
+
+	return secrets, nil
+}
+
+// DeleteOwnedSecret deletes a set of parameters returned by ListOwnedSecrets.
+func (s *Service) DeleteOwnedSecret(secret *services.BootstrapSecret) error {
+	var errs []error
+	for _, name := range secret.Names {
+		if err := s.forceDeleteSecretEntry(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
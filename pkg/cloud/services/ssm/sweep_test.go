@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/services/ssm/mock_ssmiface"
+)
+
+func TestListOwnedSecrets(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+
+	s := newTestService(g)
+	s.SSMClient = ssmMock
+
+	modified := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	ssmMock.EXPECT().DescribeParametersPages(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(input *ssm.DescribeParametersInput, fn func(*ssm.DescribeParametersOutput, bool) bool) error {
+			g.Expect(aws.StringValue(input.ParameterFilters[0].Key)).To(Equal("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"))
+			g.Expect(aws.StringValueSlice(input.ParameterFilters[1].Values)).To(Equal([]string{"/cluster.x-k8s.io/"}))
+			fn(&ssm.DescribeParametersOutput{Parameters: []*ssm.ParameterMetadata{
+				{Name: aws.String("/cluster.x-k8s.io/a/0"), LastModifiedDate: aws.Time(modified)},
+				{Name: aws.String("/cluster.x-k8s.io/b/0"), LastModifiedDate: aws.Time(modified)},
+			}}, false)
+			fn(&ssm.DescribeParametersOutput{Parameters: []*ssm.ParameterMetadata{
+				{Name: aws.String("/cluster.x-k8s.io/a/1"), LastModifiedDate: aws.Time(modified.Add(time.Second))},
+			}}, true)
+			return nil
+		})
+
+	secrets, err := s.ListOwnedSecrets()
+	g.Expect(err).To(BeNil())
+	g.Expect(secrets).To(Equal([]*services.BootstrapSecret{
+		{
+			Prefix:       "/cluster.x-k8s.io/a",
+			Names:        []string{"/cluster.x-k8s.io/a/0", "/cluster.x-k8s.io/a/1"},
+			LastModified: modified.Add(time.Second),
+		},
+		{
+			Prefix:       "/cluster.x-k8s.io/b",
+			Names:        []string{"/cluster.x-k8s.io/b/0"},
+			LastModified: modified,
+		},
+	}))
+}
+
+func TestDeleteOwnedSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+
+	s := newTestService(g)
+	s.SSMClient = ssmMock
+
+	ssmMock.EXPECT().DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{Name: aws.String("/cluster.x-k8s.io/a/0")})).
+		Return(&ssm.DeleteParameterOutput{}, nil)
+	ssmMock.EXPECT().DeleteParameter(gomock.Eq(&ssm.DeleteParameterInput{Name: aws.String("/cluster.x-k8s.io/a/1")})).
+		Return(nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil))
+
+	g.Expect(s.DeleteOwnedSecret(&services.BootstrapSecret{
+		Prefix: "/cluster.x-k8s.io/a",
+		Names:  []string{"/cluster.x-k8s.io/a/0", "/cluster.x-k8s.io/a/1"},
+	})).To(Succeed())
+}
+
+func newTestService(g *WithT) *Service {
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewFakeClientWithScheme(scheme),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{Region: "eu-west-1"},
+		},
+	})
+	g.Expect(err).To(BeNil())
+
+	return NewService(clusterScope)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// MaxUserDataSize is the maximum size of the userdata of an EC2 instance, before base64 encoding.
+const MaxUserDataSize = 16384
+
+// ignitionConfig is the part of an Ignition config common to spec versions 2 and 3, used by
+// Flatcar Container Linux and Fedora CoreOS respectively.
+type ignitionConfig struct {
+	Ignition ignitionSection `json:"ignition"`
+}
+
+type ignitionSection struct {
+	Version string                `json:"version"`
+	Config  *ignitionConfigSource `json:"config,omitempty"`
+}
+
+type ignitionConfigSource struct {
+	Replace *ignitionConfigReference `json:"replace,omitempty"`
+}
+
+type ignitionConfigReference struct {
+	Source string `json:"source"`
+}
+
+// IgnitionVersion returns the spec version of an Ignition config.
+func IgnitionVersion(config []byte) (string, error) {
+	var c ignitionConfig
+	if err := json.Unmarshal(config, &c); err != nil {
+		return "", errors.Wrap(err, "bootstrap data is not an Ignition config")
+	}
+	if c.Ignition.Version == "" {
+		return "", errors.New("bootstrap data is not an Ignition config: ignition.version is not set")
+	}
+	return c.Ignition.Version, nil
+}
+
+// IgnitionReplace returns an Ignition config which Ignition replaces with the config found at
+// source. It uses the spec version of config, as Ignition requires both to have the same major version.
+func IgnitionReplace(config []byte, source string) ([]byte, error) {
+	version, err := IgnitionVersion(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(ignitionConfig{
+		Ignition: ignitionSection{
+			Version: version,
+			Config: &ignitionConfigSource{
+				Replace: &ignitionConfigReference{Source: source},
+			},
+		},
+	})
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestIgnitionReplace(t *testing.T) {
+	g := NewWithT(t)
+
+	config := []byte(`{"ignition":{"version":"2.3.0"},"storage":{"files":[{"path":"/etc/kubernetes/kubeadm.yaml"}]}}`)
+	replace, err := IgnitionReplace(config, "s3://bucket/node/machine-0")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(replace)).To(Equal(`{"ignition":{"version":"2.3.0","config":{"replace":{"source":"s3://bucket/node/machine-0"}}}}`))
+
+	_, err = IgnitionReplace([]byte("#cloud-config\n"), "s3://bucket/node/machine-0")
+	g.Expect(err).NotTo(BeNil())
+
+	_, err = IgnitionReplace([]byte(`{"storage":{}}`), "s3://bucket/node/machine-0")
+	g.Expect(err).NotTo(BeNil())
+}
@@ -55,3 +55,25 @@ func GzipBytes(dat []byte) ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
+
+const (
+	powerShellOpenTag  = "<powershell>"
+	powerShellCloseTag = "</powershell>"
+)
+
+// PowerShell wraps a script in the tags EC2Launch uses to run userdata on Windows instances,
+// unless it is wrapped already.
+func PowerShell(script []byte) []byte {
+	if bytes.HasPrefix(bytes.TrimSpace(script), []byte(powerShellOpenTag)) {
+		return script
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(powerShellOpenTag + "\n")
+	buf.Write(script)
+	if !bytes.HasSuffix(script, []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	buf.WriteString(powerShellCloseTag + "\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPowerShell(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		expect string
+	}{
+		{
+			name:   "wraps script",
+			script: "Write-Output hello",
+			expect: "<powershell>\nWrite-Output hello\n</powershell>\n",
+		},
+		{
+			name:   "wraps script ending with a newline",
+			script: "Write-Output hello\n",
+			expect: "<powershell>\nWrite-Output hello\n</powershell>\n",
+		},
+		{
+			name:   "keeps wrapped script",
+			script: "<powershell>\nWrite-Output hello\n</powershell>\n<persist>true</persist>\n",
+			expect: "<powershell>\nWrite-Output hello\n</powershell>\n<persist>true</persist>\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(string(PowerShell([]byte(tc.script)))).To(Equal(tc.expect))
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"mime/multipart"
 	"net/textproto"
 	"strings"
+	texttemplate "text/template"
 )
 
 const (
@@ -48,14 +49,16 @@ var (
 type scriptVariables struct {
 	SecretPrefix string
 	Chunks       int32
+	KeepSecrets  bool
 	Region       string
 	Endpoint     string
 }
 
 // GenerateInitDocument renders a given template, applies MIME properties
 // and returns a series of byte chunks which put together represent a UserData
-// script
-func GenerateInitDocument(secretPrefix string, chunks int32, region string, endpoint string, secretFetchScript string) ([]byte, error) {
+// script. When keepSecrets is set, the script leaves the secrets in place once
+// fetched, as they are shared with other instances.
+func GenerateInitDocument(secretPrefix string, chunks int32, keepSecrets bool, region string, endpoint string, secretFetchScript string) ([]byte, error) {
 	var secretFetchTemplate = template.Must(template.New("secret-fetch-script").Parse(secretFetchScript))
 
 	var buf bytes.Buffer
@@ -69,6 +72,7 @@ func GenerateInitDocument(secretPrefix string, chunks int32, region string, endp
 	scriptVariables := scriptVariables{
 		SecretPrefix: secretPrefix,
 		Chunks:       chunks,
+		KeepSecrets:  keepSecrets,
 		Region:       region,
 		Endpoint:     endpoint,
 	}
@@ -98,3 +102,25 @@ func GenerateInitDocument(secretPrefix string, chunks int32, region string, endp
 
 	return buf.Bytes(), nil
 }
+
+// GenerateScript renders a given template as a plain script, for instances
+// which don't run cloud-init and so can't process a MIME document, such as
+// Windows instances
+func GenerateScript(secretPrefix string, chunks int32, keepSecrets bool, region string, endpoint string, secretFetchScript string) ([]byte, error) {
+	var secretFetchTemplate = texttemplate.Must(texttemplate.New("secret-fetch-script").Parse(secretFetchScript))
+
+	scriptVariables := scriptVariables{
+		SecretPrefix: secretPrefix,
+		Chunks:       chunks,
+		KeepSecrets:  keepSecrets,
+		Region:       region,
+		Endpoint:     endpoint,
+	}
+
+	var scriptBuf bytes.Buffer
+	if err := secretFetchTemplate.Execute(&scriptBuf, scriptVariables); err != nil {
+		return []byte{}, err
+	}
+
+	return scriptBuf.Bytes(), nil
+}
@@ -24,10 +24,20 @@ import (
 
 func TestGenerateInitDocument(t *testing.T) {
 	secretARN := "secretARN"
-	doc, _ := GenerateInitDocument(secretARN, 1, "eu-west-1", "localhost", "abc123")
+	doc, _ := GenerateInitDocument(secretARN, 1, false, "eu-west-1", "localhost", "abc123")
 
 	_, err := mail.ReadMessage(bytes.NewBuffer(doc))
 	if err != nil {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
 }
+
+func TestGenerateScript(t *testing.T) {
+	doc, err := GenerateScript("secretARN", 2, false, "eu-west-1", "https://localhost", "<powershell>{{.SecretPrefix}} {{.Chunks}} {{.Region}} {{.Endpoint}}</powershell>")
+	if err != nil {
+		t.Fatalf("Cannot render script: %+v", err)
+	}
+	if expected := "<powershell>secretARN 2 eu-west-1 https://localhost</powershell>"; string(doc) != expected {
+		t.Fatalf("Expected script %q, got %q", expected, string(doc))
+	}
+}